@@ -10,12 +10,15 @@ import (
 	"os/signal"
 	"runtime/debug"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	_ "embed"
 
 	"github.com/clintjedwards/todo/internal/config"
+	"github.com/clintjedwards/todo/internal/metrics"
 	"github.com/clintjedwards/todo/internal/storage"
 	proto "github.com/clintjedwards/todo/proto"
 	"github.com/go-chi/chi/v5/middleware"
@@ -24,10 +27,13 @@ import (
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
@@ -44,10 +50,27 @@ type API struct {
 
 	// Storage represents the main backend storage implementation. Todo stores most of its critical state information
 	// using this storage mechanism.
-	db storage.DB
+	db storage.Engine
 
-	// A map of all scheduled tasks so we can quickly quit the goroutine when the user requests.e
-	scheduledTasks map[string]context.CancelFunc
+	// A map of all scheduled tasks so we can quickly quit the goroutine when the user requests. Scheduled tasks that
+	// should be running but couldn't be started are kept in schedulerFailures instead; both are guarded by
+	// scheduledTasksMu.
+	scheduledTasks    map[string]context.CancelFunc
+	schedulerFailures map[string]struct{}
+	scheduledTasksMu  sync.Mutex
+
+	// schedulerRestored is set once all scheduled tasks have been loaded from the database on startup.
+	schedulerRestored atomic.Bool
+
+	// Held while attachment contents are added to or removed from the attachment directory so that a file isn't
+	// removed as unused just as a new attachment with the same contents is being added.
+	attachmentsMu sync.Mutex
+
+	// health tracks the serving status reported through the standard GRPC health checking protocol.
+	health *health.Server
+
+	// When the API was started; used to report uptime.
+	started time.Time
 
 	// We opt out of forward compatibility with this embedded interface. This is required by GRPC.
 	//
@@ -59,11 +82,14 @@ type API struct {
 }
 
 // NewAPI creates a new instance of the main Todo API service.
-func NewAPI(config *config.API, storage storage.DB) (*API, error) {
+func NewAPI(config *config.API, storage storage.Engine) (*API, error) {
 	newAPI := &API{
-		config:         config,
-		db:             storage,
-		scheduledTasks: map[string]context.CancelFunc{},
+		config:            config,
+		db:                storage,
+		scheduledTasks:    map[string]context.CancelFunc{},
+		schedulerFailures: map[string]struct{}{},
+		health:            health.NewServer(),
+		started:           time.Now(),
 	}
 
 	err := newAPI.restoreReoccurringTasks()
@@ -86,9 +112,31 @@ func (api *API) StartAPIService() {
 		log.Fatal().Err(err).Msg("could not get proper TLS config")
 	}
 
-	httpServer := wrapGRPCServer(api.config, grpcServer)
+	router := mux.NewRouter()
+	router.HandleFunc("/healthz", api.healthzHandler)
+	router.HandleFunc("/readyz", api.readyzHandler)
+
+	if api.config.Server.MetricsEnabled {
+		metrics.Registry.MustRegister(newTaskCollector(api.db))
+		router.Handle("/metrics", promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}))
+		log.Info().Msg("metrics enabled at /metrics")
+	}
+
+	httpServer := wrapGRPCServer(api.config, grpcServer, router)
 	httpServer.TLSConfig = tlsConfig
 
+	if api.config.Server.BackupDir != "" {
+		go api.runAutomaticBackups()
+		log.Info().Str("dir", api.config.Server.BackupDir).Dur("interval", api.config.Server.BackupInterval).
+			Int("retention", api.config.Server.BackupRetention).Msg("automatic backups enabled")
+	}
+
+	if api.config.Server.ArchivePolicy != archivePolicyNone {
+		go api.runArchivePolicy()
+		log.Info().Str("policy", api.config.Server.ArchivePolicy).Dur("after", api.config.Server.ArchiveAfter).
+			Dur("interval", api.config.Server.ArchiveInterval).Msg("archive policy enabled")
+	}
+
 	// Run our server in a goroutine and listen for signals that indicate graceful shutdown
 	go func() {
 		if err := httpServer.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
@@ -97,10 +145,17 @@ func (api *API) StartAPIService() {
 	}()
 	log.Info().Str("url", api.config.Server.Host).Msg("started todo grpc/http service")
 
+	readinessCtx, stopReadiness := context.WithCancel(context.Background())
+	go api.watchReadiness(readinessCtx)
+
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, syscall.SIGTERM, syscall.SIGINT)
 	<-c
 
+	// Let anything probing us know we're going away before we stop accepting connections.
+	stopReadiness()
+	api.health.Shutdown()
+
 	// Doesn't block if no connections, otherwise will wait until the timeout deadline or connections to finish,
 	// whichever comes first.
 	ctx, cancel := context.WithTimeout(context.Background(), api.config.Server.ShutdownTimeout) // shutdown gracefully
@@ -139,11 +194,9 @@ func loggingMiddleware(next http.Handler) http.Handler {
 // Rather than going through the trouble of setting up a separate proxy and extra for the service in order to server http/grpc/grpc-web
 // this keeps things simple by enabling the operator to deploy a single binary and serve them all from one endpoint.
 // This reduces operational burden, configuration headache and overall just makes for a better time for both client and operator.
-func wrapGRPCServer(config *config.API, grpcServer *grpc.Server) *http.Server {
+func wrapGRPCServer(config *config.API, grpcServer *grpc.Server, router *mux.Router) *http.Server {
 	wrappedGrpc := grpcweb.WrapServer(grpcServer)
 
-	router := mux.NewRouter()
-
 	// Define GRPC/HTTP request detection middleware
 	GRPCandHTTPHandler := http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
 		if strings.Contains(req.Header.Get("Content-Type"), "application/grpc") || wrappedGrpc.IsGrpcWebRequest(req) {
@@ -179,24 +232,29 @@ func (api *API) createGRPCServer() (*grpc.Server, error) {
 		return status.Errorf(codes.Unknown, "server has encountered a fatal error and could not process request")
 	}
 
+	// recovery should always be first
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		grpc_recovery.UnaryServerInterceptor(grpc_recovery.WithRecoveryHandler(panicHandler)),
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		grpc_recovery.StreamServerInterceptor(grpc_recovery.WithRecoveryHandler(panicHandler)),
+	}
+
+	if api.config.Server.MetricsEnabled {
+		unaryInterceptors = append(unaryInterceptors, metricsUnaryInterceptor)
+		streamInterceptors = append(streamInterceptors, metricsStreamInterceptor)
+	}
+
 	grpcServer := grpc.NewServer(
-		// recovery should always be first
-		grpc.UnaryInterceptor(
-			grpc_middleware.ChainUnaryServer(
-				grpc_recovery.UnaryServerInterceptor(grpc_recovery.WithRecoveryHandler(panicHandler)),
-			),
-		),
-		grpc.StreamInterceptor(
-			grpc_middleware.ChainStreamServer(
-				grpc_recovery.StreamServerInterceptor(grpc_recovery.WithRecoveryHandler(panicHandler)),
-			),
-		),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
 
 		// Handle TLS
 		grpc.Creds(credentials.NewTLS(tlsConfig)),
 	)
 
 	reflection.Register(grpcServer)
+	healthpb.RegisterHealthServer(grpcServer, api.health)
 	proto.RegisterTodoServer(grpcServer, api)
 
 	return grpcServer, nil
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"time"
+
+	"github.com/clintjedwards/todo/internal/models"
+	"github.com/clintjedwards/todo/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	archivePolicyNone   = "none"
+	archivePolicyDelete = "delete"
+)
+
+// runArchivePolicy applies the archive policy every archive interval. It blocks forever and is meant to be run in its
+// own goroutine.
+func (api *API) runArchivePolicy() {
+	ticker := time.NewTicker(api.config.Server.ArchiveInterval)
+	defer ticker.Stop()
+
+	for {
+		cleaned, err := api.applyArchivePolicy(time.Now())
+		if err != nil {
+			log.Error().Err(err).Msg("could not apply archive policy")
+		} else if cleaned > 0 {
+			log.Info().Int("tasks", cleaned).Str("policy", api.config.Server.ArchivePolicy).
+				Msg("applied archive policy to completed tasks")
+		}
+
+		<-ticker.C
+	}
+}
+
+// applyArchivePolicy archives or deletes, depending on the policy, every top level task that has been completed for
+// longer than archive_after along with all of its children. It returns the number of tasks cleaned up.
+//
+// Trees with a task that has since been reopened are left alone. So are trees holding the latest task created by a
+// scheduled task, since scheduled tasks look at it to work out when they next come due.
+func (api *API) applyArchivePolicy(now time.Time) (int, error) {
+	ids, err := api.db.FindTaskIDsMatching(api.db, storage.TaskFilter{
+		State:           string(models.TaskStateCompleted),
+		CompletedBefore: now.Add(-api.config.Server.ArchiveAfter).UnixMilli(),
+		TopLevel:        true,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	cleaned := 0
+	for _, id := range ids {
+		var digests []string
+		treeCleaned := 0
+
+		err := api.db.InsideTx(func(tx storage.Queryable) error {
+			tree, err := api.taskTree(tx, id)
+			if err != nil {
+				return err
+			}
+
+			for _, task := range tree {
+				if task.State != string(models.TaskStateCompleted) {
+					return nil
+				}
+
+				// Scheduled tasks can create tasks beneath other tasks, so every task in the tree is checked.
+				if task.ScheduledTaskID == "" {
+					continue
+				}
+
+				latest, err := api.db.GetLatestScheduledTaskInstance(tx, task.ScheduledTaskID)
+				if err != nil {
+					return err
+				}
+
+				if latest.ID == task.ID {
+					return nil
+				}
+			}
+
+			treeIDs := []string{}
+			for _, task := range tree {
+				treeIDs = append(treeIDs, task.ID)
+			}
+
+			for _, id := range treeIDs {
+				if api.config.Server.ArchivePolicy == archivePolicyDelete {
+					err = api.db.DeleteTask(tx, id)
+					if err != nil {
+						return err
+					}
+
+					err = api.db.DeleteTaskComments(tx, id)
+				} else {
+					err = api.db.ArchiveTask(tx, id, now.UnixMilli())
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			if api.config.Server.ArchivePolicy == archivePolicyDelete {
+				digests, err = api.deleteAttachments(tx, treeIDs)
+				if err != nil {
+					return err
+				}
+			}
+
+			treeCleaned = len(tree)
+			return nil
+		})
+		if err != nil {
+			return cleaned, err
+		}
+
+		cleaned += treeCleaned
+		api.removeUnusedBlobs(digests)
+	}
+
+	return cleaned, nil
+}
+
+// taskTree returns the task given followed by every task beneath it.
+func (api *API) taskTree(tx storage.Queryable, id string) ([]storage.Task, error) {
+	task, err := api.db.GetTask(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := []storage.Task{task}
+	for i := 0; i < len(tree); i++ {
+		children, err := api.db.GetTaskChildren(tx, tree[i].ID)
+		if err != nil {
+			return nil, err
+		}
+
+		tree = append(tree, children...)
+	}
+
+	return tree, nil
+}
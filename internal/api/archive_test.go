@@ -0,0 +1,57 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clintjedwards/todo/internal/models"
+	"github.com/clintjedwards/todo/internal/storage"
+)
+
+func TestApplyArchivePolicy(t *testing.T) {
+	api := newTestAPI(t)
+	api.config.Server.ArchivePolicy = "archive"
+	api.config.Server.ArchiveAfter = time.Hour
+
+	completed := string(models.TaskStateCompleted)
+
+	// plain -> plain child, and held -> scheduled, where scheduled is the latest task its scheduled task created.
+	tasks := []storage.Task{
+		{ID: "plain", Title: "plain", State: completed, CompletedAt: 1},
+		{ID: "plain_child", Title: "plain child", State: completed, CompletedAt: 1, Parent: "plain"},
+		{ID: "held", Title: "held", State: completed, CompletedAt: 1},
+		{ID: "scheduled", Title: "scheduled", State: completed, CompletedAt: 1, Parent: "held",
+			ScheduledTaskID: "sched", Created: 2},
+		{ID: "older", Title: "older", State: completed, CompletedAt: 1, ScheduledTaskID: "sched", Created: 1},
+	}
+
+	for _, task := range tasks {
+		err := api.db.InsertTask(api.db, &task)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cleaned, err := api.applyArchivePolicy(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cleaned != 3 {
+		t.Errorf("expected plain, its child and the older scheduled task to be archived; got %d tasks", cleaned)
+	}
+
+	for _, id := range []string{"held", "scheduled"} {
+		_, err := api.db.GetTask(api.db, id)
+		if err != nil {
+			t.Errorf("expected %s to be left alone since it holds the latest scheduled task: %v", id, err)
+		}
+	}
+
+	for _, id := range []string{"plain", "plain_child", "older"} {
+		_, err := api.db.GetArchivedTask(api.db, id)
+		if err != nil {
+			t.Errorf("expected %s to be archived: %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/clintjedwards/todo/internal/storage"
+	proto "github.com/clintjedwards/todo/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (api *API) ListArchivedTasks(ctx context.Context, request *proto.ListArchivedTasksRequest) (*proto.ListArchivedTasksResponse, error) {
+	tasks, err := api.db.ListArchivedTasks(api.db, int(request.Offset), int(request.Limit))
+	if err != nil {
+		log.Error().Err(err).Msg("could not get archived tasks")
+		return nil, status.Error(codes.Internal, "failed to retrieve archived tasks from database")
+	}
+
+	protoTasks := []*proto.Task{}
+	for _, task := range tasks {
+		protoTasks = append(protoTasks, task.ToProto())
+	}
+
+	return &proto.ListArchivedTasksResponse{
+		Tasks: protoTasks,
+	}, nil
+}
+
+func (api *API) RestoreArchivedTask(ctx context.Context, request *proto.RestoreArchivedTaskRequest) (*proto.RestoreArchivedTaskResponse, error) {
+	id, err := resolveID("archived task", request.Id, func(prefix string, limit int) ([]string, error) {
+		return api.db.FindArchivedTaskIDs(api.db, prefix, limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	restored := []string{}
+	err = api.db.InsideTx(func(tx storage.Queryable) error {
+		// Trees are archived whole, so restore from the top of the tree the task given belongs to.
+		root, err := api.db.GetArchivedTask(tx, id)
+		if err != nil {
+			return err
+		}
+
+		for root.Parent != "" {
+			parent, err := api.db.GetArchivedTask(tx, root.Parent)
+			if errors.Is(err, storage.ErrEntityNotFound) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			root = parent
+		}
+
+		restored = append(restored, root.ID)
+		for i := 0; i < len(restored); i++ {
+			children, err := api.db.GetArchivedTaskChildren(tx, restored[i])
+			if err != nil {
+				return err
+			}
+
+			for _, child := range children {
+				restored = append(restored, child.ID)
+			}
+		}
+
+		for _, id := range restored {
+			err := api.db.RestoreArchivedTask(tx, id)
+			if err != nil {
+				if errors.Is(err, storage.ErrEntityExists) {
+					return status.Errorf(codes.FailedPrecondition,
+						"could not restore task %s; a task with the same id has since been created", id)
+				}
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return nil, err
+		}
+		log.Error().Err(err).Str("id", id).Msg("could not restore archived task")
+		return nil, status.Errorf(codes.Internal, "could not restore archived task %s", id)
+	}
+
+	log.Info().Strs("ids", restored).Msg("restored archived tasks")
+
+	return &proto.RestoreArchivedTaskResponse{RestoredIds: restored}, nil
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/clintjedwards/todo/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// The most bytes sent in a single message when downloading an attachment.
+const attachmentChunkSize = 64 << 10
+
+// errAttachmentTooLarge is returned when a file being attached goes past the space it is allowed.
+var errAttachmentTooLarge = errors.New("attachment too large")
+
+// attachmentDir returns the directory attachment contents are kept in, or an empty string if there isn't one.
+func (api *API) attachmentDir() string {
+	if api.config.Server.AttachmentDir != "" {
+		return api.config.Server.AttachmentDir
+	}
+
+	if api.config.Server.StorageDriver == string(storage.DriverSQLite) {
+		return filepath.Join(filepath.Dir(api.config.Server.StoragePath), "attachments")
+	}
+
+	return ""
+}
+
+// blobPath returns where contents with the digest given are kept. Files are spread over subdirectories named by the
+// first two characters of their digest so that no one directory gets too large.
+func blobPath(dir, digest string) string {
+	return filepath.Join(dir, digest[:2], digest)
+}
+
+// blobWriter writes the contents of a new attachment to a temporary file in the attachment directory, hashing them as
+// they're written and refusing to write more than its limit.
+type blobWriter struct {
+	file    *os.File
+	hash    hash.Hash
+	size    int64
+	limit   int64
+	dir     string
+	settled bool
+}
+
+func newBlobWriter(dir string, limit int64) (*blobWriter, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("could not create attachment directory: %w", err)
+	}
+
+	file, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("could not create attachment file: %w", err)
+	}
+
+	return &blobWriter{
+		file:  file,
+		hash:  sha256.New(),
+		limit: limit,
+		dir:   dir,
+	}, nil
+}
+
+func (w *blobWriter) Write(p []byte) (int, error) {
+	if w.size+int64(len(p)) > w.limit {
+		return 0, errAttachmentTooLarge
+	}
+
+	n, err := io.MultiWriter(w.file, w.hash).Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// commit moves the contents written into place under their digest and returns it. Contents that are already stored
+// are kept as they are. The caller must hold attachmentsMu.
+func (w *blobWriter) commit() (string, error) {
+	w.settled = true
+
+	err := w.file.Close()
+	if err != nil {
+		os.Remove(w.file.Name())
+		return "", err
+	}
+
+	digest := hex.EncodeToString(w.hash.Sum(nil))
+	path := blobPath(w.dir, digest)
+
+	if _, err := os.Stat(path); err == nil {
+		os.Remove(w.file.Name())
+		return digest, nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		os.Remove(w.file.Name())
+		return "", err
+	}
+
+	err = os.Rename(w.file.Name(), path)
+	if err != nil {
+		os.Remove(w.file.Name())
+		return "", err
+	}
+
+	return digest, nil
+}
+
+// discard removes the temporary file unless the contents have already been committed.
+func (w *blobWriter) discard() {
+	if w.settled {
+		return
+	}
+
+	w.settled = true
+	w.file.Close()
+	os.Remove(w.file.Name())
+}
+
+// deleteAttachments removes the attachments on every task given and returns the digests of their contents, which
+// should be passed to removeUnusedBlobs once the transaction has been committed.
+func (api *API) deleteAttachments(tx storage.Queryable, taskIDs []string) ([]string, error) {
+	digests := []string{}
+	for _, id := range taskIDs {
+		attachments, err := api.db.ListAttachments(tx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(attachments) == 0 {
+			continue
+		}
+
+		for _, attachment := range attachments {
+			digests = append(digests, attachment.Digest)
+		}
+
+		err = api.db.DeleteTaskAttachments(tx, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return digests, nil
+}
+
+// removeUnusedBlobs removes the stored contents for each digest given that no attachment uses anymore, and that no
+// operation in the journal would need to put back if it were undone. Failures are only logged since the attachments
+// themselves are already gone.
+func (api *API) removeUnusedBlobs(digests []string) {
+	if len(digests) == 0 {
+		return
+	}
+
+	dir := api.attachmentDir()
+	if dir == "" {
+		return
+	}
+
+	api.attachmentsMu.Lock()
+	defer api.attachmentsMu.Unlock()
+
+	journaled, err := api.journaledDigests()
+	if err != nil {
+		log.Error().Err(err).Msg("could not check which attachment contents the journal needs")
+		return
+	}
+
+	for _, digest := range digests {
+		if journaled[digest] {
+			continue
+		}
+
+		count, err := api.db.CountAttachmentsWithDigest(api.db, digest)
+		if err != nil {
+			log.Error().Err(err).Str("digest", digest).Msg("could not check whether attachment contents are in use")
+			continue
+		}
+
+		if count > 0 {
+			continue
+		}
+
+		err = os.Remove(blobPath(dir, digest))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Error().Err(err).Str("digest", digest).Msg("could not remove unused attachment contents")
+			continue
+		}
+
+		log.Debug().Str("digest", digest).Msg("removed unused attachment contents")
+	}
+}
@@ -0,0 +1,225 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/clintjedwards/todo/internal/models"
+	"github.com/clintjedwards/todo/internal/storage"
+	proto "github.com/clintjedwards/todo/proto"
+	"github.com/dustin/go-humanize"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (api *API) UploadAttachment(stream grpc.ClientStreamingServer[proto.UploadAttachmentRequest, proto.UploadAttachmentResponse]) error {
+	dir := api.attachmentDir()
+	if dir == "" {
+		return status.Error(codes.FailedPrecondition,
+			"attachments are unavailable; server.attachment_dir must be set to use them with this storage driver")
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, "attachment details required")
+	}
+
+	details := first.GetDetails()
+	if details == nil {
+		return status.Error(codes.FailedPrecondition, "attachment details must be sent before its contents")
+	}
+
+	name := filepath.Base(strings.ReplaceAll(details.Name, `\`, "/"))
+	if name == "" || name == "." || name == "/" {
+		return status.Error(codes.FailedPrecondition, "file name required")
+	}
+
+	taskID, err := api.resolveTaskID(details.TaskId)
+	if err != nil {
+		return err
+	}
+
+	existing, err := api.db.ListAttachments(api.db, taskID)
+	if err != nil {
+		log.Error().Err(err).Str("task_id", taskID).Msg("could not get attachments")
+		return status.Error(codes.Internal, "failed to retrieve attachments from database")
+	}
+
+	var used int64
+	for _, attachment := range existing {
+		used += attachment.Size
+	}
+
+	limit := min(api.config.Server.AttachmentMaxSize, api.config.Server.AttachmentMaxTaskSize-used)
+	if limit <= 0 {
+		return status.Errorf(codes.FailedPrecondition, "task's attachments already take up the %s allowed",
+			humanize.IBytes(uint64(api.config.Server.AttachmentMaxTaskSize)))
+	}
+
+	writer, err := newBlobWriter(dir, limit)
+	if err != nil {
+		log.Error().Err(err).Msg("could not store attachment")
+		return status.Error(codes.Internal, "could not store attachment")
+	}
+	defer writer.discard()
+
+	for {
+		message, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = writer.Write(message.GetChunk())
+		if err != nil {
+			if errors.Is(err, errAttachmentTooLarge) {
+				if limit < api.config.Server.AttachmentMaxSize {
+					return status.Errorf(codes.FailedPrecondition,
+						"file is larger than the %s left of the %s each task's attachments may take up",
+						humanize.IBytes(uint64(limit)), humanize.IBytes(uint64(api.config.Server.AttachmentMaxTaskSize)))
+				}
+				return status.Errorf(codes.FailedPrecondition, "file is larger than the %s allowed",
+					humanize.IBytes(uint64(limit)))
+			}
+			log.Error().Err(err).Msg("could not store attachment")
+			return status.Error(codes.Internal, "could not store attachment")
+		}
+	}
+
+	// The contents and the attachment pointing to them are added together so that the contents aren't removed as
+	// unused in between.
+	api.attachmentsMu.Lock()
+	defer api.attachmentsMu.Unlock()
+
+	// Other uploads to the same task may have finished while this one was being received, so the space left is
+	// checked again now that no other attachment can be added.
+	existing, err = api.db.ListAttachments(api.db, taskID)
+	if err != nil {
+		log.Error().Err(err).Str("task_id", taskID).Msg("could not get attachments")
+		return status.Error(codes.Internal, "failed to retrieve attachments from database")
+	}
+
+	used = 0
+	for _, attachment := range existing {
+		used += attachment.Size
+	}
+
+	if used+writer.size > api.config.Server.AttachmentMaxTaskSize {
+		return status.Errorf(codes.FailedPrecondition,
+			"file is larger than the %s left of the %s each task's attachments may take up",
+			humanize.IBytes(uint64(max(api.config.Server.AttachmentMaxTaskSize-used, 0))),
+			humanize.IBytes(uint64(api.config.Server.AttachmentMaxTaskSize)))
+	}
+
+	digest, err := writer.commit()
+	if err != nil {
+		log.Error().Err(err).Msg("could not store attachment")
+		return status.Error(codes.Internal, "could not store attachment")
+	}
+
+	var attachment *models.Attachment
+	_, err = api.insertWithUniqueID(func(id string) error {
+		attachment = models.NewAttachment(id, taskID, name, writer.size, digest)
+		return api.db.InsertAttachment(api.db, attachment.ToStorage())
+	})
+	if err != nil {
+		log.Error().Err(err).Str("task_id", taskID).Msg("could not insert attachment")
+		return status.Error(codes.Internal, "could not insert attachment")
+	}
+
+	log.Info().Str("id", attachment.ID).Str("task_id", taskID).Int64("size", attachment.Size).Msg("attached file")
+
+	return stream.SendAndClose(&proto.UploadAttachmentResponse{
+		Attachment: attachment.ToStorage().ToProto(),
+	})
+}
+
+func (api *API) ListAttachments(ctx context.Context, request *proto.ListAttachmentsRequest) (*proto.ListAttachmentsResponse, error) {
+	taskID, err := api.resolveTaskID(request.TaskId)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := api.db.ListAttachments(api.db, taskID)
+	if err != nil {
+		log.Error().Err(err).Str("task_id", taskID).Msg("could not get attachments")
+		return nil, status.Error(codes.Internal, "failed to retrieve attachments from database")
+	}
+
+	protoAttachments := []*proto.Attachment{}
+	for _, attachment := range attachments {
+		protoAttachments = append(protoAttachments, attachment.ToProto())
+	}
+
+	return &proto.ListAttachmentsResponse{
+		Attachments: protoAttachments,
+	}, nil
+}
+
+func (api *API) DownloadAttachment(request *proto.DownloadAttachmentRequest, stream grpc.ServerStreamingServer[proto.DownloadAttachmentResponse]) error {
+	id, err := resolveID("attachment", request.Id, func(prefix string, limit int) ([]string, error) {
+		return api.db.FindAttachmentIDs(api.db, prefix, limit)
+	})
+	if err != nil {
+		return err
+	}
+
+	attachment, err := api.db.GetAttachment(api.db, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrEntityNotFound) {
+			return status.Error(codes.FailedPrecondition, "attachment not found")
+		}
+		log.Error().Err(err).Str("id", id).Msg("could not get attachment")
+		return status.Errorf(codes.Internal, "could not get attachment %s", id)
+	}
+
+	file, err := os.Open(blobPath(api.attachmentDir(), attachment.Digest))
+	if errors.Is(err, os.ErrNotExist) {
+		// Database backups don't include the attachment directory, so a restored database can point at contents
+		// that were never brought back along with it.
+		log.Warn().Str("id", id).Str("digest", attachment.Digest).Msg("attachment contents missing")
+		return status.Errorf(codes.NotFound,
+			"contents of attachment %s are missing from the attachment directory; they may not have been restored "+
+				"along with the database", id)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("could not open attachment contents")
+		return status.Errorf(codes.Internal, "could not read attachment %s", id)
+	}
+	defer file.Close()
+
+	err = stream.Send(&proto.DownloadAttachmentResponse{
+		Data: &proto.DownloadAttachmentResponse_Attachment{Attachment: attachment.ToProto()},
+	})
+	if err != nil {
+		return err
+	}
+
+	buffer := make([]byte, attachmentChunkSize)
+	for {
+		n, err := file.Read(buffer)
+		if n > 0 {
+			sendErr := stream.Send(&proto.DownloadAttachmentResponse{
+				Data: &proto.DownloadAttachmentResponse_Chunk{Chunk: buffer[:n]},
+			})
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			log.Error().Err(err).Str("id", id).Msg("could not read attachment contents")
+			return status.Errorf(codes.Internal, "could not read attachment %s", id)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/clintjedwards/todo/internal/storage"
+	proto "github.com/clintjedwards/todo/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testUploadStream feeds an upload its messages one at a time, waiting on release (if set) before reporting the end
+// of the upload.
+type testUploadStream struct {
+	grpc.ServerStream
+	messages []*proto.UploadAttachmentRequest
+	release  chan struct{}
+	received chan struct{}
+}
+
+func newTestUploadStream(taskID, name string, contents []byte) *testUploadStream {
+	return &testUploadStream{
+		messages: []*proto.UploadAttachmentRequest{
+			{Data: &proto.UploadAttachmentRequest_Details_{Details: &proto.UploadAttachmentRequest_Details{
+				TaskId: taskID, Name: name,
+			}}},
+			{Data: &proto.UploadAttachmentRequest_Chunk{Chunk: contents}},
+		},
+		received: make(chan struct{}),
+	}
+}
+
+func (s *testUploadStream) Recv() (*proto.UploadAttachmentRequest, error) {
+	if len(s.messages) > 0 {
+		message := s.messages[0]
+		s.messages = s.messages[1:]
+		return message, nil
+	}
+
+	close(s.received)
+	if s.release != nil {
+		<-s.release
+	}
+	return nil, io.EOF
+}
+
+func (s *testUploadStream) SendAndClose(*proto.UploadAttachmentResponse) error {
+	return nil
+}
+
+func (s *testUploadStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestUploadAttachmentQuotaIsCheckedUnderLock(t *testing.T) {
+	api := newTestAPI(t)
+	api.config.Server.AttachmentDir = t.TempDir()
+	api.config.Server.AttachmentMaxSize = 10
+	api.config.Server.AttachmentMaxTaskSize = 10
+
+	id := createTestTask(t, api, "task", "")
+
+	// The slow upload has checked how much space is left and received its contents but not yet finished when the
+	// fast one takes most of that space.
+	slow := newTestUploadStream(id, "slow.txt", []byte("sixsix"))
+	slow.release = make(chan struct{})
+
+	slowErr := make(chan error)
+	go func() { slowErr <- api.UploadAttachment(slow) }()
+	<-slow.received
+
+	err := api.UploadAttachment(newTestUploadStream(id, "fast.txt", []byte("sixsix")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	close(slow.release)
+
+	err = <-slowErr
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected the second upload to go past the task's space to be refused; got %v", err)
+	}
+
+	attachments, err := api.db.ListAttachments(api.db, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attachments) != 1 {
+		t.Errorf("expected only one attachment to be added; got %d", len(attachments))
+	}
+}
+
+func TestDeletedAttachmentsAreKeptForUndo(t *testing.T) {
+	api := newTestAPI(t)
+	api.config.Server.AttachmentDir = t.TempDir()
+
+	parent := createTestTask(t, api, "parent", "")
+	child := createTestTask(t, api, "child", parent)
+
+	err := api.UploadAttachment(newTestUploadStream(child, "notes.txt", []byte("notes")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attachments, err := api.db.ListAttachments(api.db, child)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob := blobPath(api.attachmentDir(), attachments[0].Digest)
+
+	_, err = api.DeleteTask(context.Background(), &proto.DeleteTaskRequest{Id: parent})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(blob); err != nil {
+		t.Fatalf("expected the contents of a deleted attachment to be kept while the delete can be undone: %v", err)
+	}
+
+	_, err = api.UndoOperation(context.Background(), &proto.UndoOperationRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := api.db.ListAttachments(api.db, child)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(attachments, restored); diff != "" {
+		t.Errorf("unexpected attachments after undo (-want +got):\n%s", diff)
+	}
+
+	// Once the delete falls out of the journal it can no longer be undone, so the contents can go.
+	_, err = api.DeleteTask(context.Background(), &proto.DeleteTaskRequest{Id: parent})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deletion, err := api.db.GetLatestOperation(api.db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Operations made within the same millisecond can be trimmed in either order, so keep going until it's gone.
+	other := createTestTask(t, api, "other", "")
+	for i := 0; ; i++ {
+		_, err := api.UpdateTask(context.Background(), &proto.UpdateTaskRequest{Id: other, Title: ptr(fmt.Sprint(i))})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = api.db.GetOperation(api.db, deletion.ID)
+		if errors.Is(err, storage.ErrEntityNotFound) {
+			break
+		}
+		if i > 2*maxJournalLength {
+			t.Fatal("expected the delete to be trimmed from the journal")
+		}
+	}
+
+	if _, err := os.Stat(blob); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the contents to be removed once the delete left the journal; got %v", err)
+	}
+}
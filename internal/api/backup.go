@@ -0,0 +1,96 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	backupFilePrefix = "todo-"
+	backupFileSuffix = ".db"
+)
+
+// runAutomaticBackups takes a backup of the database every backup interval and removes any backups past the
+// configured retention. It blocks forever and is meant to be run in its own goroutine.
+func (api *API) runAutomaticBackups() {
+	ticker := time.NewTicker(api.config.Server.BackupInterval)
+	defer ticker.Stop()
+
+	for {
+		path, err := api.backup(time.Now())
+		if err != nil {
+			log.Error().Err(err).Msg("could not complete automatic backup")
+		} else {
+			log.Info().Str("path", path).Msg("completed automatic backup")
+		}
+
+		<-ticker.C
+	}
+}
+
+// backup writes a new timestamped backup into the backup directory and prunes old backups.
+func (api *API) backup(now time.Time) (string, error) {
+	dir := api.config.Server.BackupDir
+
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return "", fmt.Errorf("could not create backup directory: %w", err)
+	}
+
+	path := filepath.Join(dir, backupFilePrefix+now.UTC().Format("20060102T150405Z")+backupFileSuffix)
+
+	err = api.db.Backup(path)
+	if err != nil {
+		return "", err
+	}
+
+	err = pruneBackups(dir, api.config.Server.BackupRetention)
+	if err != nil {
+		return path, fmt.Errorf("could not prune old backups: %w", err)
+	}
+
+	return path, nil
+}
+
+// pruneBackups removes the oldest automatic backups in dir until only retention backups remain.
+// Since backup files are named by timestamp, sorting them lexically also sorts them by age.
+func pruneBackups(dir string, retention int) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	backups := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		if strings.HasPrefix(entry.Name(), backupFilePrefix) && strings.HasSuffix(entry.Name(), backupFileSuffix) {
+			backups = append(backups, entry.Name())
+		}
+	}
+
+	if len(backups) <= retention {
+		return nil
+	}
+
+	sort.Strings(backups)
+
+	for _, name := range backups[:len(backups)-retention] {
+		err := os.Remove(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+
+		log.Debug().Str("path", filepath.Join(dir, name)).Msg("removed expired backup")
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	proto "github.com/clintjedwards/todo/proto"
+	"github.com/rs/zerolog/log"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// readinessInterval is how often the GRPC health status is brought in line with the readiness check.
+const readinessInterval = 10 * time.Second
+
+// healthzHandler reports whether the process is alive. It intentionally checks nothing else so that
+// supervisors don't restart the service because of a dependency being briefly unavailable.
+func (api *API) healthzHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+// readyzHandler reports whether the service is ready to handle requests. That means the database can be
+// reached and every scheduled task has been restored and is being watched.
+func (api *API) readyzHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	if err := api.ready(); err != nil {
+		log.Debug().Err(err).Msg("readiness check failed")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = fmt.Fprintf(w, "not ready: %v\n", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+func (api *API) ready() error {
+	if err := api.db.Ping(); err != nil {
+		return fmt.Errorf("database unreachable: %w", err)
+	}
+
+	if !api.schedulerRestored.Load() {
+		return fmt.Errorf("scheduled tasks have not been restored yet")
+	}
+
+	if failed := api.failedScheduledTasks(); failed > 0 {
+		return fmt.Errorf("%d scheduled task(s) could not be restored", failed)
+	}
+
+	return nil
+}
+
+// watchReadiness keeps the status reported through the GRPC health checking protocol in line with the readiness check
+// served at /readyz until ctx is cancelled.
+func (api *API) watchReadiness(ctx context.Context) {
+	for {
+		status := healthpb.HealthCheckResponse_SERVING
+		if err := api.ready(); err != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		api.health.SetServingStatus("", status)
+		api.health.SetServingStatus(proto.Todo_ServiceDesc.ServiceName, status)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(readinessInterval):
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/clintjedwards/todo/internal/storage"
+)
+
+func TestReadinessFollowsSchedulerFailures(t *testing.T) {
+	api := newTestAPI(t)
+
+	if err := api.ready(); err != nil {
+		t.Fatalf("expected to be ready; got %v", err)
+	}
+
+	broken := storage.ScheduledTask{ID: "broken", Expression: "not an expression"}
+
+	err := api.startScheduledTask(broken)
+	if err == nil {
+		t.Fatal("expected scheduled task with a bad expression not to start")
+	}
+
+	if err := api.ready(); err == nil {
+		t.Fatal("expected not to be ready while a scheduled task can't be started")
+	}
+
+	// Pausing or deleting the scheduled task stops it being expected to run.
+	api.stopScheduledTask(broken.ID)
+
+	if err := api.ready(); err != nil {
+		t.Fatalf("expected to be ready once the failed scheduled task was stopped; got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/clintjedwards/todo/internal/models"
+	"github.com/clintjedwards/todo/internal/storage"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxIDAttempts is the number of IDs we'll try before giving up on inserting a new entity.
+	maxIDAttempts = 10
+
+	// idAttemptsPerLength is the number of collisions we'll tolerate before lengthening the IDs we generate.
+	idAttemptsPerLength = 3
+
+	// maxAmbiguousCandidates is the number of matching IDs we list back to the user when a prefix is ambiguous.
+	maxAmbiguousCandidates = 5
+)
+
+// insertWithUniqueID generates a new ID and calls insert with it, retrying with a new ID whenever insert reports
+// the ID is already in use. It returns the ID that was eventually used.
+func (api *API) insertWithUniqueID(insert func(id string) error) (string, error) {
+	length := api.config.Server.IDLength
+
+	for attempt := 1; attempt <= maxIDAttempts; attempt++ {
+		id := models.NewID(length, api.config.Server.SortableIDs)
+
+		err := insert(id)
+		if err == nil {
+			return id, nil
+		}
+
+		if !errors.Is(err, storage.ErrEntityExists) {
+			return "", err
+		}
+
+		log.Debug().Str("id", id).Int("attempt", attempt).Msg("generated id already in use; retrying")
+
+		// Repeated collisions mean the ID space at this length is getting crowded, so widen it.
+		if attempt%idAttemptsPerLength == 0 {
+			length++
+		}
+	}
+
+	return "", fmt.Errorf("could not generate an unused id after %d attempts; %w", maxIDAttempts, storage.ErrEntityExists)
+}
+
+// resolveTaskID returns the full ID of the task that the given ID or ID prefix refers to. The error returned is a
+// GRPC status suitable for returning to the client.
+func (api *API) resolveTaskID(prefix string) (string, error) {
+	return resolveID("task", prefix, func(prefix string, limit int) ([]string, error) {
+		return api.db.FindTaskIDs(api.db, prefix, limit)
+	})
+}
+
+// resolveScheduledTaskID returns the full ID of the scheduled task that the given ID or ID prefix refers to. The
+// error returned is a GRPC status suitable for returning to the client.
+func (api *API) resolveScheduledTaskID(prefix string) (string, error) {
+	return resolveID("scheduled task", prefix, func(prefix string, limit int) ([]string, error) {
+		return api.db.FindScheduledTaskIDs(api.db, prefix, limit)
+	})
+}
+
+// resolveParentID resolves the parent task ID given, leaving an empty parent (no parent) as it is.
+func (api *API) resolveParentID(prefix string) (string, error) {
+	if prefix == "" {
+		return "", nil
+	}
+
+	id, err := api.resolveTaskID(prefix)
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return "", status.Errorf(codes.FailedPrecondition, "parent task %q not found", prefix)
+		}
+		return "", err
+	}
+
+	return id, nil
+}
+
+// resolveID resolves a possibly shortened ID git-style: a prefix is accepted as long as it matches exactly one
+// entity. An ID that matches an entity exactly always wins, even if it is also the prefix of other IDs.
+func resolveID(kind, prefix string, find func(prefix string, limit int) ([]string, error)) (string, error) {
+	if prefix == "" {
+		return "", status.Error(codes.FailedPrecondition, "id required")
+	}
+
+	ids, err := find(prefix, maxAmbiguousCandidates+1)
+	if err != nil {
+		log.Error().Err(err).Str("prefix", prefix).Msgf("could not look up %s id", kind)
+		return "", status.Errorf(codes.Internal, "could not look up %s %s", kind, prefix)
+	}
+
+	switch {
+	case len(ids) == 0:
+		return "", status.Errorf(codes.FailedPrecondition, "%s not found", kind)
+	case len(ids) == 1, ids[0] == prefix:
+		return ids[0], nil
+	}
+
+	candidates := ids
+	if len(candidates) > maxAmbiguousCandidates {
+		candidates = append(candidates[:maxAmbiguousCandidates:maxAmbiguousCandidates], "...")
+	}
+
+	return "", status.Errorf(codes.InvalidArgument, "%s id %q is ambiguous; it could refer to any of: %s",
+		kind, prefix, strings.Join(candidates, ", "))
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/clintjedwards/todo/internal/config"
+	"github.com/clintjedwards/todo/internal/storage"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestInsertWithUniqueID(t *testing.T) {
+	errOther := errors.New("disk full")
+
+	tests := []struct {
+		name string
+		// collisions is the number of inserts that report the ID as taken before one succeeds.
+		collisions int
+		// insertErr is returned instead of a collision when set.
+		insertErr error
+		// lengths is the length of every ID tried, in order.
+		lengths []int
+		err     error
+	}{
+		{name: "no collision", lengths: []int{5}},
+		{name: "one collision", collisions: 1, lengths: []int{5, 5}},
+		{name: "lengthens after repeated collisions", collisions: 4, lengths: []int{5, 5, 5, 6, 6}},
+		{
+			name:       "gives up",
+			collisions: maxIDAttempts,
+			lengths:    []int{5, 5, 5, 6, 6, 6, 7, 7, 7, 8},
+			err:        storage.ErrEntityExists,
+		},
+		{name: "other errors aren't retried", insertErr: errOther, lengths: []int{5}, err: errOther},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			api := &API{config: config.DefaultAPIConfig()}
+			api.config.Server.IDLength = 5
+			api.config.Server.SortableIDs = false
+
+			lengths := []int{}
+			id, err := api.insertWithUniqueID(func(id string) error {
+				lengths = append(lengths, len(id))
+
+				if tc.insertErr != nil {
+					return tc.insertErr
+				}
+				if len(lengths) <= tc.collisions {
+					return storage.ErrEntityExists
+				}
+				return nil
+			})
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v; got %v", tc.err, err)
+			}
+
+			if diff := cmp.Diff(tc.lengths, lengths); diff != "" {
+				t.Errorf("unexpected id lengths (-want +got):\n%s", diff)
+			}
+
+			if tc.err == nil && len(id) != tc.lengths[len(tc.lengths)-1] {
+				t.Errorf("expected the id returned to be the last one tried; got %q", id)
+			}
+		})
+	}
+}
+
+func TestResolveID(t *testing.T) {
+	ids := []string{"abc", "abcde", "abd01", "abd02", "abd03", "abd04", "abd05", "abd06", "mn1", "mn2", "xyz"}
+
+	// find mimics the storage lookups: sorted IDs starting with prefix, up to limit.
+	find := func(prefix string, limit int) ([]string, error) {
+		found := []string{}
+		for _, id := range ids {
+			if len(found) < limit && len(id) >= len(prefix) && id[:len(prefix)] == prefix {
+				found = append(found, id)
+			}
+		}
+		return found, nil
+	}
+
+	tests := []struct {
+		name    string
+		prefix  string
+		id      string
+		code    codes.Code
+		message string
+	}{
+		{name: "unique prefix", prefix: "x", id: "xyz", code: codes.OK},
+		{name: "full id", prefix: "abcde", id: "abcde", code: codes.OK},
+		{name: "exact match that is also a prefix", prefix: "abc", id: "abc", code: codes.OK},
+		{name: "not found", prefix: "q", code: codes.FailedPrecondition, message: "task not found"},
+		{name: "empty", prefix: "", code: codes.FailedPrecondition, message: "id required"},
+		{
+			name:    "ambiguous",
+			prefix:  "abd0",
+			code:    codes.InvalidArgument,
+			message: `task id "abd0" is ambiguous; it could refer to any of: abd01, abd02, abd03, abd04, abd05, ...`,
+		},
+		{
+			name:    "ambiguous with few candidates",
+			prefix:  "mn",
+			code:    codes.InvalidArgument,
+			message: `task id "mn" is ambiguous; it could refer to any of: mn1, mn2`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			id, err := resolveID("task", tc.prefix, find)
+			if status.Code(err) != tc.code {
+				t.Fatalf("expected %v; got %v", tc.code, err)
+			}
+
+			if id != tc.id {
+				t.Errorf("expected id %q; got %q", tc.id, id)
+			}
+
+			if tc.message != "" && status.Convert(err).Message() != tc.message {
+				t.Errorf("unexpected message; got %q; want %q", status.Convert(err).Message(), tc.message)
+			}
+		})
+	}
+
+	_, err := resolveID("task", "abc", func(string, int) ([]string, error) { return nil, errors.New("gone") })
+	if status.Code(err) != codes.Internal {
+		t.Errorf("expected lookup failures to be internal errors; got %v", err)
+	}
+}
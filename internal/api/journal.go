@@ -0,0 +1,186 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/clintjedwards/todo/internal/models"
+	"github.com/clintjedwards/todo/internal/storage"
+)
+
+// maxJournalLength is the number of operations kept in the journal. Older operations can no longer be undone.
+const maxJournalLength = 100
+
+// journal collects the state tasks were in before an operation changed them so that the operation can be undone.
+type journal struct {
+	before []storage.Task
+	seen   map[string]bool
+
+	// The attachments and comments on tasks the operation deleted.
+	attachments []storage.Attachment
+	comments    []storage.TaskComment
+}
+
+func newJournal() *journal {
+	return &journal{
+		before:      []storage.Task{},
+		seen:        map[string]bool{},
+		attachments: []storage.Attachment{},
+		comments:    []storage.TaskComment{},
+	}
+}
+
+// capture records the task with the ID given as it is right now. Tasks are only captured the first time so that
+// operations that touch a task more than once keep its original state.
+func (j *journal) capture(db storage.Engine, tx storage.Queryable, id string) error {
+	if j.seen[id] {
+		return nil
+	}
+
+	task, err := db.GetTask(tx, id)
+	if err != nil {
+		return err
+	}
+
+	j.seen[id] = true
+	j.before = append(j.before, task)
+	return nil
+}
+
+// captureAttachments records the attachments on the task with the ID given so that they can be put back if the
+// operation deleting the task is undone.
+func (j *journal) captureAttachments(db storage.Engine, tx storage.Queryable, id string) error {
+	attachments, err := db.ListAttachments(tx, id)
+	if err != nil {
+		return err
+	}
+
+	j.attachments = append(j.attachments, attachments...)
+	return nil
+}
+
+// captureComments records the comments on the task with the ID given so that they can be put back if the operation
+// deleting the task is undone.
+func (j *journal) captureComments(db storage.Engine, tx storage.Queryable, id string) error {
+	for offset := 0; ; {
+		comments, err := db.ListTaskComments(tx, id, offset, 0)
+		if err != nil {
+			return err
+		}
+
+		if len(comments) == 0 {
+			return nil
+		}
+
+		j.comments = append(j.comments, comments...)
+		offset += len(comments)
+	}
+}
+
+// original returns the task with the ID given as it was before the operation changed it. It returns false if the
+// task hasn't been captured.
+func (j *journal) original(id string) (storage.Task, bool) {
+	for _, task := range j.before {
+		if task.ID == id {
+			return task, true
+		}
+	}
+
+	return storage.Task{}, false
+}
+
+// describe summarises the operation for people reading the journal, ex. Completed "Pay bills" and 3 children. Roots
+// is the number of tasks the operation was asked to change, not counting children changed along with them.
+func (j *journal) describe(verb string, roots int) string {
+	if roots == 1 && len(j.before) > 0 {
+		switch children := len(j.before) - 1; children {
+		case 0:
+			return fmt.Sprintf("%s %q", verb, j.before[0].Title)
+		case 1:
+			return fmt.Sprintf("%s %q and 1 child", verb, j.before[0].Title)
+		default:
+			return fmt.Sprintf("%s %q and %d children", verb, j.before[0].Title, children)
+		}
+	}
+
+	if len(j.before) == roots {
+		return fmt.Sprintf("%s %d tasks", verb, roots)
+	}
+
+	return fmt.Sprintf("%s %d tasks (%d including children)", verb, roots, len(j.before))
+}
+
+// recordOperation adds an operation to the journal using the tasks captured so far, then trims the journal down to
+// maxJournalLength. It is meant to be called inside the same transaction as the operation so that the journal never
+// disagrees with the tasks. Operations that didn't touch any tasks aren't recorded.
+//
+// It returns the digests of attachment contents held by the operations trimmed from the journal, which should be passed
+// to removeUnusedBlobs once the transaction has been committed.
+func (api *API) recordOperation(tx storage.Queryable, kind models.OperationKind, description string, journal *journal,
+	created int64,
+) ([]string, error) {
+	if len(journal.before) == 0 {
+		return nil, nil
+	}
+
+	_, err := api.insertWithUniqueID(func(id string) error {
+		// Check for a collision before inserting since a failed insert aborts the whole transaction on some drivers.
+		_, err := api.db.GetOperation(tx, id)
+		if err == nil {
+			return storage.ErrEntityExists
+		}
+		if !errors.Is(err, storage.ErrEntityNotFound) {
+			return err
+		}
+
+		operation := models.NewOperation(id, kind, description, journal.before, created)
+		operation.Attachments = journal.attachments
+		operation.Comments = journal.comments
+		return api.db.InsertOperation(tx, operation.ToStorage())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pruned, err := api.db.ListOperations(tx, maxJournalLength, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	digests := []string{}
+	for _, operation := range pruned {
+		for _, attachment := range operation.Attachments {
+			digests = append(digests, attachment.Digest)
+		}
+	}
+
+	err = api.db.PruneOperations(tx, maxJournalLength)
+	if err != nil {
+		return nil, err
+	}
+
+	return digests, nil
+}
+
+// journaledDigests returns the digests of attachment contents held by operations in the journal, which have to be kept
+// so that the operations can be undone.
+func (api *API) journaledDigests() (map[string]bool, error) {
+	digests := map[string]bool{}
+	for offset := 0; ; {
+		operations, err := api.db.ListOperations(api.db, offset, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, operation := range operations {
+			for _, attachment := range operation.Attachments {
+				digests[attachment.Digest] = true
+			}
+		}
+
+		if len(operations) < api.config.Server.StorageResultsLimit || len(operations) == 0 {
+			return digests, nil
+		}
+		offset += len(operations)
+	}
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/clintjedwards/todo/internal/metrics"
+	"github.com/clintjedwards/todo/internal/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// metricsUnaryInterceptor records the latency and resulting status code of every unary GRPC call.
+func metricsUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	metrics.RPCDuration.WithLabelValues(info.FullMethod, status.Code(err).String()).
+		Observe(time.Since(start).Seconds())
+	return resp, err
+}
+
+// metricsStreamInterceptor records the latency and resulting status code of every streaming GRPC call.
+func metricsStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	metrics.RPCDuration.WithLabelValues(info.FullMethod, status.Code(err).String()).
+		Observe(time.Since(start).Seconds())
+	return err
+}
+
+// taskCollector reports the current number of tasks and scheduled tasks. The values are read from the database
+// whenever metrics are scraped so that they are never out of date.
+type taskCollector struct {
+	db             storage.Engine
+	tasks          *prometheus.Desc
+	scheduledTasks *prometheus.Desc
+}
+
+func newTaskCollector(db storage.Engine) *taskCollector {
+	return &taskCollector{
+		db: db,
+		tasks: prometheus.NewDesc("todo_tasks", "Current number of tasks, labeled by state.",
+			[]string{"state"}, nil),
+		scheduledTasks: prometheus.NewDesc("todo_scheduled_tasks", "Current number of scheduled tasks.",
+			nil, nil),
+	}
+}
+
+func (c *taskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.tasks
+	ch <- c.scheduledTasks
+}
+
+func (c *taskCollector) Collect(ch chan<- prometheus.Metric) {
+	counts, err := c.db.CountTasks(c.db)
+	if err != nil {
+		log.Error().Err(err).Msg("could not count tasks for metrics")
+		ch <- prometheus.NewInvalidMetric(c.tasks, err)
+	} else {
+		// Always report both states so that a state with no tasks shows up as zero instead of disappearing.
+		for _, state := range []string{"UNRESOLVED", "COMPLETED"} {
+			ch <- prometheus.MustNewConstMetric(c.tasks, prometheus.GaugeValue, float64(counts[state]),
+				strings.ToLower(state))
+		}
+	}
+
+	scheduled, err := c.db.CountScheduledTasks(c.db)
+	if err != nil {
+		log.Error().Err(err).Msg("could not count scheduled tasks for metrics")
+		ch <- prometheus.NewInvalidMetric(c.scheduledTasks, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.scheduledTasks, prometheus.GaugeValue, float64(scheduled))
+}
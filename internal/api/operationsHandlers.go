@@ -0,0 +1,259 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/clintjedwards/todo/internal/models"
+	"github.com/clintjedwards/todo/internal/storage"
+	proto "github.com/clintjedwards/todo/proto"
+	"github.com/rs/zerolog/log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errUndoConflict is returned when tasks have changed since the operation being undone.
+var errUndoConflict = errors.New("tasks changed since operation")
+
+// errUndoArchived is returned when a task the operation being undone would recreate has been archived since. Undoing
+// would leave the task both archived and not, so it isn't allowed even with force.
+var errUndoArchived = errors.New("task has been archived since operation")
+
+// errUndoStale is returned when the operation being undone has been undone or pruned since it was looked up.
+var errUndoStale = errors.New("operation no longer undoable")
+
+func (api *API) ListOperations(ctx context.Context, request *proto.ListOperationsRequest) (*proto.ListOperationsResponse, error) {
+	operations, err := api.db.ListOperations(api.db, int(request.Offset), int(request.Limit))
+	if err != nil {
+		log.Error().Err(err).Msg("could not get operations")
+		return &proto.ListOperationsResponse{}, status.Error(codes.Internal, "failed to retrieve operations from database")
+	}
+
+	protoOperations := []*proto.Operation{}
+	for _, operation := range operations {
+		protoOperations = append(protoOperations, operation.ToProto())
+	}
+
+	return &proto.ListOperationsResponse{
+		Operations: protoOperations,
+	}, nil
+}
+
+func (api *API) UndoOperation(ctx context.Context, request *proto.UndoOperationRequest) (*proto.UndoOperationResponse, error) {
+	operation, err := api.operationToUndo(request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	restored := []string{}
+	now := time.Now().UnixMilli()
+
+	err = api.db.InsideTx(func(tx storage.Queryable) error {
+		restored = restored[:0]
+
+		// Marked first so that of two undos of the same operation only one gets any further.
+		err := api.db.MarkOperationUndone(tx, operation.ID)
+		if errors.Is(err, storage.ErrConflict) || errors.Is(err, storage.ErrEntityNotFound) {
+			return errUndoStale
+		}
+		if err != nil {
+			return err
+		}
+
+		if !request.Force {
+			err := api.checkUndoConflicts(tx, operation)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, before := range operation.Tasks {
+			err := api.restoreTask(tx, before, now)
+			if err != nil {
+				return err
+			}
+
+			restored = append(restored, before.ID)
+		}
+
+		for _, attachment := range operation.Attachments {
+			err := api.restoreAttachment(tx, attachment)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, comment := range operation.Comments {
+			err := api.restoreComment(tx, comment)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errUndoStale) {
+			return nil, status.Errorf(codes.FailedPrecondition, "operation %s has already been undone or has left "+
+				"the journal", operation.ID)
+		}
+		if errors.Is(err, errUndoArchived) {
+			return nil, status.Errorf(codes.FailedPrecondition, "could not undo operation %s; %v; restore it from "+
+				"the archive first", operation.ID, err)
+		}
+		if errors.Is(err, errUndoConflict) {
+			return nil, status.Errorf(codes.FailedPrecondition, "could not undo operation %s; %v; undo with force to "+
+				"overwrite those changes", operation.ID, err)
+		}
+		log.Error().Err(err).Str("id", operation.ID).Msg("could not undo operation")
+		return nil, status.Error(codes.Internal, "could not undo operation")
+	}
+
+	operation.Undone = true
+
+	log.Info().Str("id", operation.ID).Strs("tasks", restored).Msg("undid operation")
+	return &proto.UndoOperationResponse{
+		Operation:   operation.ToProto(),
+		RestoredIds: restored,
+	}, nil
+}
+
+// operationToUndo returns the operation with the ID or ID prefix given or, if none is given, the most recent operation
+// that hasn't been undone. The error returned is a GRPC status suitable for returning to the client.
+func (api *API) operationToUndo(prefix string) (storage.Operation, error) {
+	if prefix == "" {
+		operation, err := api.db.GetLatestOperation(api.db)
+		if err != nil {
+			if errors.Is(err, storage.ErrEntityNotFound) {
+				return storage.Operation{}, status.Error(codes.FailedPrecondition, "nothing to undo")
+			}
+			log.Error().Err(err).Msg("could not get latest operation")
+			return storage.Operation{}, status.Error(codes.Internal, "could not get latest operation")
+		}
+
+		return operation, nil
+	}
+
+	id, err := resolveID("operation", prefix, func(prefix string, limit int) ([]string, error) {
+		return api.db.FindOperationIDs(api.db, prefix, limit)
+	})
+	if err != nil {
+		return storage.Operation{}, err
+	}
+
+	operation, err := api.db.GetOperation(api.db, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrEntityNotFound) {
+			return storage.Operation{}, status.Error(codes.FailedPrecondition, "operation not found")
+		}
+		log.Error().Err(err).Str("id", id).Msg("could not get operation")
+		return storage.Operation{}, status.Errorf(codes.Internal, "could not get operation %s", id)
+	}
+
+	return operation, nil
+}
+
+// checkUndoConflicts makes sure none of the tasks an operation touched have changed since, so that undoing it doesn't
+// silently throw away later work.
+func (api *API) checkUndoConflicts(tx storage.Queryable, operation storage.Operation) error {
+	for _, before := range operation.Tasks {
+		current, err := api.db.GetTask(tx, before.ID)
+		if err != nil && !errors.Is(err, storage.ErrEntityNotFound) {
+			return err
+		}
+		exists := err == nil
+
+		if !exists {
+			err := api.checkNotArchived(tx, before.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case operation.Kind == string(models.OperationKindDelete) && exists:
+			return fmt.Errorf("%w: task %s exists again", errUndoConflict, before.ID)
+		case operation.Kind != string(models.OperationKindDelete) && !exists:
+			return fmt.Errorf("%w: task %s has since been deleted", errUndoConflict, before.ID)
+		case exists && current.Modified > operation.Created:
+			return fmt.Errorf("%w: task %s has been changed since", errUndoConflict, before.ID)
+		}
+	}
+
+	return nil
+}
+
+// restoreTask puts a task back as given, recreating it if it no longer exists and hasn't been archived. The task is marked as modified at the
+// time given rather than when it was last changed before the operation, since undoing is itself a change; the earlier
+// time is still kept in the journal.
+func (api *API) restoreTask(tx storage.Queryable, task storage.Task, modified int64) error {
+	task.Modified = modified
+
+	_, err := api.db.GetTask(tx, task.ID)
+	if errors.Is(err, storage.ErrEntityNotFound) {
+		err := api.checkNotArchived(tx, task.ID)
+		if err != nil {
+			return err
+		}
+
+		return api.db.InsertTask(tx, &task)
+	}
+	if err != nil {
+		return err
+	}
+
+	return api.db.UpdateTask(tx, task.ID, storage.UpdatableTaskFields{
+		Title:          &task.Title,
+		Description:    &task.Description,
+		State:          &task.State,
+		Modified:       &task.Modified,
+		Parent:         &task.Parent,
+		CompletedAt:    &task.CompletedAt,
+		CompletionNote: &task.CompletionNote,
+	})
+}
+
+// checkNotArchived returns errUndoArchived if the task given is in the archive.
+func (api *API) checkNotArchived(tx storage.Queryable, id string) error {
+	_, err := api.db.GetArchivedTask(tx, id)
+	if err == nil {
+		return fmt.Errorf("%w: task %s", errUndoArchived, id)
+	}
+	if !errors.Is(err, storage.ErrEntityNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// restoreAttachment puts back an attachment deleted along with its task, unless it is already there. Its contents are
+// kept for as long as the operation that deleted it is in the journal.
+func (api *API) restoreAttachment(tx storage.Queryable, attachment storage.Attachment) error {
+	// Checked before inserting since a failed insert aborts the whole transaction on some drivers.
+	_, err := api.db.GetAttachment(tx, attachment.ID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, storage.ErrEntityNotFound) {
+		return err
+	}
+
+	return api.db.InsertAttachment(tx, &attachment)
+}
+
+// restoreComment puts back a comment deleted along with its task, unless it is already there.
+func (api *API) restoreComment(tx storage.Queryable, comment storage.TaskComment) error {
+	// Checked before inserting since a failed insert aborts the whole transaction on some drivers.
+	_, err := api.db.GetTaskComment(tx, comment.ID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, storage.ErrEntityNotFound) {
+		return err
+	}
+
+	return api.db.InsertTaskComment(tx, &comment)
+}
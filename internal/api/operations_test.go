@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/clintjedwards/todo/internal/storage"
+	proto "github.com/clintjedwards/todo/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUndoMarksTasksModified(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(api *API, id string) error
+	}{
+		{name: "update", change: func(api *API, id string) error {
+			_, err := api.UpdateTask(context.Background(), &proto.UpdateTaskRequest{Id: id, Title: ptr("changed")})
+			return err
+		}},
+		{name: "delete", change: func(api *API, id string) error {
+			_, err := api.DeleteTask(context.Background(), &proto.DeleteTaskRequest{Id: id})
+			return err
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			api := newTestAPI(t)
+
+			id := createTestTask(t, api, "original", "")
+
+			// Pretend the task was last changed long ago so it can't be confused with the undo.
+			lastModified := time.Now().Add(-24 * time.Hour).UnixMilli()
+			err := api.db.UpdateTask(api.db, id, storage.UpdatableTaskFields{Modified: &lastModified})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = tc.change(api, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			undone := time.Now().UnixMilli()
+			resp, err := api.UndoOperation(context.Background(), &proto.UndoOperationRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			task, err := api.db.GetTask(api.db, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if task.Title != "original" {
+				t.Errorf("expected title to be restored to %q; got %q", "original", task.Title)
+			}
+
+			if task.Modified < undone {
+				t.Errorf("expected task to be marked modified by the undo at %d or later; got %d", undone, task.Modified)
+			}
+
+			operation, err := api.db.GetOperation(api.db, resp.Operation.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if operation.Tasks[0].Modified != lastModified {
+				t.Errorf("expected the journal to keep the time the task was last modified %d; got %d", lastModified,
+					operation.Tasks[0].Modified)
+			}
+		})
+	}
+}
+
+func TestUndoOperationOnlyOnce(t *testing.T) {
+	api := newTestAPI(t)
+
+	id := createTestTask(t, api, "original", "")
+
+	_, err := api.UpdateTask(context.Background(), &proto.UpdateTaskRequest{Id: id, Title: ptr("changed")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := api.UndoOperation(context.Background(), &proto.UndoOperationRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = api.UndoOperation(context.Background(), &proto.UndoOperationRequest{Id: resp.Operation.Id, Force: true})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition undoing an operation twice; got %v", err)
+	}
+}
+
+func TestUndoDeleteOfArchivedTask(t *testing.T) {
+	api := newTestAPI(t)
+
+	id := createTestTask(t, api, "original", "")
+
+	task, err := api.db.GetTask(api.db, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = api.DeleteTask(context.Background(), &proto.DeleteTaskRequest{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A task with the same ID has since made its way into the archive.
+	err = api.db.InsertTask(api.db, &task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = api.db.ArchiveTask(api.db, id, time.Now().UnixMilli())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, force := range []bool{false, true} {
+		_, err = api.UndoOperation(context.Background(), &proto.UndoOperationRequest{Force: force})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected FailedPrecondition undoing the delete of an archived task with force %v; got %v",
+				force, err)
+		}
+	}
+
+	_, err = api.db.GetTask(api.db, id)
+	if !errors.Is(err, storage.ErrEntityNotFound) {
+		t.Errorf("expected archived task not to be recreated; got %v", err)
+	}
+}
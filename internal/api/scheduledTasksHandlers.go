@@ -5,9 +5,10 @@ import (
 	"errors"
 	"time"
 
-	"github.com/clintjedwards/avail/v2"
 	"github.com/clintjedwards/todo/internal/models"
+	"github.com/clintjedwards/todo/internal/schedule"
 	"github.com/clintjedwards/todo/internal/storage"
+	"github.com/clintjedwards/todo/internal/tasktemplate"
 	proto "github.com/clintjedwards/todo/proto"
 	"github.com/rs/zerolog/log"
 
@@ -16,20 +17,24 @@ import (
 )
 
 func (api *API) GetScheduledTask(ctx context.Context, request *proto.GetScheduledTaskRequest) (*proto.GetScheduledTaskResponse, error) {
-	if request.Id == "" {
-		return nil, status.Error(codes.FailedPrecondition, "id required")
+	id, err := api.resolveScheduledTaskID(request.Id)
+	if err != nil {
+		return nil, err
 	}
 
-	scheduledTask, err := api.db.GetScheduledTask(api.db, request.Id)
+	scheduledTask, err := api.db.GetScheduledTask(api.db, id)
 	if err != nil {
 		if errors.Is(err, storage.ErrEntityNotFound) {
 			return nil, status.Error(codes.FailedPrecondition, "scheduled task not found")
 		}
 		log.Error().Err(err).Msg("could not get scheduled task")
-		return nil, status.Errorf(codes.FailedPrecondition, "failed to retrieve scheduled task %s from database", request.Id)
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to retrieve scheduled task %s from database", id)
 	}
 
-	return &proto.GetScheduledTaskResponse{ScheduledTask: scheduledTask.ToProto()}, nil
+	return &proto.GetScheduledTaskResponse{
+		ScheduledTask: scheduledTask.ToProto(),
+		FireTimes:     api.scheduledTaskFireTimes(scheduledTask, request.NextFireCount, time.Now()),
+	}, nil
 }
 
 func (api *API) ListScheduledTasks(ctx context.Context, request *proto.ListScheduledTasksRequest) (*proto.ListScheduledTasksResponse, error) {
@@ -39,18 +44,32 @@ func (api *API) ListScheduledTasks(ctx context.Context, request *proto.ListSched
 		return &proto.ListScheduledTasksResponse{}, status.Error(codes.Internal, "failed to retrieve scheduledTask from database")
 	}
 
+	now := time.Now()
 	protoScheduledTasks := []*proto.ScheduledTask{}
+	protoFireTimes := map[string]*proto.FireTimes{}
 	for _, scheduledTask := range scheduledTask {
 		protoScheduledTasks = append(protoScheduledTasks, scheduledTask.ToProto())
+		protoFireTimes[scheduledTask.ID] = api.scheduledTaskFireTimes(scheduledTask, request.NextFireCount, now)
 	}
 
 	return &proto.ListScheduledTasksResponse{
 		ScheduledTasks: protoScheduledTasks,
+		FireTimes:      protoFireTimes,
 	}, nil
 }
 
 func (api *API) CreateScheduledTask(ctx context.Context, request *proto.CreateScheduledTaskRequest) (*proto.CreateScheduledTaskResponse, error) {
-	if request.Title == "" {
+	template, err := api.scheduledTaskTemplate(request.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	title := request.Title
+	if title == "" && template != nil {
+		title = template.Name
+	}
+
+	if title == "" {
 		return nil, status.Error(codes.FailedPrecondition, "title required")
 	}
 
@@ -58,65 +77,114 @@ func (api *API) CreateScheduledTask(ctx context.Context, request *proto.CreateSc
 		return nil, status.Error(codes.FailedPrecondition, "expression required")
 	}
 
-	newScheduledTask := models.NewScheduledTask(request.Title, request.Description, request.Parent, request.Expression)
-	// Register it in our in-memory cache
-	innerCtx, cancelFunc := context.WithCancel(context.Background())
-	api.scheduledTasks[newScheduledTask.ID] = cancelFunc
+	location, err := api.location(request.Timezone)
+	if err != nil {
+		return &proto.CreateScheduledTaskResponse{}, status.Errorf(codes.FailedPrecondition, "time zone %q not recognized", request.Timezone)
+	}
 
-	avail, err := avail.New(request.Expression)
+	parsed, err := parseExpression(request.Recurrence, request.ExpressionType, request.Expression, time.Now().In(location))
 	if err != nil {
 		return &proto.CreateScheduledTaskResponse{}, status.Errorf(codes.FailedPrecondition, "incorrect expression used; %v", err)
 	}
 
-	go func(ctx context.Context, scheduledTask models.ScheduledTask) {
-		// Sleep for a minute first in case the user's time-frame matches exactly the time he created the task.
-		time.Sleep(time.Minute * 1)
+	parent, err := api.resolveParentID(request.Parent)
+	if err != nil {
+		return nil, err
+	}
 
-		for {
-			select {
-			case <-ctx.Done():
-				log.Debug().Str("id", scheduledTask.ID).Msg("scheduled task processing cancelled")
-				return
-			default:
-				if avail.Able(time.Now()) {
-					newTask := models.NewTask(scheduledTask.Title, scheduledTask.Description, scheduledTask.Parent)
+	var newScheduledTask *models.ScheduledTask
+	_, err = api.insertWithUniqueID(func(id string) error {
+		newScheduledTask = models.NewScheduledTask(id, title, request.Description, parent,
+			request.Expression, string(parsed.kind))
+		newScheduledTask.Recurrence = parsed.recurrence
+		newScheduledTask.SkipIfOpen = request.SkipIfOpen
+		newScheduledTask.Timezone = request.Timezone
+		if template != nil {
+			newScheduledTask.TemplateID = template.ID
+		}
+		return api.db.InsertScheduledTask(api.db, newScheduledTask.ToStorage())
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("could not insert scheduled task")
+		return &proto.CreateScheduledTaskResponse{},
+			status.Error(codes.Internal, "could not insert scheduled task")
+	}
 
-					err := api.db.InsertTask(api.db, newTask.ToStorage())
-					if err != nil {
-						log.Error().Err(err).Msg("could not create task")
-					}
+	err = api.startScheduledTask(*newScheduledTask.ToStorage())
+	if err != nil {
+		log.Error().Err(err).Str("id", newScheduledTask.ID).Msg("could not start monitoring for scheduled task")
+		return &proto.CreateScheduledTaskResponse{}, status.Error(codes.Internal, "could not start scheduled task")
+	}
 
-					log.Debug().Str("id", newTask.ID).Str("title", scheduledTask.Title).Str("scheduled_task_id", scheduledTask.ID).Msg("scheduled a new task")
-				}
+	return &proto.CreateScheduledTaskResponse{Id: newScheduledTask.ID}, nil
+}
 
-				time.Sleep(time.Minute * 1)
-			}
-		}
-	}(innerCtx, *newScheduledTask)
+// scheduledTaskTemplate resolves the template a scheduled task should create, returning nil if none was given. The
+// template is checked to make sure it can be filled in with only the variables that are always available since there's
+// no one around to supply more when the scheduled task fires.
+func (api *API) scheduledTaskTemplate(nameOrID string) (*storage.Template, error) {
+	if nameOrID == "" {
+		return nil, nil
+	}
 
-	err = api.db.InsertScheduledTask(api.db, newScheduledTask.ToStorage())
+	template, err := api.resolveTemplate(nameOrID)
 	if err != nil {
-		if errors.Is(err, storage.ErrEntityExists) {
-			return &proto.CreateScheduledTaskResponse{}, status.Error(codes.AlreadyExists, "scheduled task already exists")
-		}
+		return nil, err
+	}
 
-		return &proto.CreateScheduledTaskResponse{},
-			status.Error(codes.Internal, "could not insert scheduled task")
+	_, err = tasktemplate.RenderTasks(template.Tasks.ToProto(), tasktemplate.Variables(time.Now()))
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "template %q can't be scheduled; %v", template.Name, err)
 	}
 
-	return &proto.CreateScheduledTaskResponse{Id: newScheduledTask.ID}, nil
+	return &template, nil
 }
 
 func (api *API) UpdateScheduledTask(ctx context.Context, request *proto.UpdateScheduledTaskRequest) (*proto.UpdateScheduledTaskResponse, error) {
-	if request.Id == "" {
-		return &proto.UpdateScheduledTaskResponse{}, status.Error(codes.FailedPrecondition, "id required")
+	id, err := api.resolveScheduledTaskID(request.Id)
+	if err != nil {
+		return &proto.UpdateScheduledTaskResponse{}, err
+	}
+
+	parent, err := api.resolveParentID(request.Parent)
+	if err != nil {
+		return &proto.UpdateScheduledTaskResponse{}, err
 	}
 
-	err := api.db.UpdateScheduledTask(api.db, request.Id, storage.UpdatableScheduledTaskFields{
-		Title:       &request.Title,
-		Description: &request.Description,
-		Parent:      &request.Parent,
-		Expression:  &request.Expression,
+	if request.Expression == "" {
+		return &proto.UpdateScheduledTaskResponse{}, status.Error(codes.FailedPrecondition, "expression required")
+	}
+
+	location, err := api.location(request.Timezone)
+	if err != nil {
+		return &proto.UpdateScheduledTaskResponse{}, status.Errorf(codes.FailedPrecondition, "time zone %q not recognized", request.Timezone)
+	}
+
+	parsed, err := parseExpression(request.Recurrence, request.ExpressionType, request.Expression, time.Now().In(location))
+	if err != nil {
+		return &proto.UpdateScheduledTaskResponse{}, status.Errorf(codes.FailedPrecondition, "incorrect expression used; %v", err)
+	}
+
+	template, err := api.scheduledTaskTemplate(request.Template)
+	if err != nil {
+		return &proto.UpdateScheduledTaskResponse{}, err
+	}
+
+	templateID := ""
+	if template != nil {
+		templateID = template.ID
+	}
+
+	err = api.db.UpdateScheduledTask(api.db, id, storage.UpdatableScheduledTaskFields{
+		Title:          &request.Title,
+		Description:    &request.Description,
+		Parent:         &parent,
+		Expression:     &request.Expression,
+		ExpressionType: ptr(string(parsed.kind)),
+		Recurrence:     ptr(string(parsed.recurrence)),
+		SkipIfOpen:     &request.SkipIfOpen,
+		Timezone:       &request.Timezone,
+		TemplateID:     &templateID,
 	})
 	if err != nil {
 		if errors.Is(err, storage.ErrEntityNotFound) {
@@ -125,28 +193,260 @@ func (api *API) UpdateScheduledTask(ctx context.Context, request *proto.UpdateSc
 		return &proto.UpdateScheduledTaskResponse{}, err
 	}
 
-	log.Info().Str("id", request.Id).Msg("updated scheduled task")
+	// Restart the scheduled task so that it picks up any changes to its details or expression.
+	scheduledTask, err := api.db.GetScheduledTask(api.db, id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("could not get updated scheduled task")
+		return &proto.UpdateScheduledTaskResponse{}, status.Error(codes.Internal, "could not restart scheduled task")
+	}
+
+	if scheduledTask.Paused {
+		log.Info().Str("id", id).Msg("updated scheduled task")
+		return &proto.UpdateScheduledTaskResponse{}, nil
+	}
+
+	err = api.startScheduledTask(scheduledTask)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("could not restart scheduled task")
+		return &proto.UpdateScheduledTaskResponse{}, status.Errorf(codes.FailedPrecondition,
+			"scheduled task updated but could not be restarted; %v", err)
+	}
+
+	log.Info().Str("id", id).Msg("updated scheduled task")
 	return &proto.UpdateScheduledTaskResponse{}, nil
 }
 
 func (api *API) DeleteScheduledTask(ctx context.Context, request *proto.DeleteScheduledTaskRequest) (*proto.DeleteScheduledTaskResponse, error) {
-	if request.Id == "" {
-		return nil, status.Error(codes.FailedPrecondition, "id required")
-	}
-
-	cancel, exists := api.scheduledTasks[request.Id]
-	if !exists {
-		return &proto.DeleteScheduledTaskResponse{Id: request.Id}, nil
+	id, err := api.resolveScheduledTaskID(request.Id)
+	if err != nil {
+		return nil, err
 	}
 
-	cancel()
+	api.stopScheduledTask(id)
 
-	err := api.db.DeleteScheduledTask(api.db, request.Id)
+	err = api.db.DeleteScheduledTask(api.db, id)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "could not delete scheduled task; %v", err)
 	}
 
 	return &proto.DeleteScheduledTaskResponse{
-		Id: request.Id,
+		Id: id,
+	}, nil
+}
+
+func (api *API) PauseScheduledTask(ctx context.Context, request *proto.PauseScheduledTaskRequest) (*proto.PauseScheduledTaskResponse, error) {
+	id, err := api.resolveScheduledTaskID(request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = api.db.UpdateScheduledTask(api.db, id, storage.UpdatableScheduledTaskFields{
+		Paused: ptr(true),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("could not pause scheduled task")
+		return nil, status.Error(codes.Internal, "could not pause scheduled task")
+	}
+
+	api.stopScheduledTask(id)
+
+	log.Info().Str("id", id).Msg("paused scheduled task")
+	return &proto.PauseScheduledTaskResponse{Id: id}, nil
+}
+
+func (api *API) ResumeScheduledTask(ctx context.Context, request *proto.ResumeScheduledTaskRequest) (*proto.ResumeScheduledTaskResponse, error) {
+	id, err := api.resolveScheduledTaskID(request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = api.db.UpdateScheduledTask(api.db, id, storage.UpdatableScheduledTaskFields{
+		Paused: ptr(false),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("could not resume scheduled task")
+		return nil, status.Error(codes.Internal, "could not resume scheduled task")
+	}
+
+	scheduledTask, err := api.db.GetScheduledTask(api.db, id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("could not get resumed scheduled task")
+		return nil, status.Error(codes.Internal, "could not resume scheduled task")
+	}
+
+	err = api.startScheduledTask(scheduledTask)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("could not start monitoring for scheduled task")
+		return nil, status.Errorf(codes.FailedPrecondition, "could not resume scheduled task; %v", err)
+	}
+
+	log.Info().Str("id", id).Msg("resumed scheduled task")
+	return &proto.ResumeScheduledTaskResponse{Id: id}, nil
+}
+
+func (api *API) GetScheduledTaskHistory(ctx context.Context, request *proto.GetScheduledTaskHistoryRequest,
+) (*proto.GetScheduledTaskHistoryResponse, error) {
+	id, err := api.resolveScheduledTaskID(request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledTask, err := api.db.GetScheduledTask(api.db, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrEntityNotFound) {
+			return nil, status.Error(codes.FailedPrecondition, "scheduled task not found")
+		}
+		log.Error().Err(err).Msg("could not get scheduled task")
+		return nil, status.Errorf(codes.Internal, "failed to retrieve scheduled task %s from database", id)
+	}
+
+	// The summary covers every task the scheduled task has created so we page through all of them.
+	instances := []storage.Task{}
+	for {
+		page, err := api.db.ListScheduledTaskInstances(api.db, id, len(instances), 0)
+		if err != nil {
+			log.Error().Err(err).Str("id", id).Msg("could not list scheduled task history")
+			return nil, status.Error(codes.Internal, "failed to retrieve scheduled task history from database")
+		}
+
+		instances = append(instances, page...)
+
+		if len(page) < api.config.Server.StorageResultsLimit || len(page) == 0 {
+			break
+		}
+	}
+
+	limit := len(instances)
+	if request.Limit > 0 {
+		limit = min(limit, int(request.Limit))
+	}
+
+	protoTasks := []*proto.Task{}
+	for _, task := range instances[:limit] {
+		protoTasks = append(protoTasks, task.ToProto())
+	}
+
+	return &proto.GetScheduledTaskHistoryResponse{
+		ScheduledTask: scheduledTask.ToProto(),
+		Tasks:         protoTasks,
+		Summary:       summarizeHistory(instances),
 	}, nil
 }
+
+// summarizeHistory works out how consistently the tasks created by a scheduled task were completed. Tasks are
+// expected newest first.
+func summarizeHistory(tasks []storage.Task) *proto.ScheduledTaskHistorySummary {
+	summary := &proto.ScheduledTaskHistorySummary{Total: int64(len(tasks))}
+
+	// The newest task doesn't count against the schedule while it's still unresolved since it may just not be done yet.
+	if len(tasks) > 0 && tasks[0].State != string(models.TaskStateCompleted) {
+		tasks = tasks[1:]
+	}
+
+	var streak int64
+	currentStreakEnded := false
+	for _, task := range tasks {
+		if task.State != string(models.TaskStateCompleted) {
+			currentStreakEnded = true
+			streak = 0
+			continue
+		}
+
+		summary.Completed++
+		streak++
+		summary.LongestStreak = max(summary.LongestStreak, streak)
+		if !currentStreakEnded {
+			summary.CurrentStreak = streak
+		}
+	}
+
+	if len(tasks) > 0 {
+		summary.CompletionRate = float64(summary.Completed) / float64(len(tasks))
+	}
+
+	return summary
+}
+
+func (api *API) PreviewSchedule(ctx context.Context, request *proto.PreviewScheduleRequest) (*proto.PreviewScheduleResponse, error) {
+	if request.Expression == "" {
+		return nil, status.Error(codes.FailedPrecondition, "expression required")
+	}
+
+	location, err := api.location(request.Timezone)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "time zone %q not recognized", request.Timezone)
+	}
+
+	now := time.Now().In(location)
+
+	parsed, err := parseExpression(request.Recurrence, request.ExpressionType, request.Expression, now)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "incorrect expression used; %v", err)
+	}
+
+	response := &proto.PreviewScheduleResponse{
+		FireTimes:      &proto.FireTimes{},
+		ExpressionType: proto.ScheduledTask_ExpressionType(proto.ScheduledTask_ExpressionType_value[string(parsed.kind)]),
+		Recurrence:     proto.ScheduledTask_Recurrence(proto.ScheduledTask_Recurrence_value[string(parsed.recurrence)]),
+		Timezone:       location.String(),
+	}
+
+	if parsed.recurrence == models.ScheduledTaskRecurrenceAfterCompletion {
+		response.Delay = parsed.delay.String()
+		return response, nil
+	}
+
+	response.FireTimes = fireTimes(parsed.schedule, request.NextFireCount, now)
+	return response, nil
+}
+
+// maxNextFireCount is the most upcoming fire times we'll compute for a single schedule.
+const maxNextFireCount = 100
+
+// scheduledTaskFireTimes returns when the scheduled task given last created tasks, as recorded by the scheduler, and
+// computes when it will next fire relative to now.
+// Expressions that can't be parsed return no fire times rather than an error so that a single bad expression doesn't
+// prevent listing others.
+//
+// After completion scheduled tasks have at most one next fire time and none at all while their previous task is
+// still open.
+func (api *API) scheduledTaskFireTimes(scheduledTask storage.ScheduledTask, count int64, now time.Time) *proto.FireTimes {
+	parsed, err := api.parseScheduledTaskExpression(scheduledTask)
+	if err != nil {
+		return &proto.FireTimes{}
+	}
+
+	if parsed.recurrence != models.ScheduledTaskRecurrenceAfterCompletion {
+		times := fireTimes(parsed.schedule, count, now)
+		times.Last = scheduledTask.LastFired
+		return times
+	}
+
+	times := &proto.FireTimes{Last: scheduledTask.LastFired}
+
+	_, due, _, ok, err := api.nextInstanceDue(scheduledTask, parsed)
+	if err != nil {
+		log.Error().Err(err).Str("id", scheduledTask.ID).Msg("could not look up previous task for scheduled task")
+		return times
+	}
+
+	if ok && count > 0 {
+		times.Next = []int64{max(due.UnixMilli(), now.UnixMilli())}
+	}
+
+	return times
+}
+
+// fireTimes computes when the schedule given will next fire relative to now. When it last fired is left to the caller
+// since only the scheduler knows that.
+func fireTimes(parsedSchedule schedule.Schedule, count int64, now time.Time) *proto.FireTimes {
+	count = min(max(count, 0), maxNextFireCount)
+
+	times := &proto.FireTimes{}
+
+	for _, next := range schedule.Upcoming(parsedSchedule, now, int(count)) {
+		times.Next = append(times.Next, next.UnixMilli())
+	}
+
+	return times
+}
@@ -0,0 +1,430 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/clintjedwards/todo/internal/metrics"
+	"github.com/clintjedwards/todo/internal/models"
+	"github.com/clintjedwards/todo/internal/schedule"
+	"github.com/clintjedwards/todo/internal/storage"
+	"github.com/clintjedwards/todo/internal/tasktemplate"
+	proto "github.com/clintjedwards/todo/proto"
+	"github.com/rs/zerolog/log"
+)
+
+// parsedExpression is a scheduled task's expression along with how it was interpreted.
+type parsedExpression struct {
+	recurrence models.ScheduledTaskRecurrence
+	kind       schedule.Kind
+
+	// Only set for calendar recurrences.
+	schedule schedule.Schedule
+
+	// Only set for after completion recurrences.
+	delay schedule.Delay
+
+	// The time zone the expression is evaluated in.
+	location *time.Location
+}
+
+// parseExpression parses an expression with the recurrence and type given, detecting either from the expression if it
+// wasn't specified. The anchor is when the scheduled task was created, in the time zone the expression should be
+// evaluated in.
+func parseExpression(recurrence proto.ScheduledTask_Recurrence, expressionType proto.ScheduledTask_ExpressionType,
+	expression string, anchor time.Time,
+) (parsedExpression, error) {
+	parsed := parsedExpression{
+		recurrence: models.ScheduledTaskRecurrence(recurrence.String()),
+		kind:       schedule.Kind(expressionType.String()),
+		location:   anchor.Location(),
+	}
+
+	if parsed.recurrence == models.ScheduledTaskRecurrenceUnknown {
+		parsed.recurrence = models.ScheduledTaskRecurrenceCalendar
+		if schedule.IsAfterCompletion(expression) {
+			parsed.recurrence = models.ScheduledTaskRecurrenceAfterCompletion
+		}
+	}
+
+	if parsed.recurrence == models.ScheduledTaskRecurrenceAfterCompletion {
+		delay, err := schedule.ParseDelay(expression)
+		if err != nil {
+			return parsedExpression{}, err
+		}
+
+		parsed.kind = schedule.KindUnknown
+		parsed.delay = delay
+		return parsed, nil
+	}
+
+	if parsed.kind == schedule.KindUnknown {
+		parsed.kind = schedule.Detect(expression)
+	}
+
+	parsedSchedule, err := schedule.Parse(parsed.kind, expression, anchor)
+	if err != nil {
+		return parsedExpression{}, err
+	}
+
+	parsed.schedule = parsedSchedule
+	return parsed, nil
+}
+
+// parseScheduledTaskExpression parses the expression of a stored scheduled task.
+func (api *API) parseScheduledTaskExpression(scheduledTask storage.ScheduledTask) (parsedExpression, error) {
+	location, err := api.location(scheduledTask.Timezone)
+	if err != nil {
+		return parsedExpression{}, fmt.Errorf("time zone %q not recognized; %w", scheduledTask.Timezone, err)
+	}
+
+	return parseExpression(
+		proto.ScheduledTask_Recurrence(proto.ScheduledTask_Recurrence_value[scheduledTask.Recurrence]),
+		proto.ScheduledTask_ExpressionType(proto.ScheduledTask_ExpressionType_value[scheduledTask.ExpressionType]),
+		scheduledTask.Expression, time.UnixMilli(scheduledTask.Created).In(location))
+}
+
+// location returns the time zone with the IANA name given, falling back to the server's default time zone when the
+// name is empty.
+func (api *API) location(name string) (*time.Location, error) {
+	if name == "" {
+		name = api.config.Server.Timezone
+	}
+
+	// LoadLocation treats an empty name as UTC but we'd rather fall back to the time zone the server runs in.
+	if name == "" {
+		return time.Local, nil
+	}
+
+	return time.LoadLocation(name)
+}
+
+// startScheduledTask begins watching the scheduled task given in its own goroutine, creating new tasks as its
+// expression dictates. The goroutine runs until stopScheduledTask is called for the same id or the schedule has no
+// more fire times.
+func (api *API) startScheduledTask(scheduledTask storage.ScheduledTask) error {
+	parsed, err := api.parseScheduledTaskExpression(scheduledTask)
+	if err != nil {
+		// Whatever was watching the scheduled task before is stopped either way so that it doesn't carry on with
+		// details that have since changed. It counts as failed until it is started, paused or deleted.
+		api.scheduledTasksMu.Lock()
+		if existing, exists := api.scheduledTasks[scheduledTask.ID]; exists {
+			existing()
+			delete(api.scheduledTasks, scheduledTask.ID)
+		}
+		api.schedulerFailures[scheduledTask.ID] = struct{}{}
+		api.scheduledTasksMu.Unlock()
+
+		return fmt.Errorf("could not parse expression %q: %w", scheduledTask.Expression, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	api.scheduledTasksMu.Lock()
+	if existing, exists := api.scheduledTasks[scheduledTask.ID]; exists {
+		existing()
+	}
+	api.scheduledTasks[scheduledTask.ID] = cancel
+	delete(api.schedulerFailures, scheduledTask.ID)
+	api.scheduledTasksMu.Unlock()
+
+	if parsed.recurrence == models.ScheduledTaskRecurrenceAfterCompletion {
+		go api.runAfterCompletionTask(ctx, scheduledTask, parsed)
+		return nil
+	}
+
+	go api.runScheduledTask(ctx, scheduledTask, parsed.schedule)
+
+	return nil
+}
+
+// stopScheduledTask stops the goroutine watching the scheduled task with the given id if there is one. Scheduled tasks
+// that are stopped aren't expected to be running, so they no longer count as failed either.
+func (api *API) stopScheduledTask(id string) {
+	api.scheduledTasksMu.Lock()
+	defer api.scheduledTasksMu.Unlock()
+
+	delete(api.schedulerFailures, id)
+
+	cancel, exists := api.scheduledTasks[id]
+	if !exists {
+		return
+	}
+
+	cancel()
+	delete(api.scheduledTasks, id)
+}
+
+// runningScheduledTasks returns the number of scheduled tasks currently being watched.
+func (api *API) runningScheduledTasks() int {
+	api.scheduledTasksMu.Lock()
+	defer api.scheduledTasksMu.Unlock()
+
+	return len(api.scheduledTasks)
+}
+
+// failedScheduledTasks returns the number of scheduled tasks that should be running but couldn't be started.
+func (api *API) failedScheduledTasks() int {
+	api.scheduledTasksMu.Lock()
+	defer api.scheduledTasksMu.Unlock()
+
+	return len(api.schedulerFailures)
+}
+
+func (api *API) runScheduledTask(ctx context.Context, scheduledTask storage.ScheduledTask,
+	parsedSchedule schedule.Schedule,
+) {
+	next, ok := parsedSchedule.Next(time.Now())
+	for ok {
+		// We wait in steps of at most a minute rather than all at once since timers don't account for the machine
+		// sleeping or the wall clock being changed.
+		for wait := time.Until(next); wait > 0; wait = time.Until(next) {
+			select {
+			case <-ctx.Done():
+				log.Debug().Str("id", scheduledTask.ID).Msg("scheduled task processing cancelled")
+				return
+			case <-time.After(min(wait, time.Minute)):
+			}
+		}
+
+		api.fireScheduledTask(scheduledTask, next)
+
+		// Fire times missed while the machine was asleep are skipped rather than all created at once.
+		next, ok = parsedSchedule.Next(time.Now())
+	}
+
+	log.Info().Str("id", scheduledTask.ID).Msg("scheduled task has no more fire times")
+}
+
+// runAfterCompletionTask creates a new task from the scheduled task given whenever the previous one has been completed
+// for the delay given. The first task is created straight away.
+func (api *API) runAfterCompletionTask(ctx context.Context, scheduledTask storage.ScheduledTask,
+	parsed parsedExpression,
+) {
+	for {
+		wait := time.Minute
+
+		// The scheduled task is read again each time round since when it last fired is kept on it.
+		current, err := api.db.GetScheduledTask(api.db, scheduledTask.ID)
+		if err != nil {
+			log.Error().Err(err).Str("id", scheduledTask.ID).Msg("could not get scheduled task")
+		} else {
+			_, due, completed, ok, err := api.nextInstanceDue(current, parsed)
+			if err != nil {
+				log.Error().Err(err).Str("id", scheduledTask.ID).
+					Msg("could not look up previous task for scheduled task")
+			} else if ok {
+				if completed != current.LastCompleted {
+					api.recordScheduledTaskCompletion(current.ID, completed)
+				}
+
+				wait = min(time.Until(due), time.Minute)
+				if wait <= 0 {
+					metrics.SchedulerFires.Inc()
+					api.createScheduledTaskInstance(current, due)
+					wait = time.Minute
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Debug().Str("id", scheduledTask.ID).Msg("scheduled task processing cancelled")
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// nextInstanceDue returns when the next task should be created for an after completion scheduled task along with when
+// it last created one, which is the zero time if it never has. It returns false if the task it last created is still
+// unresolved.
+//
+// The task it last created is only used while it's still around; once it's deleted the times kept on the scheduled
+// task are used instead so that an older task's completion doesn't make the next one due straight away. Nothing is
+// written here: when that task was completed is returned so that the scheduler can keep it on the scheduled task.
+func (api *API) nextInstanceDue(scheduledTask storage.ScheduledTask, parsed parsedExpression,
+) (lastFired time.Time, due time.Time, completed int64, ok bool, err error) {
+	if scheduledTask.LastFired == 0 {
+		return time.Time{}, time.UnixMilli(scheduledTask.Created), 0, true, nil
+	}
+
+	lastFired = time.UnixMilli(scheduledTask.LastFired)
+	completed = scheduledTask.LastCompleted
+
+	task, err := api.db.GetLatestScheduledTaskInstance(api.db, scheduledTask.ID)
+	if err != nil && !errors.Is(err, storage.ErrEntityNotFound) {
+		return time.Time{}, time.Time{}, 0, false, err
+	}
+
+	// Tasks are created after the fire time is taken, so anything older was created by an earlier fire.
+	if err == nil && task.Created >= scheduledTask.LastFired {
+		if task.State != string(models.TaskStateCompleted) {
+			return lastFired, time.Time{}, completed, false, nil
+		}
+
+		completed = task.CompletedAt
+	}
+
+	// A task deleted before it was seen to be completed counts from when it was created.
+	from := lastFired
+	if completed != 0 {
+		from = time.UnixMilli(completed)
+	}
+
+	// Delays of days or longer keep the time of day the task was completed at in the scheduled task's time zone.
+	return lastFired, parsed.delay.After(from.In(parsed.location)), completed, true, nil
+}
+
+// fireScheduledTask is called every time a calendar scheduled task comes due and creates its new task unless the
+// scheduled task skips while its previous task is open.
+func (api *API) fireScheduledTask(scheduledTask storage.ScheduledTask, scheduledFor time.Time) {
+	metrics.SchedulerFires.Inc()
+
+	if scheduledTask.SkipIfOpen {
+		latest, err := api.db.GetLatestScheduledTaskInstance(api.db, scheduledTask.ID)
+		if err != nil && !errors.Is(err, storage.ErrEntityNotFound) {
+			metrics.SchedulerFailures.Inc()
+			log.Error().Err(err).Str("id", scheduledTask.ID).Msg("could not look up previous task for scheduled task")
+			return
+		}
+
+		if err == nil && latest.State != string(models.TaskStateCompleted) {
+			metrics.SchedulerSkips.Inc()
+			log.Debug().Str("id", scheduledTask.ID).Str("open_task_id", latest.ID).
+				Msg("skipped scheduled task since its previous task is still open")
+			return
+		}
+	}
+
+	api.createScheduledTaskInstance(scheduledTask, scheduledFor)
+}
+
+// createScheduledTaskInstance creates a new task from the scheduled task given, linked back to it along with the time
+// it was due. Scheduled tasks with a template create the template's whole tree instead, with only the tasks at the top
+// linked back.
+func (api *API) createScheduledTaskInstance(scheduledTask storage.ScheduledTask, scheduledFor time.Time) {
+	// Taken before any task is created so that every task created by this fire was created at or after it.
+	fired := time.Now().UnixMilli()
+
+	if scheduledTask.TemplateID != "" {
+		if api.createScheduledTemplateInstance(scheduledTask, scheduledFor) {
+			api.recordScheduledTaskFire(scheduledTask.ID, fired)
+		}
+		return
+	}
+
+	id, err := api.insertWithUniqueID(func(id string) error {
+		newTask := models.NewTask(id, scheduledTask.Title, scheduledTask.Description, scheduledTask.Parent)
+		newTask.ScheduledTaskID = scheduledTask.ID
+		newTask.ScheduledFor = scheduledFor.UnixMilli()
+		return api.db.InsertTask(api.db, newTask.ToStorage())
+	})
+	if err != nil {
+		metrics.SchedulerFailures.Inc()
+		log.Error().Err(err).Msg("could not create task")
+		return
+	}
+
+	api.recordScheduledTaskFire(scheduledTask.ID, fired)
+
+	log.Debug().Str("id", id).Str("title", scheduledTask.Title).
+		Str("scheduled_task_id", scheduledTask.ID).Msg("scheduled a new task")
+}
+
+// recordScheduledTaskFire keeps when the scheduled task given last created tasks on it. The task it created hasn't been
+// completed yet, so neither has the scheduled task's latest.
+func (api *API) recordScheduledTaskFire(id string, fired int64) {
+	err := api.db.UpdateScheduledTask(api.db, id, storage.UpdatableScheduledTaskFields{
+		LastFired:     &fired,
+		LastCompleted: ptr(int64(0)),
+	})
+	if err != nil {
+		metrics.SchedulerFailures.Inc()
+		log.Error().Err(err).Str("id", id).Msg("could not record when scheduled task fired")
+	}
+}
+
+// recordScheduledTaskCompletion keeps when the task the scheduled task given last created was completed on it, so that
+// it's still known once that task is deleted.
+func (api *API) recordScheduledTaskCompletion(id string, completed int64) {
+	err := api.db.UpdateScheduledTask(api.db, id, storage.UpdatableScheduledTaskFields{
+		LastCompleted: &completed,
+	})
+	if err != nil {
+		metrics.SchedulerFailures.Inc()
+		log.Error().Err(err).Str("id", id).Msg("could not record when scheduled task's latest task was completed")
+	}
+}
+
+// createScheduledTemplateInstance creates the template's tree for the scheduled task given, returning false if it
+// couldn't.
+func (api *API) createScheduledTemplateInstance(scheduledTask storage.ScheduledTask, scheduledFor time.Time) bool {
+	template, err := api.db.GetTemplate(api.db, scheduledTask.TemplateID)
+	if err != nil {
+		metrics.SchedulerFailures.Inc()
+		log.Error().Err(err).Str("id", scheduledTask.ID).Str("template_id", scheduledTask.TemplateID).
+			Msg("could not get template for scheduled task")
+		return false
+	}
+
+	// Variables describe when the tasks were due in the scheduled task's own time zone.
+	location, err := api.location(scheduledTask.Timezone)
+	if err != nil {
+		location = scheduledFor.Location()
+	}
+
+	ids, _, err := api.CreateTemplateTree(template, scheduledTask.Parent,
+		tasktemplate.Variables(scheduledFor.In(location)), func(task *models.Task) {
+			task.ScheduledTaskID = scheduledTask.ID
+			task.ScheduledFor = scheduledFor.UnixMilli()
+		})
+	if err != nil {
+		metrics.SchedulerFailures.Inc()
+		log.Error().Err(err).Str("id", scheduledTask.ID).Msg("could not create tasks from template")
+		return false
+	}
+
+	log.Debug().Strs("ids", ids).Str("template", template.Name).
+		Str("scheduled_task_id", scheduledTask.ID).Msg("scheduled new tasks from template")
+	return true
+}
+
+// restoreReoccurringTasks starts watching every scheduled task in the database that isn't paused. It is called once on
+// startup.
+// Scheduled tasks that fail to start are logged and counted so that they can be surfaced by readiness checks.
+func (api *API) restoreReoccurringTasks() error {
+	offset := 0
+
+	for {
+		scheduledTasks, err := api.db.ListScheduledTasks(api.db, offset, 0)
+		if err != nil {
+			return err
+		}
+
+		for _, task := range scheduledTasks {
+			if task.Paused {
+				continue
+			}
+
+			err := api.startScheduledTask(task)
+			if err != nil {
+				log.Error().Err(err).Str("id", task.ID).Msg("could not start monitoring for scheduled task")
+			}
+		}
+
+		if len(scheduledTasks) < api.config.Server.StorageResultsLimit || len(scheduledTasks) == 0 {
+			break
+		}
+
+		offset += len(scheduledTasks)
+	}
+
+	api.schedulerRestored.Store(true)
+
+	log.Info().Int("running", api.runningScheduledTasks()).Int("failed", api.failedScheduledTasks()).
+		Msg("restored scheduled tasks")
+	return nil
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clintjedwards/todo/internal/models"
+	"github.com/clintjedwards/todo/internal/storage"
+)
+
+func TestNextInstanceDue(t *testing.T) {
+	api := newTestAPI(t)
+
+	scheduledTask := storage.ScheduledTask{
+		ID:         "sched",
+		Title:      "Water plants",
+		Expression: "every 2 hours after completion",
+		Recurrence: string(models.ScheduledTaskRecurrenceAfterCompletion),
+		Created:    1_000,
+	}
+
+	err := api.db.InsertScheduledTask(api.db, &scheduledTask)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := api.parseScheduledTaskExpression(scheduledTask)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// get reads the scheduled task back as the scheduler does, along with anything the scheduler kept on it.
+	get := func() storage.ScheduledTask {
+		t.Helper()
+
+		scheduledTask, err := api.db.GetScheduledTask(api.db, "sched")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return scheduledTask
+	}
+
+	// Never fired: the first task is due straight away.
+	_, due, _, ok, err := api.nextInstanceDue(get(), parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || due.UnixMilli() != scheduledTask.Created {
+		t.Fatalf("expected first task to be due when the scheduled task was created; got %v %v", ok, due)
+	}
+
+	// An older task, completed long ago, is left over from an earlier fire.
+	older := storage.Task{ID: "older", Title: "older", State: string(models.TaskStateCompleted), Created: 2_000,
+		CompletedAt: 3_000, ScheduledTaskID: "sched"}
+	err = api.db.InsertTask(api.db, &older)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	api.recordScheduledTaskFire("sched", 10_000)
+
+	latest := storage.Task{ID: "latest", Title: "latest", State: string(models.TaskStateUnresolved), Created: 10_001,
+		ScheduledTaskID: "sched"}
+	err = api.db.InsertTask(api.db, &latest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lastFired, _, _, ok, err := api.nextInstanceDue(get(), parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("expected nothing to be due while the latest task is open")
+	}
+	if lastFired.UnixMilli() != 10_000 {
+		t.Errorf("expected last fire time 10000; got %d", lastFired.UnixMilli())
+	}
+
+	err = api.db.UpdateTask(api.db, "latest", storage.UpdatableTaskFields{
+		State:       ptr(string(models.TaskStateCompleted)),
+		CompletedAt: ptr(int64(20_000)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantDue := time.UnixMilli(20_000).Add(2 * time.Hour)
+
+	_, due, completed, ok, err := api.nextInstanceDue(get(), parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || !due.Equal(wantDue) {
+		t.Fatalf("expected next task due at %v; got %v %v", wantDue, ok, due)
+	}
+	if completed != 20_000 {
+		t.Errorf("expected completion time 20000; got %d", completed)
+	}
+
+	// Looking up when the next task is due only reads; keeping the completion is left to the scheduler.
+	if lastCompleted := get().LastCompleted; lastCompleted != 0 {
+		t.Errorf("expected nextInstanceDue not to keep the completion time; got %d", lastCompleted)
+	}
+
+	api.recordScheduledTaskCompletion("sched", completed)
+
+	// Deleting the latest task mustn't fall back to the older one and make the next task due straight away.
+	err = api.db.DeleteTask(api.db, "latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, due, _, ok, err = api.nextInstanceDue(get(), parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || !due.Equal(wantDue) {
+		t.Fatalf("expected next task still due at %v after deleting the latest; got %v %v", wantDue, ok, due)
+	}
+
+	// A task deleted before it was seen to be completed counts from when it was created.
+	api.recordScheduledTaskFire("sched", 30_000)
+
+	_, due, _, ok, err = api.nextInstanceDue(get(), parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wantDue := time.UnixMilli(30_000).Add(2 * time.Hour); !ok || !due.Equal(wantDue) {
+		t.Fatalf("expected next task due at %v; got %v %v", wantDue, ok, due)
+	}
+}
+
+func TestScheduledTaskFireTimesLastFired(t *testing.T) {
+	api := newTestAPI(t)
+
+	scheduledTask := storage.ScheduledTask{
+		ID:         "sched",
+		Title:      "Take out the bins",
+		Expression: "0 9 * * *",
+		Recurrence: string(models.ScheduledTaskRecurrenceCalendar),
+		Created:    1_000,
+	}
+
+	now := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+
+	// Having never fired, there's nothing to show even though the expression would have come due before now.
+	times := api.scheduledTaskFireTimes(scheduledTask, 1, now)
+	if times.Last != 0 {
+		t.Errorf("expected no last fire time for a scheduled task that has never fired; got %d", times.Last)
+	}
+	if len(times.Next) != 1 {
+		t.Errorf("expected 1 upcoming fire time; got %d", len(times.Next))
+	}
+
+	scheduledTask.LastFired = 5_000
+
+	times = api.scheduledTaskFireTimes(scheduledTask, 1, now)
+	if times.Last != 5_000 {
+		t.Errorf("expected the recorded last fire time 5000; got %d", times.Last)
+	}
+}
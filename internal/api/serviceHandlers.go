@@ -2,8 +2,14 @@ package api
 
 import (
 	"context"
+	"net/url"
+	"time"
 
+	"github.com/clintjedwards/todo/internal/storage"
 	proto "github.com/clintjedwards/todo/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // GetSystemInfo returns system information and health
@@ -12,9 +18,60 @@ func (api *API) GetSystemInfo(context context.Context, request *proto.GetSystemI
 
 	devModeEnabled := api.config.Development.PrettyLogging || api.config.Development.UseLocalhostTLS
 
+	schemaVersion, err := api.db.SchemaVersion()
+	if err != nil {
+		log.Error().Err(err).Msg("could not get schema version")
+		return nil, status.Error(codes.Internal, "could not get schema version")
+	}
+
+	size, err := api.db.Size()
+	if err != nil {
+		log.Error().Err(err).Msg("could not get storage size")
+		return nil, status.Error(codes.Internal, "could not get storage size")
+	}
+
+	taskCounts, err := api.db.CountTasks(api.db)
+	if err != nil {
+		log.Error().Err(err).Msg("could not count tasks")
+		return nil, status.Error(codes.Internal, "could not count tasks")
+	}
+
+	scheduledTaskCount, err := api.db.CountScheduledTasks(api.db)
+	if err != nil {
+		log.Error().Err(err).Msg("could not count scheduled tasks")
+		return nil, status.Error(codes.Internal, "could not count scheduled tasks")
+	}
+
 	return &proto.GetSystemInfoResponse{
-		Commit:         commit,
-		DevModeEnabled: devModeEnabled,
-		Semver:         version,
+		Commit:           commit,
+		DevModeEnabled:   devModeEnabled,
+		Semver:           version,
+		SchemaVersion:    schemaVersion,
+		UptimeSeconds:    int64(time.Since(api.started).Seconds()),
+		StorageDriver:    api.config.Server.StorageDriver,
+		StoragePath:      api.storageLocation(),
+		StorageSizeBytes: size,
+		UnresolvedTasks:  taskCounts[proto.Task_UNRESOLVED.String()],
+		CompletedTasks:   taskCounts[proto.Task_COMPLETED.String()],
+		ScheduledTasks:   scheduledTaskCount,
+		Scheduler: &proto.SchedulerInfo{
+			Restored: api.schedulerRestored.Load(),
+			Running:  int64(api.runningScheduledTasks()),
+			Failed:   int64(api.failedScheduledTasks()),
+		},
 	}, nil
 }
+
+// storageLocation returns where the database lives in a form that is safe to show to users.
+func (api *API) storageLocation() string {
+	if storage.Driver(api.config.Server.StorageDriver) != storage.DriverPostgres {
+		return api.config.Server.StoragePath
+	}
+
+	parsedURL, err := url.Parse(api.config.Server.StorageURL)
+	if err != nil {
+		return ""
+	}
+
+	return parsedURL.Redacted()
+}
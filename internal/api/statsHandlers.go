@@ -0,0 +1,204 @@
+package api
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/clintjedwards/todo/internal/models"
+	"github.com/clintjedwards/todo/internal/storage"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultStatsDays  = 14
+	defaultStatsWeeks = 8
+
+	// The most days or weeks of completions that can be asked for at once.
+	maxStatsPeriods = 366
+)
+
+// The buckets open tasks are sorted into by age, youngest first. Each holds tasks younger than its limit; the last
+// has no limit and holds everything older.
+var ageBuckets = []struct {
+	label string
+	limit time.Duration
+}{
+	{"under a day", 24 * time.Hour},
+	{"1-7 days", 7 * 24 * time.Hour},
+	{"1-4 weeks", 28 * 24 * time.Hour},
+	{"1-3 months", 90 * 24 * time.Hour},
+	{"over 3 months", 0},
+}
+
+func (api *API) GetTaskStats(ctx context.Context, request *proto.GetTaskStatsRequest) (*proto.GetTaskStatsResponse, error) {
+	days := request.Days
+	if days <= 0 {
+		days = defaultStatsDays
+	}
+
+	weeks := request.Weeks
+	if weeks <= 0 {
+		weeks = defaultStatsWeeks
+	}
+
+	if days > maxStatsPeriods || weeks > maxStatsPeriods {
+		return nil, status.Errorf(codes.FailedPrecondition, "at most %d days or weeks can be counted", maxStatsPeriods)
+	}
+
+	location, err := api.location(request.Timezone)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "time zone %q not recognized", request.Timezone)
+	}
+
+	// Stats cover every task so we page through all of them.
+	tasks := []storage.Task{}
+	for {
+		page, err := api.db.ListTasks(api.db, len(tasks), 0, false)
+		if err != nil {
+			log.Error().Err(err).Msg("could not list tasks")
+			return nil, status.Error(codes.Internal, "failed to retrieve tasks from database")
+		}
+
+		tasks = append(tasks, page...)
+
+		if len(page) < api.config.Server.StorageResultsLimit || len(page) == 0 {
+			break
+		}
+	}
+
+	stats := summarizeTasks(tasks, time.Now().In(location), int(days), int(weeks))
+	stats.Timezone = location.String()
+
+	return stats, nil
+}
+
+// summarizeTasks works out the stats for the tasks given as of now. Days and weeks are counted in now's time zone.
+func summarizeTasks(tasks []storage.Task, now time.Time, days, weeks int) *proto.GetTaskStatsResponse {
+	stats := &proto.GetTaskStatsResponse{}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	thisWeek := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+
+	for i := range days {
+		stats.CompletedPerDay = append(stats.CompletedPerDay, &proto.PeriodCount{
+			Start: today.AddDate(0, 0, i-days+1).UnixMilli(),
+		})
+	}
+
+	for i := range weeks {
+		stats.CompletedPerWeek = append(stats.CompletedPerWeek, &proto.PeriodCount{
+			Start: thisWeek.AddDate(0, 0, 7*(i-weeks+1)).UnixMilli(),
+		})
+	}
+
+	for _, bucket := range ageBuckets {
+		stats.OpenByAge = append(stats.OpenByAge, &proto.AgeBucket{Label: bucket.label})
+	}
+
+	var timeToComplete time.Duration
+	var timed int64
+	for _, task := range tasks {
+		if task.State != string(models.TaskStateCompleted) {
+			stats.Open++
+			stats.OpenByAge[ageBucket(now.Sub(time.UnixMilli(task.Created)))].Count++
+			continue
+		}
+
+		stats.Completed++
+
+		completed := task.CompletedAt
+		if completed == 0 {
+			continue
+		}
+
+		timeToComplete += time.UnixMilli(completed).Sub(time.UnixMilli(task.Created))
+		timed++
+		countPeriod(stats.CompletedPerDay, completed)
+		countPeriod(stats.CompletedPerWeek, completed)
+	}
+
+	if timed > 0 {
+		stats.AverageTimeToComplete = (timeToComplete / time.Duration(timed)).Milliseconds()
+	}
+
+	stats.Progress = taskProgress(tasks)
+
+	return stats
+}
+
+func ageBucket(age time.Duration) int {
+	for i, bucket := range ageBuckets {
+		if bucket.limit == 0 || age < bucket.limit {
+			return i
+		}
+	}
+
+	return len(ageBuckets) - 1
+}
+
+// countPeriod adds one to the period the time given falls in. Periods are oldest first and each runs until the next
+// one starts; times before the first period aren't counted.
+func countPeriod(periods []*proto.PeriodCount, unixMilli int64) {
+	for i := len(periods) - 1; i >= 0; i-- {
+		if unixMilli >= periods[i].Start {
+			periods[i].Count++
+			return
+		}
+	}
+}
+
+// taskProgress returns how far along each open top level task with children is, counting every task beneath it.
+func taskProgress(tasks []storage.Task) []*proto.TaskProgress {
+	children := map[string][]storage.Task{}
+	for _, task := range tasks {
+		if task.Parent != "" {
+			children[task.Parent] = append(children[task.Parent], task)
+		}
+	}
+
+	// Tasks are only counted once so that tasks made each other's parents before that was refused can't loop forever.
+	counted := map[string]bool{}
+
+	var count func(id string) (completed, total int64)
+	count = func(id string) (completed, total int64) {
+		for _, child := range children[id] {
+			if counted[child.ID] {
+				continue
+			}
+			counted[child.ID] = true
+
+			total++
+			if child.State == string(models.TaskStateCompleted) {
+				completed++
+			}
+
+			childCompleted, childTotal := count(child.ID)
+			completed += childCompleted
+			total += childTotal
+		}
+		return completed, total
+	}
+
+	progress := []*proto.TaskProgress{}
+	for _, task := range tasks {
+		if task.Parent != "" || task.State == string(models.TaskStateCompleted) || len(children[task.ID]) == 0 {
+			continue
+		}
+
+		completed, total := count(task.ID)
+		progress = append(progress, &proto.TaskProgress{
+			Id:        task.ID,
+			Title:     task.Title,
+			Completed: completed,
+			Total:     total,
+		})
+	}
+
+	sort.Slice(progress, func(i, j int) bool { return progress[i].Id < progress[j].Id })
+
+	return progress
+}
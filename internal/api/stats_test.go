@@ -0,0 +1,254 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clintjedwards/todo/internal/storage"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return location
+}
+
+// starts returns the start of each period given, in unix milliseconds.
+func starts(periods []*proto.PeriodCount) []int64 {
+	starts := []int64{}
+	for _, period := range periods {
+		starts = append(starts, period.Start)
+	}
+	return starts
+}
+
+func TestSummarizeTasksPeriods(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	// Clocks in New York went forward an hour at 2am on Sunday the 9th of March 2025, so that week is an hour short.
+	date := func(day, hour, minute int) time.Time {
+		return time.Date(2025, time.March, day, hour, minute, 0, 0, newYork)
+	}
+
+	tests := []struct {
+		name  string
+		now   time.Time
+		days  []int64
+		weeks []int64
+	}{
+		{
+			name:  "week clocks went forward",
+			now:   date(9, 12, 0),
+			days:  []int64{date(7, 0, 0).UnixMilli(), date(8, 0, 0).UnixMilli(), date(9, 0, 0).UnixMilli()},
+			weeks: []int64{date(3, 0, 0).UnixMilli()},
+		},
+		{
+			name:  "days either side of clocks going forward",
+			now:   date(11, 10, 0),
+			days:  []int64{date(9, 0, 0).UnixMilli(), date(10, 0, 0).UnixMilli(), date(11, 0, 0).UnixMilli()},
+			weeks: []int64{date(10, 0, 0).UnixMilli()},
+		},
+		{
+			name:  "start of monday",
+			now:   date(10, 0, 0),
+			days:  []int64{date(8, 0, 0).UnixMilli(), date(9, 0, 0).UnixMilli(), date(10, 0, 0).UnixMilli()},
+			weeks: []int64{date(10, 0, 0).UnixMilli()},
+		},
+		{
+			name:  "end of sunday",
+			now:   date(16, 23, 59),
+			days:  []int64{date(14, 0, 0).UnixMilli(), date(15, 0, 0).UnixMilli(), date(16, 0, 0).UnixMilli()},
+			weeks: []int64{date(10, 0, 0).UnixMilli()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			stats := summarizeTasks(nil, tc.now, 3, 1)
+
+			if diff := cmp.Diff(tc.days, starts(stats.CompletedPerDay)); diff != "" {
+				t.Errorf("unexpected day starts (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.weeks, starts(stats.CompletedPerWeek)); diff != "" {
+				t.Errorf("unexpected week starts (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// Weeks run Monday to Monday even when one of them is only 167 hours long.
+	stats := summarizeTasks(nil, date(12, 10, 0), 1, 2)
+	if got := stats.CompletedPerWeek[1].Start - stats.CompletedPerWeek[0].Start; got != (167 * time.Hour).Milliseconds() {
+		t.Errorf("expected the week clocks went forward to be 167 hours long; got %v", time.Duration(got)*time.Millisecond)
+	}
+}
+
+func TestSummarizeTasks(t *testing.T) {
+	now := time.Date(2025, time.March, 12, 12, 0, 0, 0, time.UTC)
+	at := func(age time.Duration) int64 { return now.Add(-age).UnixMilli() }
+
+	tasks := []storage.Task{
+		{ID: "open1", State: "UNRESOLVED", Created: at(time.Hour)},
+		{ID: "open2", State: "UNRESOLVED", Created: at(10 * 24 * time.Hour)},
+		{ID: "done1", State: "COMPLETED", Created: at(3 * time.Hour), CompletedAt: at(time.Hour)},
+		{ID: "done2", State: "COMPLETED", Created: at(30 * time.Hour), CompletedAt: at(26 * time.Hour)},
+		// Completed before completion times were recorded, so it is counted but not timed.
+		{ID: "done3", State: "COMPLETED", Created: at(time.Hour)},
+	}
+
+	stats := summarizeTasks(tasks, now, 2, 1)
+
+	if stats.Open != 2 || stats.Completed != 3 {
+		t.Errorf("expected 2 open and 3 completed; got %d open and %d completed", stats.Open, stats.Completed)
+	}
+
+	if want := (3 * time.Hour).Milliseconds(); stats.AverageTimeToComplete != want {
+		t.Errorf("expected average time to complete of %d; got %d", want, stats.AverageTimeToComplete)
+	}
+
+	counts := []int64{}
+	for _, period := range stats.CompletedPerDay {
+		counts = append(counts, period.Count)
+	}
+	if diff := cmp.Diff([]int64{1, 1}, counts); diff != "" {
+		t.Errorf("unexpected completions per day (-want +got):\n%s", diff)
+	}
+
+	if stats.CompletedPerWeek[0].Count != 2 {
+		t.Errorf("expected 2 completions this week; got %d", stats.CompletedPerWeek[0].Count)
+	}
+
+	ages := []int64{}
+	for _, bucket := range stats.OpenByAge {
+		ages = append(ages, bucket.Count)
+	}
+	if diff := cmp.Diff([]int64{1, 0, 1, 0, 0}, ages); diff != "" {
+		t.Errorf("unexpected open tasks by age (-want +got):\n%s", diff)
+	}
+}
+
+func TestCountPeriod(t *testing.T) {
+	tests := []struct {
+		name   string
+		time   int64
+		counts []int64
+	}{
+		{name: "before the first period", time: 99, counts: []int64{0, 0, 0}},
+		{name: "start of the first period", time: 100, counts: []int64{1, 0, 0}},
+		{name: "end of the first period", time: 199, counts: []int64{1, 0, 0}},
+		{name: "start of a period", time: 200, counts: []int64{0, 1, 0}},
+		{name: "after the last period started", time: 10000, counts: []int64{0, 0, 1}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			periods := []*proto.PeriodCount{{Start: 100}, {Start: 200}, {Start: 300}}
+
+			countPeriod(periods, tc.time)
+
+			counts := []int64{}
+			for _, period := range periods {
+				counts = append(counts, period.Count)
+			}
+			if diff := cmp.Diff(tc.counts, counts); diff != "" {
+				t.Errorf("unexpected counts (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAgeBucket(t *testing.T) {
+	day := 24 * time.Hour
+
+	tests := []struct {
+		age    time.Duration
+		bucket string
+	}{
+		{age: 0, bucket: "under a day"},
+		{age: day - time.Millisecond, bucket: "under a day"},
+		{age: day, bucket: "1-7 days"},
+		{age: 7*day - time.Millisecond, bucket: "1-7 days"},
+		{age: 7 * day, bucket: "1-4 weeks"},
+		{age: 28 * day, bucket: "1-3 months"},
+		{age: 90*day - time.Millisecond, bucket: "1-3 months"},
+		{age: 90 * day, bucket: "over 3 months"},
+		{age: 1000 * day, bucket: "over 3 months"},
+		// Clocks can disagree about when a task was created.
+		{age: -time.Hour, bucket: "under a day"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.age.String(), func(t *testing.T) {
+			if got := ageBuckets[ageBucket(tc.age)].label; got != tc.bucket {
+				t.Errorf("expected bucket %q; got %q", tc.bucket, got)
+			}
+		})
+	}
+}
+
+func TestTaskProgress(t *testing.T) {
+	tests := []struct {
+		name     string
+		tasks    []storage.Task
+		progress []*proto.TaskProgress
+	}{
+		{
+			name: "counts every level",
+			tasks: []storage.Task{
+				{ID: "root", Title: "root", State: "UNRESOLVED"},
+				{ID: "child1", Parent: "root", State: "COMPLETED"},
+				{ID: "child2", Parent: "root", State: "UNRESOLVED"},
+				{ID: "grandchild", Parent: "child2", State: "COMPLETED"},
+			},
+			progress: []*proto.TaskProgress{{Id: "root", Title: "root", Completed: 2, Total: 3}},
+		},
+		{
+			name: "skips completed and childless tasks",
+			tasks: []storage.Task{
+				{ID: "done", State: "COMPLETED"},
+				{ID: "child", Parent: "done", State: "COMPLETED"},
+				{ID: "alone", State: "UNRESOLVED"},
+			},
+			progress: []*proto.TaskProgress{},
+		},
+		{
+			name: "sorted by id",
+			tasks: []storage.Task{
+				{ID: "b", Title: "b", State: "UNRESOLVED"},
+				{ID: "b1", Parent: "b", State: "UNRESOLVED"},
+				{ID: "a", Title: "a", State: "UNRESOLVED"},
+				{ID: "a1", Parent: "a", State: "COMPLETED"},
+			},
+			progress: []*proto.TaskProgress{
+				{Id: "a", Title: "a", Completed: 1, Total: 1},
+				{Id: "b", Title: "b", Completed: 0, Total: 1},
+			},
+		},
+		{
+			name: "tasks that are each other's parents",
+			tasks: []storage.Task{
+				{ID: "root", Title: "root", State: "UNRESOLVED"},
+				{ID: "child", Parent: "root", State: "COMPLETED"},
+				{ID: "x", Parent: "y", State: "UNRESOLVED"},
+				{ID: "y", Parent: "x", State: "UNRESOLVED"},
+			},
+			progress: []*proto.TaskProgress{{Id: "root", Title: "root", Completed: 1, Total: 1}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.progress, taskProgress(tc.tasks), protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected progress (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/clintjedwards/todo/internal/models"
+	proto "github.com/clintjedwards/todo/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (api *API) AddTaskComment(ctx context.Context, request *proto.AddTaskCommentRequest) (*proto.AddTaskCommentResponse, error) {
+	if strings.TrimSpace(request.Text) == "" {
+		return nil, status.Error(codes.FailedPrecondition, "comment text required")
+	}
+
+	taskID, err := api.resolveTaskID(request.TaskId)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment *models.TaskComment
+	_, err = api.insertWithUniqueID(func(id string) error {
+		comment = models.NewTaskComment(id, taskID, request.Author, request.Text)
+		return api.db.InsertTaskComment(api.db, comment.ToStorage())
+	})
+	if err != nil {
+		log.Error().Err(err).Str("task_id", taskID).Msg("could not insert task comment")
+		return nil, status.Error(codes.Internal, "could not insert task comment")
+	}
+
+	return &proto.AddTaskCommentResponse{
+		Comment: comment.ToStorage().ToProto(),
+	}, nil
+}
+
+func (api *API) ListTaskComments(ctx context.Context, request *proto.ListTaskCommentsRequest) (*proto.ListTaskCommentsResponse, error) {
+	taskID, err := api.resolveTaskID(request.TaskId)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := api.db.ListTaskComments(api.db, taskID, int(request.Offset), int(request.Limit))
+	if err != nil {
+		log.Error().Err(err).Str("task_id", taskID).Msg("could not get task comments")
+		return nil, status.Error(codes.Internal, "failed to retrieve task comments from database")
+	}
+
+	protoComments := []*proto.TaskComment{}
+	for _, comment := range comments {
+		protoComments = append(protoComments, comment.ToProto())
+	}
+
+	return &proto.ListTaskCommentsResponse{
+		Comments: protoComments,
+	}, nil
+}
@@ -1,37 +1,74 @@
 package api
 
 import (
-	"context"
+	"errors"
+	"slices"
 	"time"
 
-	"github.com/clintjedwards/avail/v2"
 	"github.com/clintjedwards/todo/internal/models"
 	"github.com/clintjedwards/todo/internal/storage"
-	"github.com/jmoiron/sqlx"
+	proto "github.com/clintjedwards/todo/proto"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// Deletes a parent task and all it's children recursively.
+// Deletes a parent task and all it's children recursively, recording the deletion in the journal so it can be undone.
+// The tasks' comments and attachments are deleted along with them and brought back by an undo.
 func (api *API) DeleteTaskTree(id string) ([]string, error) {
 	deletedTasks := []string{}
+	var digests []string
 
-	err := storage.InsideTx(api.db.DB, func(tx *sqlx.Tx) error {
-		err := api.recursivelyDeleteTasks(tx, id, &deletedTasks)
+	err := api.db.InsideTx(func(tx storage.Queryable) error {
+		journal := newJournal()
+
+		err := api.recursivelyDeleteTasks(tx, id, &deletedTasks, journal)
 		if err != nil {
 			return err
 		}
-		return nil
+
+		digests, err = api.recordOperation(tx, models.OperationKindDelete, journal.describe("Deleted", 1),
+			journal, time.Now().UnixMilli())
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	api.removeUnusedBlobs(digests)
+
 	return deletedTasks, nil
 }
 
-// Deletes a parent task and all it's children recursively.
-func (api *API) recursivelyDeleteTasks(tx *sqlx.Tx, id string, deletedTasks *[]string) error {
-	err := api.db.DeleteTask(tx, id)
+// Deletes a parent task and all it's children recursively, along with their comments and attachments. The comments
+// and attachments are kept in the journal along with the tasks; attachment contents are left in place.
+func (api *API) recursivelyDeleteTasks(tx storage.Queryable, id string, deletedTasks *[]string, journal *journal) error {
+	err := journal.capture(api.db, tx, id)
+	if err != nil {
+		return err
+	}
+
+	err = journal.captureComments(api.db, tx, id)
+	if err != nil {
+		return err
+	}
+
+	err = journal.captureAttachments(api.db, tx, id)
+	if err != nil {
+		return err
+	}
+
+	err = api.db.DeleteTaskAttachments(tx, id)
+	if err != nil {
+		return err
+	}
+
+	err = api.db.DeleteTask(tx, id)
+	if err != nil {
+		return err
+	}
+
+	err = api.db.DeleteTaskComments(tx, id)
 	if err != nil {
 		return err
 	}
@@ -45,7 +82,7 @@ func (api *API) recursivelyDeleteTasks(tx *sqlx.Tx, id string, deletedTasks *[]s
 	}
 
 	for _, task := range children {
-		err := api.recursivelyDeleteTasks(tx, task.ID, deletedTasks)
+		err := api.recursivelyDeleteTasks(tx, task.ID, deletedTasks, journal)
 		if err != nil {
 			return err
 		}
@@ -54,29 +91,125 @@ func (api *API) recursivelyDeleteTasks(tx *sqlx.Tx, id string, deletedTasks *[]s
 	return nil
 }
 
-// Complete a parent task and all it's children recursively.
-func (api *API) CompleteTaskTree(id string) ([]string, error) {
-	completedTasks := []string{}
+// Updates a task and, if it is being completed, all it's children recursively, recording the change in the journal so
+// it can be undone. If expectedVersion is given the update fails with storage.ErrConflict unless the task is still at
+// that version. It returns the IDs of every task changed.
+func (api *API) UpdateTaskTree(id string, expectedVersion *int64, fields storage.UpdatableTaskFields) ([]string, error) {
+	updatedTasks := []string{}
+	var digests []string
+
+	err := api.db.InsideTx(func(tx storage.Queryable) error {
+		journal := newJournal()
 
-	err := storage.InsideTx(api.db.DB, func(tx *sqlx.Tx) error {
-		err := api.recursivelyCompleteTasks(tx, id, &completedTasks)
+		err := journal.capture(api.db, tx, id)
 		if err != nil {
 			return err
 		}
-		return nil
+
+		if fields.Parent != nil {
+			err = api.checkParent(tx, id, *fields.Parent)
+			if err != nil {
+				return err
+			}
+		}
+
+		if expectedVersion != nil {
+			err = api.db.UpdateTaskAtVersion(tx, id, *expectedVersion, fields)
+		} else {
+			err = api.db.UpdateTask(tx, id, fields)
+		}
+		if err != nil {
+			return err
+		}
+
+		kind, verb := models.OperationKindUpdate, "Updated"
+
+		if fields.State != nil && *fields.State == string(models.TaskStateCompleted) {
+			if journal.before[0].State != string(models.TaskStateCompleted) {
+				kind, verb = models.OperationKindComplete, "Completed"
+			}
+
+			note := ""
+			if fields.CompletionNote != nil {
+				note = *fields.CompletionNote
+			}
+
+			// If we have completed a task we want to also complete all it's children.
+			err := api.recursivelyCompleteTasks(tx, id, *fields.Modified, note, &updatedTasks, journal)
+			if err != nil {
+				return err
+			}
+		} else {
+			updatedTasks = append(updatedTasks, id)
+		}
+
+		digests, err = api.recordOperation(tx, kind, journal.describe(verb, 1), journal, *fields.Modified)
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return completedTasks, nil
+	api.removeUnusedBlobs(digests)
+
+	return updatedTasks, nil
 }
 
-// Completes a parent task and all it's children recursively.
-func (api *API) recursivelyCompleteTasks(tx *sqlx.Tx, id string, completedTasks *[]string) error {
-	err := api.db.UpdateTask(tx, id, storage.UpdatableTaskFields{
-		State: ptr(string(models.TaskStateCompleted)),
-	})
+// checkParent returns errParentCycle if making parent the parent of the task with the ID given would put the task
+// beneath itself. It follows parent's own parents up to the top level task, so it has to run in the same transaction
+// as the update for another update not to slip a cycle in between.
+func (api *API) checkParent(tx storage.Queryable, id, parent string) error {
+	seen := map[string]bool{}
+
+	for parent != "" {
+		if parent == id {
+			return errParentCycle
+		}
+
+		// Tasks already caught in a cycle are left for the walk to stop on rather than loop forever.
+		if seen[parent] {
+			return nil
+		}
+		seen[parent] = true
+
+		task, err := api.db.GetTask(tx, parent)
+		if err != nil {
+			return err
+		}
+
+		parent = task.Parent
+	}
+
+	return nil
+}
+
+// Completes a parent task and all it's children recursively. Modified is set on every task so that we know when each
+// was changed. Tasks that weren't already completed are also stamped with when they were completed and the note given;
+// those that were keep their own.
+func (api *API) recursivelyCompleteTasks(tx storage.Queryable, id string, completedAt int64, note string,
+	completedTasks *[]string, journal *journal,
+) error {
+	// Guards against looping forever on tasks that were made each other's parents before that was refused.
+	if slices.Contains(*completedTasks, id) {
+		return nil
+	}
+
+	err := journal.capture(api.db, tx, id)
+	if err != nil {
+		return err
+	}
+
+	fields := storage.UpdatableTaskFields{
+		State:    ptr(string(models.TaskStateCompleted)),
+		Modified: &completedAt,
+	}
+
+	if original, _ := journal.original(id); original.State != string(models.TaskStateCompleted) {
+		fields.CompletedAt = &completedAt
+		fields.CompletionNote = &note
+	}
+
+	err = api.db.UpdateTask(tx, id, fields)
 	if err != nil {
 		return err
 	}
@@ -89,7 +222,7 @@ func (api *API) recursivelyCompleteTasks(tx *sqlx.Tx, id string, completedTasks
 	}
 
 	for _, task := range children {
-		err := api.recursivelyCompleteTasks(tx, task.ID, completedTasks)
+		err := api.recursivelyCompleteTasks(tx, task.ID, completedAt, note, completedTasks, journal)
 		if err != nil {
 			return err
 		}
@@ -98,47 +231,106 @@ func (api *API) recursivelyCompleteTasks(tx *sqlx.Tx, id string, completedTasks
 	return nil
 }
 
-func (api *API) restoreReoccurringTasks() error {
-	scheduledTasks, err := api.db.ListScheduledTasks(api.db, 0, 0)
-	if err != nil {
-		return err
+// completionFields adds what else changes alongside a task's state to the fields given: completing a task records the
+// note given, while reopening it clears when and why it was completed. It returns an error suitable for returning to
+// the client if a note is given without completing the task.
+func completionFields(fields *storage.UpdatableTaskFields, state *proto.UpdateTaskRequest_TaskState, note *string,
+) error {
+	if state != nil && *state == proto.UpdateTaskRequest_UNRESOLVED {
+		if note != nil {
+			return status.Error(codes.FailedPrecondition, "a completion note can't be given when reopening a task")
+		}
+
+		fields.CompletedAt = ptr(int64(0))
+		fields.CompletionNote = ptr("")
+		return nil
 	}
 
-	for _, task := range scheduledTasks {
-		task := task
+	fields.CompletionNote = note
+	return nil
+}
 
-		innerCtx, cancelFunc := context.WithCancel(context.Background())
-		api.scheduledTasks[task.ID] = cancelFunc
+// errDryRun is returned from inside a transaction to roll back the changes a dry run made.
+var errDryRun = errors.New("dry run; rolling back")
 
-		go func(ctx context.Context, scheduledTask storage.ScheduledTask) {
-			avail, err := avail.New(task.Expression)
-			if err != nil {
-				log.Error().Err(err).Msg("could not start monitoring for scheduled task")
-				return
-			}
+// errDuplicateCreateRequest is returned from inside a transaction when another create with the same request ID got
+// there first.
+var errDuplicateCreateRequest = errors.New("create request already handled")
 
-			for {
-				select {
-				case <-ctx.Done():
-					log.Debug().Str("id", scheduledTask.ID).Msg("scheduled task processing cancelled")
-					return
-				default:
-					if avail.Able(time.Now()) {
-						newTask := models.NewTask(scheduledTask.Title, scheduledTask.Description, scheduledTask.Parent)
+// errParentCycle is returned when a task would be moved beneath itself or one of its own children.
+var errParentCycle = errors.New("a task cannot be moved beneath itself or one of its own children")
+
+// batchTarget is a task chosen for a batch operation. Tasks given by an ID that couldn't be resolved carry the reason
+// so that it can be reported back in their result.
+type batchTarget struct {
+	id  string
+	err string
+}
+
+// batchTargets returns the tasks a batch operation should act on: either the tasks with the IDs given or the tasks
+// matching the filter. The error returned is a GRPC status suitable for returning to the client.
+func (api *API) batchTargets(ids []string, filter *proto.TaskFilter) ([]batchTarget, error) {
+	hasFilter := filter != nil && (filter.State != proto.Task_TASK_STATE_UNKNOWN || filter.Subtree != "" ||
+		filter.CreatedBefore != 0)
+
+	switch {
+	case len(ids) > 0 && filter != nil:
+		return nil, status.Error(codes.FailedPrecondition, "either ids or a filter can be given, not both")
+	case filter != nil && !hasFilter:
+		return nil, status.Error(codes.FailedPrecondition, "filter must set at least one criterion")
+	case len(ids) == 0 && !hasFilter:
+		return nil, status.Error(codes.FailedPrecondition, "ids or a filter required")
+	}
 
-						err := api.db.InsertTask(api.db, newTask.ToStorage())
-						if err != nil {
-							log.Error().Err(err).Msg("could not create task")
-						}
+	targets := []batchTarget{}
 
-						log.Debug().Str("id", newTask.ID).Str("title", scheduledTask.Title).Str("scheduled_task_id", scheduledTask.ID).Msg("scheduled a new task")
-					}
+	if !hasFilter {
+		seen := map[string]bool{}
 
-					time.Sleep(time.Minute * 1)
+		for _, prefix := range ids {
+			id, err := api.resolveTaskID(prefix)
+			if err != nil {
+				if status.Code(err) == codes.Internal {
+					return nil, err
 				}
+				targets = append(targets, batchTarget{id: prefix, err: status.Convert(err).Message()})
+				continue
+			}
+
+			if seen[id] {
+				continue
 			}
-		}(innerCtx, task)
+			seen[id] = true
+
+			targets = append(targets, batchTarget{id: id})
+		}
+
+		return targets, nil
 	}
 
-	return nil
+	storageFilter := storage.TaskFilter{CreatedBefore: filter.CreatedBefore}
+
+	if filter.State != proto.Task_TASK_STATE_UNKNOWN {
+		storageFilter.State = filter.State.String()
+	}
+
+	if filter.Subtree != "" {
+		subtree, err := api.resolveTaskID(filter.Subtree)
+		if err != nil {
+			return nil, err
+		}
+		storageFilter.Subtree = subtree
+	}
+
+	matches, err := api.db.FindTaskIDsMatching(api.db, storageFilter)
+	if err != nil {
+		log.Error().Err(err).Msg("could not find tasks matching filter")
+		return nil, status.Error(codes.Internal, "could not find tasks matching filter")
+	}
+
+	for _, id := range matches {
+		targets = append(targets, batchTarget{id: id})
+	}
+
+	return targets, nil
 }
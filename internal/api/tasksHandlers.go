@@ -15,23 +15,42 @@ import (
 )
 
 func (api *API) GetTask(ctx context.Context, request *proto.GetTaskRequest) (*proto.GetTaskResponse, error) {
-	if request.Id == "" {
-		return nil, status.Error(codes.FailedPrecondition, "id required")
+	id, err := api.resolveTaskID(request.Id)
+	if err != nil {
+		return nil, err
 	}
 
-	task, err := api.db.GetTask(api.db, request.Id)
+	task, err := api.db.GetTask(api.db, id)
 	if err != nil {
 		if errors.Is(err, storage.ErrEntityNotFound) {
 			return nil, status.Error(codes.FailedPrecondition, "task not found")
 		}
 		log.Error().Err(err).Msg("could not get task")
-		return nil, status.Errorf(codes.FailedPrecondition, "failed to retrieve task %s from database", request.Id)
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to retrieve task %s from database", id)
 	}
 
 	return &proto.GetTaskResponse{Task: task.ToProto()}, nil
 }
 
 func (api *API) ListTasks(ctx context.Context, request *proto.ListTasksRequest) (*proto.ListTasksResponse, error) {
+	if request.IncludeArchived {
+		tasks, err := api.db.ListTasksIncludingArchived(api.db, int(request.Offset), int(request.Limit),
+			request.ExcludeCompleted)
+		if err != nil {
+			log.Error().Err(err).Msg("could not get tasks")
+			return &proto.ListTasksResponse{}, status.Error(codes.Internal, "failed to retrieve tasks from database")
+		}
+
+		protoTasks := []*proto.Task{}
+		for _, task := range tasks {
+			protoTasks = append(protoTasks, task.ToProto())
+		}
+
+		return &proto.ListTasksResponse{
+			Tasks: protoTasks,
+		}, nil
+	}
+
 	tasks, err := api.db.ListTasks(api.db, int(request.Offset), int(request.Limit), request.ExcludeCompleted)
 	if err != nil {
 		log.Error().Err(err).Msg("could not get tasks")
@@ -53,64 +72,133 @@ func (api *API) CreateTask(ctx context.Context, request *proto.CreateTaskRequest
 		return nil, status.Error(codes.FailedPrecondition, "title required")
 	}
 
-	newTask := models.NewTask(request.Title, request.Description, request.Parent)
-
-	err := api.db.InsertTask(api.db, newTask.ToStorage())
+	parent, err := api.resolveParentID(request.Parent)
 	if err != nil {
-		if errors.Is(err, storage.ErrEntityExists) {
-			return &proto.CreateTaskResponse{}, status.Error(codes.AlreadyExists, "task already exists")
+		return nil, err
+	}
+
+	// A create that is sent again returns the task it created the first time round.
+	if request.RequestId != "" {
+		id, err := api.db.GetTaskCreateRequest(api.db, request.RequestId)
+		if err == nil {
+			return &proto.CreateTaskResponse{Id: id}, nil
+		}
+		if !errors.Is(err, storage.ErrEntityNotFound) {
+			log.Error().Err(err).Str("request_id", request.RequestId).Msg("could not look up create request")
+			return &proto.CreateTaskResponse{}, status.Error(codes.Internal, "could not insert task")
+		}
+	}
+
+	id, err := api.insertWithUniqueID(func(id string) error {
+		newTask := models.NewTask(id, request.Title, request.Description, parent)
+		if request.RequestId == "" {
+			return api.db.InsertTask(api.db, newTask.ToStorage())
 		}
 
+		return api.db.InsideTx(func(tx storage.Queryable) error {
+			err := api.db.InsertTask(tx, newTask.ToStorage())
+			if err != nil {
+				return err
+			}
+
+			err = api.db.InsertTaskCreateRequest(tx, request.RequestId, id, newTask.Created)
+			if errors.Is(err, storage.ErrEntityExists) {
+				return errDuplicateCreateRequest
+			}
+			return err
+		})
+	})
+	if errors.Is(err, errDuplicateCreateRequest) {
+		// The same create was sent twice at once and the other one won; both get its task.
+		id, err = api.db.GetTaskCreateRequest(api.db, request.RequestId)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("could not insert task")
 		return &proto.CreateTaskResponse{},
 			status.Error(codes.Internal, "could not insert task")
 	}
 
-	return &proto.CreateTaskResponse{Id: newTask.ID}, nil
+	return &proto.CreateTaskResponse{Id: id}, nil
 }
 
 func (api *API) UpdateTask(ctx context.Context, request *proto.UpdateTaskRequest) (*proto.UpdateTaskResponse, error) {
-	if request.Id == "" {
-		return &proto.UpdateTaskResponse{}, status.Error(codes.FailedPrecondition, "id required")
+	id, err := api.resolveTaskID(request.Id)
+	if err != nil {
+		return &proto.UpdateTaskResponse{}, err
+	}
+
+	if request.Title == nil && request.Description == nil && request.Parent == nil && request.State == nil &&
+		request.CompletionNote == nil {
+		return &proto.UpdateTaskResponse{}, status.Error(codes.FailedPrecondition, "no changes given")
 	}
 
-	err := api.db.UpdateTask(api.db, request.Id, storage.UpdatableTaskFields{
-		Title:       &request.Title,
-		Description: &request.Description,
+	if request.Title != nil && *request.Title == "" {
+		return &proto.UpdateTaskResponse{}, status.Error(codes.FailedPrecondition, "title cannot be empty")
+	}
+
+	fields := storage.UpdatableTaskFields{
+		Title:       request.Title,
+		Description: request.Description,
 		Modified:    ptr(time.Now().UnixMilli()),
-		Parent:      &request.Parent,
-		State:       ptr(request.State.String()),
-	})
+	}
+
+	if request.Parent != nil {
+		parent, err := api.resolveParentID(*request.Parent)
+		if err != nil {
+			return &proto.UpdateTaskResponse{}, err
+		}
+
+		if parent == id {
+			return &proto.UpdateTaskResponse{}, status.Error(codes.FailedPrecondition, "a task cannot be its own parent")
+		}
+
+		fields.Parent = &parent
+	}
+
+	if request.State != nil {
+		fields.State = ptr(request.State.String())
+	}
+
+	err = completionFields(&fields, request.State, request.CompletionNote)
+	if err != nil {
+		return &proto.UpdateTaskResponse{}, err
+	}
+
+	updatedTasks, err := api.UpdateTaskTree(id, request.ExpectedVersion, fields)
 	if err != nil {
 		if errors.Is(err, storage.ErrEntityNotFound) {
 			return &proto.UpdateTaskResponse{}, status.Error(codes.FailedPrecondition, "could not find task")
 		}
+		if errors.Is(err, errParentCycle) {
+			return &proto.UpdateTaskResponse{}, status.Error(codes.FailedPrecondition, errParentCycle.Error())
+		}
+		if errors.Is(err, storage.ErrConflict) {
+			return &proto.UpdateTaskResponse{}, status.Errorf(codes.Aborted,
+				"task %s has been changed since version %d; get it again and retry", id, *request.ExpectedVersion)
+		}
 		return &proto.UpdateTaskResponse{}, err
 	}
 
-	if request.State == proto.UpdateTaskRequest_COMPLETED {
-		// If we have completed a task we want to also complete all it's children.
-		completedTasks, err := api.CompleteTaskTree(request.Id)
-		if err != nil {
-			if errors.Is(err, storage.ErrEntityNotFound) {
-				return nil, status.Error(codes.FailedPrecondition, "could not find task")
-			}
-			return nil, err
-		}
+	log.Debug().Strs("ids", updatedTasks).Msg("updated task chain")
 
-		log.Debug().Strs("ids", completedTasks).Msg("completed task chain")
+	task, err := api.db.GetTask(api.db, id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("could not get updated task")
+		return &proto.UpdateTaskResponse{}, status.Error(codes.Internal, "task updated but could not be retrieved")
 	}
 
-	log.Info().Interface("task", request.Id).Msg("updated task")
-	return &proto.UpdateTaskResponse{}, nil
+	log.Info().Interface("task", id).Msg("updated task")
+	return &proto.UpdateTaskResponse{Task: task.ToProto()}, nil
 }
 
 func (api *API) DeleteTask(ctx context.Context, request *proto.DeleteTaskRequest) (*proto.DeleteTaskResponse, error) {
-	if request.Id == "" {
-		return nil, status.Error(codes.FailedPrecondition, "id required")
+	id, err := api.resolveTaskID(request.Id)
+	if err != nil {
+		return nil, err
 	}
 
 	// If you delete a parent task we also need to delete all the children tasks.
-	deletedTasks, err := api.DeleteTaskTree(request.Id)
+	deletedTasks, err := api.DeleteTaskTree(id)
 	if err != nil {
 		if errors.Is(err, storage.ErrEntityNotFound) {
 			return nil, status.Error(codes.FailedPrecondition, "could not find task")
@@ -122,3 +210,200 @@ func (api *API) DeleteTask(ctx context.Context, request *proto.DeleteTaskRequest
 		Ids: deletedTasks,
 	}, nil
 }
+
+func (api *API) BatchUpdateTasks(ctx context.Context, request *proto.BatchUpdateTasksRequest) (*proto.BatchUpdateTasksResponse, error) {
+	if request.Title == nil && request.Description == nil && request.Parent == nil && request.State == nil &&
+		request.CompletionNote == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no changes given")
+	}
+
+	if request.Title != nil && *request.Title == "" {
+		return nil, status.Error(codes.FailedPrecondition, "title cannot be empty")
+	}
+
+	targets, err := api.batchTargets(request.Ids, request.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := storage.UpdatableTaskFields{
+		Title:       request.Title,
+		Description: request.Description,
+		Modified:    ptr(time.Now().UnixMilli()),
+	}
+
+	if request.Parent != nil {
+		parent, err := api.resolveParentID(*request.Parent)
+		if err != nil {
+			return nil, err
+		}
+		fields.Parent = &parent
+	}
+
+	if request.State != nil {
+		fields.State = ptr(request.State.String())
+	}
+
+	err = completionFields(&fields, request.State, request.CompletionNote)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*proto.BatchTaskResult{}
+	var digests []string
+
+	completing := request.State != nil && *request.State == proto.UpdateTaskRequest_COMPLETED
+
+	note := ""
+	if request.CompletionNote != nil {
+		note = *request.CompletionNote
+	}
+
+	err = api.db.InsideTx(func(tx storage.Queryable) error {
+		results = results[:0]
+		journal := newJournal()
+		updated := 0
+
+		for _, target := range targets {
+			result := &proto.BatchTaskResult{Id: target.id, Error: target.err}
+			results = append(results, result)
+
+			if target.err != "" {
+				continue
+			}
+
+			err := api.updateBatchTarget(tx, target.id, fields, journal)
+			if err != nil {
+				result.Error = batchTargetError(target.id, err)
+				continue
+			}
+
+			updated++
+
+			if completing {
+				// If we have completed a task we want to also complete all it's children.
+				err := api.recursivelyCompleteTasks(tx, target.id, *fields.Modified, note, &result.AffectedIds,
+					journal)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			result.AffectedIds = []string{target.id}
+		}
+
+		if request.DryRun {
+			return errDryRun
+		}
+
+		kind, verb := models.OperationKindUpdate, "Updated"
+		if completing {
+			kind, verb = models.OperationKindComplete, "Completed"
+		}
+
+		digests, err = api.recordOperation(tx, kind, journal.describe(verb, updated), journal, *fields.Modified)
+		return err
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		log.Error().Err(err).Msg("could not update tasks")
+		return nil, status.Error(codes.Internal, "could not update tasks")
+	}
+
+	api.removeUnusedBlobs(digests)
+
+	log.Info().Int("tasks", len(results)).Bool("dry_run", request.DryRun).Msg("batch updated tasks")
+	return &proto.BatchUpdateTasksResponse{Results: results}, nil
+}
+
+// updateBatchTarget applies the fields to a single task chosen for a batch update. Moving a task is checked within the
+// same transaction so that no two tasks chosen for the batch can be moved beneath each other.
+func (api *API) updateBatchTarget(tx storage.Queryable, id string, fields storage.UpdatableTaskFields,
+	journal *journal,
+) error {
+	if fields.Parent != nil {
+		err := api.checkParent(tx, id, *fields.Parent)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := journal.capture(api.db, tx, id)
+	if err != nil {
+		return err
+	}
+
+	return api.db.UpdateTask(tx, id, fields)
+}
+
+// batchTargetError describes why a single task in a batch couldn't be changed, for its result. Tasks can be deleted
+// between being chosen for the batch and the batch reaching them, which is reported like any other missing task.
+func batchTargetError(id string, err error) string {
+	switch {
+	case errors.Is(err, errParentCycle):
+		return errParentCycle.Error()
+	case errors.Is(err, storage.ErrEntityNotFound):
+		return "could not find task"
+	default:
+		log.Error().Err(err).Str("id", id).Msg("could not change task in batch")
+		return "could not change task"
+	}
+}
+
+func (api *API) BatchDeleteTasks(ctx context.Context, request *proto.BatchDeleteTasksRequest) (*proto.BatchDeleteTasksResponse, error) {
+	targets, err := api.batchTargets(request.Ids, request.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*proto.BatchTaskResult{}
+	var digests []string
+
+	err = api.db.InsideTx(func(tx storage.Queryable) error {
+		results = results[:0]
+
+		journal := newJournal()
+		roots := 0
+
+		// Tasks can be deleted along with an earlier task's children before we reach them.
+		deleted := map[string]bool{}
+
+		for _, target := range targets {
+			result := &proto.BatchTaskResult{Id: target.id, Error: target.err}
+			results = append(results, result)
+
+			if target.err != "" || deleted[target.id] {
+				continue
+			}
+
+			// If you delete a parent task we also need to delete all the children tasks.
+			err := api.recursivelyDeleteTasks(tx, target.id, &result.AffectedIds, journal)
+			if err != nil {
+				return err
+			}
+
+			roots++
+			for _, id := range result.AffectedIds {
+				deleted[id] = true
+			}
+		}
+
+		if request.DryRun {
+			return errDryRun
+		}
+
+		var err error
+		digests, err = api.recordOperation(tx, models.OperationKindDelete, journal.describe("Deleted", roots), journal,
+			time.Now().UnixMilli())
+		return err
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		log.Error().Err(err).Msg("could not delete tasks")
+		return nil, status.Error(codes.Internal, "could not delete tasks")
+	}
+
+	api.removeUnusedBlobs(digests)
+
+	log.Info().Int("tasks", len(results)).Bool("dry_run", request.DryRun).Msg("batch deleted tasks")
+	return &proto.BatchDeleteTasksResponse{Results: results}, nil
+}
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/clintjedwards/todo/internal/config"
+	"github.com/clintjedwards/todo/internal/storage"
+	proto "github.com/clintjedwards/todo/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestAPI returns an API backed by a fresh sqlite database that is removed once the test finishes.
+func newTestAPI(t *testing.T) *API {
+	t.Helper()
+
+	db, err := storage.New(storage.DriverSQLite, filepath.Join(t.TempDir(), "todo.db"), 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	api, err := NewAPI(config.DefaultAPIConfig(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return api
+}
+
+// createTestTask creates a task beneath the parent given, which may be empty, and returns its ID.
+func createTestTask(t *testing.T, api *API, title, parent string) string {
+	t.Helper()
+
+	resp, err := api.CreateTask(context.Background(), &proto.CreateTaskRequest{Title: title, Parent: parent})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resp.Id
+}
+
+func TestUpdateTaskRejectsParentCycles(t *testing.T) {
+	api := newTestAPI(t)
+
+	// grandparent -> parent -> child
+	grandparent := createTestTask(t, api, "grandparent", "")
+	parent := createTestTask(t, api, "parent", grandparent)
+	child := createTestTask(t, api, "child", parent)
+	other := createTestTask(t, api, "other", "")
+
+	// Cases run in order; the ones that are accepted come last so that they don't rearrange the tree for the others.
+	tests := []struct {
+		name   string
+		id     string
+		parent string
+		code   codes.Code
+	}{
+		{name: "itself", id: parent, parent: parent, code: codes.FailedPrecondition},
+		{name: "its child", id: parent, parent: child, code: codes.FailedPrecondition},
+		{name: "its grandchild", id: grandparent, parent: child, code: codes.FailedPrecondition},
+		{name: "an unrelated task", id: grandparent, parent: other, code: codes.OK},
+		{name: "its grandparent", id: child, parent: grandparent, code: codes.OK},
+		{name: "no parent", id: child, parent: "", code: codes.OK},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := api.UpdateTask(context.Background(), &proto.UpdateTaskRequest{
+				Id:     tc.id,
+				Parent: &tc.parent,
+			})
+			if status.Code(err) != tc.code {
+				t.Fatalf("expected %v; got %v", tc.code, err)
+			}
+		})
+	}
+
+	// Completing the tree still has to finish, which it wouldn't if a cycle had slipped through.
+	_, err := api.UpdateTask(context.Background(), &proto.UpdateTaskRequest{
+		Id:    other,
+		State: proto.UpdateTaskRequest_COMPLETED.Enum(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBatchUpdateTasksRejectsParentCycles(t *testing.T) {
+	api := newTestAPI(t)
+
+	// x -> a -> y, with z on its own.
+	x := createTestTask(t, api, "x", "")
+	a := createTestTask(t, api, "a", x)
+	y := createTestTask(t, api, "y", a)
+	z := createTestTask(t, api, "z", "")
+
+	resp, err := api.BatchUpdateTasks(context.Background(), &proto.BatchUpdateTasksRequest{
+		Ids:    []string{x, a, y, z},
+		Parent: &y,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := map[string]string{}
+	for _, result := range resp.Results {
+		errs[result.Id] = result.Error
+	}
+
+	expected := map[string]string{
+		x: errParentCycle.Error(),
+		a: errParentCycle.Error(),
+		y: errParentCycle.Error(),
+		z: "",
+	}
+	if diff := cmp.Diff(expected, errs); diff != "" {
+		t.Errorf("unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestBatchTargetErrors(t *testing.T) {
+	api := newTestAPI(t)
+
+	id := createTestTask(t, api, "task", "")
+
+	// A task deleted after the batch chose it is reported in its result without spoiling the rest of the batch.
+	err := api.db.InsideTx(func(tx storage.Queryable) error {
+		err := api.updateBatchTarget(tx, "missing", storage.UpdatableTaskFields{Title: ptr("gone")}, newJournal())
+		if got := batchTargetError("missing", err); got != "could not find task" {
+			t.Errorf("expected a missing task to be reported as not found; got %q", got)
+		}
+
+		return api.updateBatchTarget(tx, id, storage.UpdatableTaskFields{Title: ptr("renamed")}, newJournal())
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	task, err := api.db.GetTask(api.db, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if task.Title != "renamed" {
+		t.Errorf("expected title %q; got %q", "renamed", task.Title)
+	}
+}
+
+func TestDeleteTasksJournalsComments(t *testing.T) {
+	tests := []struct {
+		name   string
+		delete func(api *API, id string) error
+	}{
+		{name: "delete", delete: func(api *API, id string) error {
+			_, err := api.DeleteTask(context.Background(), &proto.DeleteTaskRequest{Id: id})
+			return err
+		}},
+		{name: "batch delete", delete: func(api *API, id string) error {
+			_, err := api.BatchDeleteTasks(context.Background(), &proto.BatchDeleteTasksRequest{Ids: []string{id}})
+			return err
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			api := newTestAPI(t)
+
+			parent := createTestTask(t, api, "parent", "")
+			child := createTestTask(t, api, "child", parent)
+
+			for _, id := range []string{parent, child} {
+				_, err := api.AddTaskComment(context.Background(), &proto.AddTaskCommentRequest{TaskId: id, Text: "note"})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := tc.delete(api, parent)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, id := range []string{parent, child} {
+				comments, err := api.db.ListTaskComments(api.db, id, 0, 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(comments) != 0 {
+					t.Errorf("expected comments on deleted task %s to be removed; found %d", id, len(comments))
+				}
+			}
+
+			_, err = api.UndoOperation(context.Background(), &proto.UndoOperationRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, id := range []string{parent, child} {
+				comments, err := api.db.ListTaskComments(api.db, id, 0, 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(comments) != 1 || comments[0].Text != "note" {
+					t.Errorf("expected the comment on %s to be brought back by undo; got %+v", id, comments)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/clintjedwards/todo/internal/models"
+	"github.com/clintjedwards/todo/internal/storage"
+	"github.com/clintjedwards/todo/internal/tasktemplate"
+	proto "github.com/clintjedwards/todo/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// resolveTemplate returns the template that the given name, ID or ID prefix refers to. Names are checked first since
+// they're what users usually type. The error returned is a GRPC status suitable for returning to the client.
+func (api *API) resolveTemplate(nameOrID string) (storage.Template, error) {
+	template, err := api.db.GetTemplateByName(api.db, nameOrID)
+	if err == nil {
+		return template, nil
+	}
+	if !errors.Is(err, storage.ErrEntityNotFound) {
+		log.Error().Err(err).Str("name", nameOrID).Msg("could not look up template")
+		return storage.Template{}, status.Errorf(codes.Internal, "could not look up template %s", nameOrID)
+	}
+
+	id, err := resolveID("template", nameOrID, func(prefix string, limit int) ([]string, error) {
+		return api.db.FindTemplateIDs(api.db, prefix, limit)
+	})
+	if err != nil {
+		return storage.Template{}, err
+	}
+
+	template, err = api.db.GetTemplate(api.db, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrEntityNotFound) {
+			return storage.Template{}, status.Error(codes.FailedPrecondition, "template not found")
+		}
+		log.Error().Err(err).Str("id", id).Msg("could not get template")
+		return storage.Template{}, status.Errorf(codes.Internal, "could not get template %s", id)
+	}
+
+	return template, nil
+}
+
+// templateVariables returns the variables available when applying a template: the built in ones describing the time
+// given, overridden by any the user supplied.
+func templateVariables(builtin map[string]string, user map[string]string) map[string]string {
+	variables := maps.Clone(builtin)
+	maps.Copy(variables, user)
+	return variables
+}
+
+// CreateTemplateTree creates every task the template describes, with its variables filled in, inside a single
+// transaction so that a template is either applied completely or not at all. The tasks at the top of the template are
+// children of parent and are passed to link before being inserted so callers can attach details to them. It returns
+// the IDs of every task created along with the IDs of just the top level ones.
+func (api *API) CreateTemplateTree(template storage.Template, parent string, variables map[string]string,
+	link func(task *models.Task),
+) (ids []string, rootIDs []string, err error) {
+	tasks, err := tasktemplate.RenderTasks(template.Tasks.ToProto(), variables)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not fill in template %q: %w", template.Name, err)
+	}
+
+	err = api.db.InsideTx(func(tx storage.Queryable) error {
+		ids, rootIDs = []string{}, []string{}
+
+		for _, task := range tasks {
+			id, err := api.recursivelyCreateTemplateTasks(tx, task, parent, link, &ids)
+			if err != nil {
+				return err
+			}
+
+			rootIDs = append(rootIDs, id)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ids, rootIDs, nil
+}
+
+// Creates a template task and all it's children recursively, returning the ID of the task created.
+func (api *API) recursivelyCreateTemplateTasks(tx storage.Queryable, task *proto.TemplateTask, parent string,
+	link func(task *models.Task), createdTasks *[]string,
+) (string, error) {
+	id, err := api.insertWithUniqueID(func(id string) error {
+		// Check for a collision before inserting since a failed insert aborts the whole transaction on some drivers.
+		_, err := api.db.GetTask(tx, id)
+		if err == nil {
+			return storage.ErrEntityExists
+		}
+		if !errors.Is(err, storage.ErrEntityNotFound) {
+			return err
+		}
+
+		newTask := models.NewTask(id, task.Title, task.Description, parent)
+		if link != nil {
+			link(newTask)
+		}
+		return api.db.InsertTask(tx, newTask.ToStorage())
+	})
+	if err != nil {
+		return "", err
+	}
+
+	*createdTasks = append(*createdTasks, id)
+
+	for _, child := range task.Children {
+		_, err := api.recursivelyCreateTemplateTasks(tx, child, id, nil, createdTasks)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return id, nil
+}
+
+// scheduledTasksUsingTemplate returns the IDs of every scheduled task that creates the template with the ID given.
+func (api *API) scheduledTasksUsingTemplate(templateID string) ([]string, error) {
+	ids := []string{}
+	offset := 0
+
+	for {
+		scheduledTasks, err := api.db.ListScheduledTasks(api.db, offset, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, task := range scheduledTasks {
+			if task.TemplateID == templateID {
+				ids = append(ids, task.ID)
+			}
+		}
+
+		if len(scheduledTasks) < api.config.Server.StorageResultsLimit || len(scheduledTasks) == 0 {
+			return ids, nil
+		}
+
+		offset += len(scheduledTasks)
+	}
+}
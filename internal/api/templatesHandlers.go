@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/clintjedwards/todo/internal/models"
+	"github.com/clintjedwards/todo/internal/storage"
+	"github.com/clintjedwards/todo/internal/tasktemplate"
+	proto "github.com/clintjedwards/todo/proto"
+	"github.com/rs/zerolog/log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (api *API) ListTemplates(ctx context.Context, request *proto.ListTemplatesRequest) (*proto.ListTemplatesResponse, error) {
+	templates, err := api.db.ListTemplates(api.db, int(request.Offset), int(request.Limit))
+	if err != nil {
+		log.Error().Err(err).Msg("could not get templates")
+		return &proto.ListTemplatesResponse{}, status.Error(codes.Internal, "failed to retrieve templates from database")
+	}
+
+	protoTemplates := []*proto.Template{}
+	for _, template := range templates {
+		protoTemplates = append(protoTemplates, template.ToProto())
+	}
+
+	return &proto.ListTemplatesResponse{
+		Templates: protoTemplates,
+	}, nil
+}
+
+func (api *API) GetTemplate(ctx context.Context, request *proto.GetTemplateRequest) (*proto.GetTemplateResponse, error) {
+	template, err := api.resolveTemplate(request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.GetTemplateResponse{Template: template.ToProto()}, nil
+}
+
+func (api *API) CreateTemplate(ctx context.Context, request *proto.CreateTemplateRequest) (*proto.CreateTemplateResponse, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return nil, status.Error(codes.FailedPrecondition, "name required")
+	}
+
+	err := tasktemplate.Validate(request.Tasks)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "invalid template; %v", err)
+	}
+
+	// Names are checked up front since a duplicate name would otherwise look like an ID collision to
+	// insertWithUniqueID.
+	_, err = api.db.GetTemplateByName(api.db, name)
+	if err == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "template named %q already exists", name)
+	}
+	if !errors.Is(err, storage.ErrEntityNotFound) {
+		log.Error().Err(err).Str("name", name).Msg("could not look up template")
+		return nil, status.Error(codes.Internal, "could not insert template")
+	}
+
+	id, err := api.insertWithUniqueID(func(id string) error {
+		newTemplate := models.NewTemplate(id, name, request.Description, request.Tasks)
+		return api.db.InsertTemplate(api.db, newTemplate.ToStorage())
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("could not insert template")
+		return &proto.CreateTemplateResponse{},
+			status.Error(codes.Internal, "could not insert template")
+	}
+
+	log.Info().Str("id", id).Str("name", name).Msg("created template")
+	return &proto.CreateTemplateResponse{Id: id}, nil
+}
+
+func (api *API) DeleteTemplate(ctx context.Context, request *proto.DeleteTemplateRequest) (*proto.DeleteTemplateResponse, error) {
+	template, err := api.resolveTemplate(request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledTasks, err := api.scheduledTasksUsingTemplate(template.ID)
+	if err != nil {
+		log.Error().Err(err).Str("id", template.ID).Msg("could not look up scheduled tasks using template")
+		return nil, status.Error(codes.Internal, "could not delete template")
+	}
+
+	if len(scheduledTasks) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"template is used by scheduled tasks %s; delete or update them first", strings.Join(scheduledTasks, ", "))
+	}
+
+	err = api.db.DeleteTemplate(api.db, template.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not delete template; %v", err)
+	}
+
+	log.Info().Str("id", template.ID).Msg("deleted template")
+	return &proto.DeleteTemplateResponse{Id: template.ID}, nil
+}
+
+func (api *API) ApplyTemplate(ctx context.Context, request *proto.ApplyTemplateRequest) (*proto.ApplyTemplateResponse, error) {
+	template, err := api.resolveTemplate(request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err := api.resolveParentID(request.Parent)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := api.location("")
+	if err != nil {
+		log.Error().Err(err).Msg("could not load server time zone")
+		return nil, status.Error(codes.Internal, "could not load server time zone")
+	}
+
+	variables := templateVariables(tasktemplate.Variables(time.Now().In(location)), request.Variables)
+
+	// Render up front so that a missing variable is reported as the user's mistake rather than an internal error.
+	_, err = tasktemplate.RenderTasks(template.Tasks.ToProto(), variables)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "could not fill in template; %v", err)
+	}
+
+	ids, rootIDs, err := api.CreateTemplateTree(template, parent, variables, nil)
+	if err != nil {
+		log.Error().Err(err).Str("id", template.ID).Msg("could not apply template")
+		return nil, status.Error(codes.Internal, "could not apply template")
+	}
+
+	log.Info().Str("id", template.ID).Int("tasks", len(ids)).Msg("applied template")
+	return &proto.ApplyTemplateResponse{Ids: ids, RootIds: rootIDs}, nil
+}
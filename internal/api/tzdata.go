@@ -0,0 +1,5 @@
+package api
+
+// Scheduled tasks can be evaluated in any time zone, so we embed the time zone database rather than rely on the host
+// having one; minimal container images often don't.
+import _ "time/tzdata"
@@ -3,6 +3,8 @@
 package app
 
 import (
+	"fmt"
+
 	"github.com/clintjedwards/todo/internal/api"
 	"github.com/clintjedwards/todo/internal/config"
 	"github.com/clintjedwards/todo/internal/storage"
@@ -15,12 +17,12 @@ func StartServices(config *config.API) {
 		log.Warn().Msg("Using Localhost TLS certs due to config setting development.use_localhost_tls; Not for use in production.")
 	}
 
-	newStorage, err := initStorage(config.Server)
+	newStorage, err := InitStorage(config.Server)
 	if err != nil {
 		log.Fatal().Err(err).Msg("could not init storage")
 	}
 
-	log.Info().Str("path", config.Server.StoragePath).Int("max_results_limit", config.Server.StorageResultsLimit).
+	log.Info().Str("driver", config.Server.StorageDriver).Int("max_results_limit", config.Server.StorageResultsLimit).
 		Msg("storage initialized")
 
 	newAPI, err := api.NewAPI(config, newStorage)
@@ -31,7 +33,34 @@ func StartServices(config *config.API) {
 	newAPI.StartAPIService()
 }
 
-// initStorage creates a storage object with the appropriate engine
-func initStorage(config *config.Server) (storage.DB, error) {
-	return storage.New(config.StoragePath, config.StorageResultsLimit)
+// InitStorage creates a storage object with the appropriate engine and migrates it to the latest schema.
+func InitStorage(config *config.Server) (*storage.DB, error) {
+	driver, path, err := storageSettings(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.New(driver, path, config.StorageResultsLimit)
+}
+
+// OpenStorage creates a storage object with the appropriate engine without running any migrations.
+func OpenStorage(config *config.Server) (*storage.DB, error) {
+	driver, path, err := storageSettings(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.Open(driver, path, config.StorageResultsLimit)
+}
+
+func storageSettings(config *config.Server) (storage.Driver, string, error) {
+	switch storage.Driver(config.StorageDriver) {
+	case storage.DriverSQLite:
+		return storage.DriverSQLite, config.StoragePath, nil
+	case storage.DriverPostgres:
+		return storage.DriverPostgres, config.StorageURL, nil
+	default:
+		return "", "", fmt.Errorf("storage driver %q not recognized; must be one of %q or %q",
+			config.StorageDriver, storage.DriverSQLite, storage.DriverPostgres)
+	}
 }
@@ -3,13 +3,17 @@
 package cl
 
 import (
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/clintjedwards/polyfmt"
+	"github.com/clintjedwards/todo/internal/cli/format"
 	"github.com/clintjedwards/todo/internal/config"
 	"github.com/fatih/color"
 	"github.com/hashicorp/hcl/v2/gohcl"
@@ -19,11 +23,31 @@ import (
 	"google.golang.org/grpc/credentials"
 )
 
+const (
+	// ConfigAnnotation is the cobra annotation key used to mark which configuration a command consumes through
+	// the global --config flag.
+	ConfigAnnotation = "config"
+
+	// ConfigAnnotationAPI marks a command as operating on the server directly. Such commands read the
+	// service configuration instead of the CLI configuration.
+	ConfigAnnotationAPI = "api"
+
+	// ReadAnnotation marks a command as one that only reads data, which it can write for scripts through --format
+	// or --template instead of describing it. Set with AddOutputFlags.
+	ReadAnnotation = "read"
+)
+
 // Harness is a structure for values that all commands need access to.
 type Harness struct {
 	Fmt            polyfmt.Formatter
 	Config         *config.CLI
 	ConfigFilePath string
+
+	// Template is the Go template given to a read command through --template, if any.
+	Template string
+
+	// writesData is set when a read command is writing its data for scripts, so nothing else may go to stdout.
+	writesData bool
 }
 
 // State holds values that aid in the lifetime of a command.
@@ -54,6 +78,18 @@ func (s *Harness) Connect() (*grpc.ClientConn, error) {
 	return conn, nil
 }
 
+// CacheFile returns the path of a file the cli keeps about the server at host, such as its local copy of tasks. Each
+// server gets its own files so that switching --host never mixes up what is known about another one.
+func CacheFile(host, name string) string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+
+	sum := sha256.Sum256([]byte(host))
+	return filepath.Join(cacheDir, "todo", hex.EncodeToString(sum[:8]), name)
+}
+
 // Init harness for command line functions, used to provide different functionality during the life of a command line run.
 func InitState(cmd *cobra.Command) {
 	// Including these in the pre run hook instead of in the enclosing/parent command definition
@@ -68,19 +104,40 @@ func InitState(cmd *cobra.Command) {
 
 	// This is a hack. Because the start command needs to use the --config global variable for its own purposes
 	// we tell it to skip parsing the as if its a CLI config and supply it with some defaults.
-	if cmd.Name() == "start" && cmd.Parent().Name() == "service" {
+	// Other commands that operate on the server directly do the same, but still want normal output.
+	switch {
+	case cmd.Name() == "start" && cmd.Parent().Name() == "service":
 		State.Config = &config.CLI{
 			Format: "silent",
 		}
-	} else {
+	// Shells read completions and completion scripts straight from stdout, so nothing else can be written there.
+	case cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd ||
+		(cmd.HasParent() && cmd.Parent().Name() == "completion"):
+		State.Config = &config.CLI{
+			Format: "silent",
+		}
+	case cmd.Annotations[ConfigAnnotation] == ConfigAnnotationAPI:
+		State.Config = config.DefaultCLIConfig()
+	default:
 		config, _ := cmd.Flags().GetString("config")
 		State.NewConfig(config)
 	}
 
 	// Initiate the formatter(this controls the command line output)
-	format, _ := cmd.Flags().GetString("format")
-	if format != "" {
-		State.Config.Format = format
+	outputFormat, _ := cmd.Flags().GetString("format")
+	if outputFormat != "" {
+		State.Config.Format = outputFormat
+	}
+
+	if cmd.Annotations[ReadAnnotation] == "true" {
+		State.Template, _ = cmd.Flags().GetString("template")
+		State.writesData = State.Template != "" || format.IsStructured(State.Config.Format)
+
+		// Read commands that can download files write them to stdout when given "--output -".
+		output, _ := cmd.Flags().GetString("output")
+		if output == "-" {
+			State.writesData = true
+		}
 	}
 
 	State.NewFormatter()
@@ -112,7 +169,19 @@ func overlayGlobalFlags(cmd *cobra.Command) {
 }
 
 func (s *Harness) NewFormatter() {
-	clifmt, err := polyfmt.NewFormatter(polyfmt.Mode(s.Config.Format), false)
+	if s.writesData {
+		s.Fmt = errorFormatter{}
+		return
+	}
+
+	// Commands that don't read anything only have messages to show, which in any structured format are written as
+	// polyfmt's json.
+	mode := s.Config.Format
+	if format.IsStructured(mode) {
+		mode = format.JSON
+	}
+
+	clifmt, err := polyfmt.NewFormatter(polyfmt.Mode(mode), false)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,224 @@
+package cl
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/clintjedwards/todo/internal/config"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// completionCacheTTL is how long IDs fetched for shell completion are reused before asking the server again. It
+	// only needs to cover the handful of tab presses that go into typing one command.
+	completionCacheTTL = 30 * time.Second
+
+	// completionTimeout bounds how long completion waits on the server, so that a slow or missing server leaves the
+	// shell usable.
+	completionTimeout = 2 * time.Second
+)
+
+// completionCandidate is an ID offered for completion along with a description shown next to it by shells that
+// support them.
+type completionCandidate struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
+
+// CompleteTaskIDs completes task IDs for commands that take any number of them, offering open tasks with their titles
+// as descriptions.
+func CompleteTaskIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeIDs(cmd, "tasks", listOpenTasks, args, toComplete)
+}
+
+// CompleteTaskID completes the task ID for commands that take a single one as their first argument.
+func CompleteTaskID(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return CompleteTaskIDs(cmd, args, toComplete)
+}
+
+// CompleteTaskIDFlag completes a flag, such as --parent, that takes a task ID.
+func CompleteTaskIDFlag(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeIDs(cmd, "tasks", listOpenTasks, nil, toComplete)
+}
+
+// CompleteScheduledTaskID completes the scheduled task ID for commands that take a single one as their first
+// argument, offering scheduled tasks with their titles as descriptions.
+func CompleteScheduledTaskID(cmd *cobra.Command, args []string, toComplete string,
+) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return completeIDs(cmd, "scheduled", listScheduledTasks, args, toComplete)
+}
+
+// completeIDs offers the IDs starting with what has been typed so far, leaving out those already given as arguments.
+// Errors are never reported; completion simply offers nothing.
+func completeIDs(cmd *cobra.Command, kind string, list func(proto.TodoClient) ([]completionCandidate, error),
+	args []string, toComplete string,
+) ([]string, cobra.ShellCompDirective) {
+	candidates, err := cachedCandidates(cmd, kind, list)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	given := map[string]struct{}{}
+	for _, arg := range args {
+		given[arg] = struct{}{}
+	}
+
+	completions := []string{}
+	for _, candidate := range candidates {
+		if _, exists := given[candidate.ID]; exists {
+			continue
+		}
+
+		if !strings.HasPrefix(candidate.ID, toComplete) {
+			continue
+		}
+
+		// Shells show whatever follows the tab as the description, which has to stay on one line.
+		description := strings.Join(strings.Fields(candidate.Description), " ")
+		completions = append(completions, candidate.ID+"\t"+description)
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// cachedCandidates returns the candidates of the kind given, from the cache if it was written recently enough and from
+// the server otherwise. Completion doesn't go through InitState, so the config is loaded here.
+func cachedCandidates(cmd *cobra.Command, kind string, list func(proto.TodoClient) ([]completionCandidate, error),
+) ([]completionCandidate, error) {
+	configPath, _ := cmd.Flags().GetString("config")
+	cliConfig, err := config.InitCLIConfig(configPath, true)
+	if err != nil {
+		return nil, err
+	}
+
+	host, _ := cmd.Flags().GetString("host")
+	if host != "" {
+		cliConfig.Host = host
+	}
+
+	cachePath := CacheFile(cliConfig.Host, "completion-"+kind+".json")
+
+	candidates, err := readCompletionCache(cachePath)
+	if err == nil {
+		return candidates, nil
+	}
+
+	conn, err := (&Harness{Config: cliConfig}).Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	candidates, err = list(proto.NewTodoClient(conn))
+	if err != nil {
+		return nil, err
+	}
+
+	writeCompletionCache(cachePath, candidates)
+	return candidates, nil
+}
+
+func listOpenTasks(client proto.TodoClient) ([]completionCandidate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	tasks, err := ListAllTasks(ctx, client, &proto.ListTasksRequest{
+		ExcludeCompleted: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []completionCandidate{}
+	for _, task := range tasks {
+		candidates = append(candidates, completionCandidate{ID: task.Id, Description: task.Title})
+	}
+
+	return candidates, nil
+}
+
+func listScheduledTasks(client proto.TodoClient) ([]completionCandidate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	resp, err := client.ListScheduledTasks(ctx, &proto.ListScheduledTasksRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []completionCandidate{}
+	for _, task := range resp.ScheduledTasks {
+		candidates = append(candidates, completionCandidate{ID: task.Id, Description: task.Title})
+	}
+
+	return candidates, nil
+}
+
+func readCompletionCache(path string) ([]completionCandidate, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Since(info.ModTime()) > completionCacheTTL {
+		return nil, os.ErrNotExist
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []completionCandidate{}
+	err = json.Unmarshal(contents, &candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// writeCompletionCache saves candidates for the next completion. Failing to is harmless, the server is just asked
+// again, so errors are ignored.
+func writeCompletionCache(path string, candidates []completionCandidate) {
+	contents, err := json.Marshal(candidates)
+	if err != nil {
+		return
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return
+	}
+
+	// Written to a temporary file first so that a completion running at the same time never reads half of it.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return
+	}
+
+	_, err = tmp.Write(contents)
+	tmp.Close()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
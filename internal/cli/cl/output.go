@@ -0,0 +1,45 @@
+package cl
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/clintjedwards/polyfmt"
+	"github.com/clintjedwards/todo/internal/cli/format"
+	"github.com/spf13/cobra"
+)
+
+// AddOutputFlags marks a command as a read command, giving it the --template flag and letting it write its data in
+// any of the structured formats chosen with --format.
+func AddOutputFlags(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[ReadAnnotation] = "true"
+
+	cmd.Flags().String("template", "", format.TemplateFlagHelp)
+}
+
+// WritesData returns true if the command should write its data for scripts rather than describing it for people.
+// Only commands marked with AddOutputFlags ever do.
+func (s *Harness) WritesData() bool {
+	return s.writesData
+}
+
+// WriteData writes data, a single item or a slice of them, to stdout in the format the user asked for.
+func (s *Harness) WriteData(data any) error {
+	return format.Write(os.Stdout, s.Config.Format, s.Template, data)
+}
+
+// errorFormatter is used while a command writes data for scripts. It keeps stdout for that data alone, dropping
+// progress messages and writing errors to stderr.
+type errorFormatter struct{}
+
+func (errorFormatter) Print(_ interface{}, _ ...polyfmt.Mode)        {}
+func (errorFormatter) PrintSuccess(_ interface{}, _ ...polyfmt.Mode) {}
+func (errorFormatter) Println(_ interface{}, _ ...polyfmt.Mode)      {}
+func (errorFormatter) Finish()                                       {}
+
+func (errorFormatter) PrintErr(msg interface{}, _ ...polyfmt.Mode) {
+	fmt.Fprintf(os.Stderr, "x %v\n", msg)
+}
@@ -0,0 +1,39 @@
+package cl
+
+import (
+	"context"
+
+	"github.com/clintjedwards/todo/proto"
+)
+
+// ListAllTasks pages through every task matching the request given, starting from its offset. The server caps how
+// many tasks it returns at once at a limit the cli doesn't know, so the first page is taken to be that limit and
+// paging stops at a shorter or empty page.
+func ListAllTasks(ctx context.Context, client proto.TodoClient, request *proto.ListTasksRequest,
+) ([]*proto.Task, error) {
+	tasks := []*proto.Task{}
+	pageSize := 0
+	for {
+		page := &proto.ListTasksRequest{
+			Offset:           request.Offset + int64(len(tasks)),
+			Limit:            request.Limit,
+			ExcludeCompleted: request.ExcludeCompleted,
+			IncludeArchived:  request.IncludeArchived,
+		}
+
+		resp, err := client.ListTasks(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, resp.Tasks...)
+
+		if len(resp.Tasks) == 0 || len(resp.Tasks) < pageSize {
+			return tasks, nil
+		}
+
+		if pageSize == 0 {
+			pageSize = len(resp.Tasks)
+		}
+	}
+}
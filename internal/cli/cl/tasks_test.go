@@ -0,0 +1,63 @@
+package cl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/clintjedwards/todo/proto"
+	"google.golang.org/grpc"
+)
+
+// pagedClient returns tasks a page at a time, the way the server caps its results.
+type pagedClient struct {
+	proto.TodoClient
+	tasks    int
+	pageSize int
+	calls    int
+}
+
+func (c *pagedClient) ListTasks(_ context.Context, in *proto.ListTasksRequest, _ ...grpc.CallOption,
+) (*proto.ListTasksResponse, error) {
+	c.calls++
+
+	tasks := []*proto.Task{}
+	for i := int(in.Offset); i < c.tasks && len(tasks) < c.pageSize; i++ {
+		tasks = append(tasks, &proto.Task{Id: fmt.Sprintf("task%d", i)})
+	}
+
+	return &proto.ListTasksResponse{Tasks: tasks}, nil
+}
+
+func TestListAllTasks(t *testing.T) {
+	tests := []struct {
+		name  string
+		tasks int
+		calls int
+	}{
+		{name: "no tasks", tasks: 0, calls: 1},
+		{name: "less than a page", tasks: 3, calls: 2},
+		{name: "exactly one page", tasks: 5, calls: 2},
+		{name: "several pages", tasks: 12, calls: 3},
+		{name: "several full pages", tasks: 15, calls: 4},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &pagedClient{tasks: tc.tasks, pageSize: 5}
+
+			tasks, err := ListAllTasks(context.Background(), client, &proto.ListTasksRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(tasks) != tc.tasks {
+				t.Errorf("expected every one of the %d tasks; got %d", tc.tasks, len(tasks))
+			}
+
+			if client.calls != tc.calls {
+				t.Errorf("expected %d calls to the server; got %d", tc.calls, client.calls)
+			}
+		})
+	}
+}
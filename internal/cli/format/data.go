@@ -0,0 +1,330 @@
+package format
+
+import (
+	"strings"
+	"time"
+
+	"github.com/clintjedwards/todo/proto"
+)
+
+// The types below are how read commands describe their data in structured output. They're kept separate from the
+// protobuf messages so that the field names scripts rely on stay the same as the API changes underneath them. Times
+// are RFC 3339 in UTC and are left out when not set; enums are lower case.
+
+type Task struct {
+	ID              string `json:"id" yaml:"id"`
+	Title           string `json:"title" yaml:"title"`
+	Description     string `json:"description" yaml:"description"`
+	State           string `json:"state" yaml:"state"`
+	Parent          string `json:"parent" yaml:"parent"`
+	Created         string `json:"created,omitempty" yaml:"created,omitempty"`
+	Modified        string `json:"modified,omitempty" yaml:"modified,omitempty"`
+	Version         int64  `json:"version" yaml:"version"`
+	ScheduledTaskID string `json:"scheduled_task_id,omitempty" yaml:"scheduled_task_id,omitempty"`
+	ScheduledFor    string `json:"scheduled_for,omitempty" yaml:"scheduled_for,omitempty"`
+	CompletedAt     string `json:"completed_at,omitempty" yaml:"completed_at,omitempty"`
+	CompletionNote  string `json:"completion_note,omitempty" yaml:"completion_note,omitempty"`
+	Archived        string `json:"archived,omitempty" yaml:"archived,omitempty"`
+	// Only filled in when describing a single task.
+	Comments []TaskComment `json:"comments,omitempty" yaml:"comments,omitempty"`
+}
+
+func NewTask(task *proto.Task) Task {
+	return Task{
+		ID:              task.Id,
+		Title:           task.Title,
+		Description:     task.Description,
+		State:           enum(task.State.String()),
+		Parent:          task.Parent,
+		Created:         timestamp(task.Created),
+		Modified:        timestamp(task.Modified),
+		Version:         task.Version,
+		ScheduledTaskID: task.ScheduledTaskId,
+		ScheduledFor:    timestamp(task.ScheduledFor),
+		CompletedAt:     timestamp(task.CompletedAt),
+		CompletionNote:  task.CompletionNote,
+		Archived:        timestamp(task.Archived),
+	}
+}
+
+func NewTasks(tasks []*proto.Task) []Task {
+	converted := []Task{}
+	for _, task := range tasks {
+		converted = append(converted, NewTask(task))
+	}
+	return converted
+}
+
+type TaskComment struct {
+	ID      string `json:"id" yaml:"id"`
+	Author  string `json:"author" yaml:"author"`
+	Text    string `json:"text" yaml:"text"`
+	Created string `json:"created,omitempty" yaml:"created,omitempty"`
+}
+
+func NewTaskComments(comments []*proto.TaskComment) []TaskComment {
+	converted := []TaskComment{}
+	for _, comment := range comments {
+		converted = append(converted, TaskComment{
+			ID:      comment.Id,
+			Author:  comment.Author,
+			Text:    comment.Text,
+			Created: timestamp(comment.Created),
+		})
+	}
+	return converted
+}
+
+type Attachment struct {
+	ID      string `json:"id" yaml:"id"`
+	TaskID  string `json:"task_id" yaml:"task_id"`
+	Name    string `json:"name" yaml:"name"`
+	Size    int64  `json:"size" yaml:"size"`
+	Digest  string `json:"digest" yaml:"digest"`
+	Created string `json:"created,omitempty" yaml:"created,omitempty"`
+}
+
+func NewAttachments(attachments []*proto.Attachment) []Attachment {
+	converted := []Attachment{}
+	for _, attachment := range attachments {
+		converted = append(converted, Attachment{
+			ID:      attachment.Id,
+			TaskID:  attachment.TaskId,
+			Name:    attachment.Name,
+			Size:    attachment.Size,
+			Digest:  attachment.Digest,
+			Created: timestamp(attachment.Created),
+		})
+	}
+	return converted
+}
+
+type ScheduledTask struct {
+	ID             string   `json:"id" yaml:"id"`
+	Title          string   `json:"title" yaml:"title"`
+	Description    string   `json:"description" yaml:"description"`
+	Parent         string   `json:"parent" yaml:"parent"`
+	Expression     string   `json:"expression" yaml:"expression"`
+	ExpressionType string   `json:"expression_type" yaml:"expression_type"`
+	Recurrence     string   `json:"recurrence" yaml:"recurrence"`
+	Timezone       string   `json:"timezone" yaml:"timezone"`
+	TemplateID     string   `json:"template_id" yaml:"template_id"`
+	Paused         bool     `json:"paused" yaml:"paused"`
+	SkipIfOpen     bool     `json:"skip_if_open" yaml:"skip_if_open"`
+	Created        string   `json:"created,omitempty" yaml:"created,omitempty"`
+	LastFired      string   `json:"last_fired,omitempty" yaml:"last_fired,omitempty"`
+	NextFires      []string `json:"next_fires" yaml:"next_fires"`
+}
+
+// NewScheduledTask converts a scheduled task along with the fire times returned for it, if any.
+func NewScheduledTask(task *proto.ScheduledTask, fireTimes *proto.FireTimes) ScheduledTask {
+	return ScheduledTask{
+		ID:             task.Id,
+		Title:          task.Title,
+		Description:    task.Description,
+		Parent:         task.Parent,
+		Expression:     task.Expression,
+		ExpressionType: enum(task.ExpressionType.String()),
+		Recurrence:     enum(task.Recurrence.String()),
+		Timezone:       task.Timezone,
+		TemplateID:     task.TemplateId,
+		Paused:         task.Paused,
+		SkipIfOpen:     task.SkipIfOpen,
+		Created:        timestamp(task.Created),
+		LastFired:      timestamp(fireTimes.GetLast()),
+		NextFires:      timestamps(fireTimes.GetNext()),
+	}
+}
+
+type ScheduledTaskHistory struct {
+	ScheduledTask  ScheduledTask `json:"scheduled_task" yaml:"scheduled_task"`
+	Total          int64         `json:"total" yaml:"total"`
+	Completed      int64         `json:"completed" yaml:"completed"`
+	CompletionRate float64       `json:"completion_rate" yaml:"completion_rate"`
+	CurrentStreak  int64         `json:"current_streak" yaml:"current_streak"`
+	LongestStreak  int64         `json:"longest_streak" yaml:"longest_streak"`
+	Tasks          []Task        `json:"tasks" yaml:"tasks"`
+}
+
+func NewScheduledTaskHistory(history *proto.GetScheduledTaskHistoryResponse) ScheduledTaskHistory {
+	return ScheduledTaskHistory{
+		ScheduledTask:  NewScheduledTask(history.ScheduledTask, nil),
+		Total:          history.Summary.GetTotal(),
+		Completed:      history.Summary.GetCompleted(),
+		CompletionRate: history.Summary.GetCompletionRate(),
+		CurrentStreak:  history.Summary.GetCurrentStreak(),
+		LongestStreak:  history.Summary.GetLongestStreak(),
+		Tasks:          NewTasks(history.Tasks),
+	}
+}
+
+type SchedulePreview struct {
+	Expression     string   `json:"expression" yaml:"expression"`
+	ExpressionType string   `json:"expression_type" yaml:"expression_type"`
+	Recurrence     string   `json:"recurrence" yaml:"recurrence"`
+	Timezone       string   `json:"timezone" yaml:"timezone"`
+	Delay          string   `json:"delay,omitempty" yaml:"delay,omitempty"`
+	NextFires      []string `json:"next_fires" yaml:"next_fires"`
+}
+
+func NewSchedulePreview(expression string, preview *proto.PreviewScheduleResponse) SchedulePreview {
+	return SchedulePreview{
+		Expression:     expression,
+		ExpressionType: enum(preview.ExpressionType.String()),
+		Recurrence:     enum(preview.Recurrence.String()),
+		Timezone:       preview.Timezone,
+		Delay:          preview.Delay,
+		NextFires:      timestamps(preview.FireTimes.GetNext()),
+	}
+}
+
+type Template struct {
+	ID          string         `json:"id" yaml:"id"`
+	Name        string         `json:"name" yaml:"name"`
+	Description string         `json:"description" yaml:"description"`
+	Created     string         `json:"created,omitempty" yaml:"created,omitempty"`
+	Tasks       []TemplateTask `json:"tasks" yaml:"tasks"`
+}
+
+type TemplateTask struct {
+	Title       string         `json:"title" yaml:"title"`
+	Description string         `json:"description" yaml:"description"`
+	Children    []TemplateTask `json:"children" yaml:"children"`
+}
+
+func NewTemplate(template *proto.Template) Template {
+	return Template{
+		ID:          template.Id,
+		Name:        template.Name,
+		Description: template.Description,
+		Created:     timestamp(template.Created),
+		Tasks:       newTemplateTasks(template.Tasks),
+	}
+}
+
+func newTemplateTasks(tasks []*proto.TemplateTask) []TemplateTask {
+	converted := []TemplateTask{}
+	for _, task := range tasks {
+		converted = append(converted, TemplateTask{
+			Title:       task.Title,
+			Description: task.Description,
+			Children:    newTemplateTasks(task.Children),
+		})
+	}
+	return converted
+}
+
+type Operation struct {
+	ID          string   `json:"id" yaml:"id"`
+	Kind        string   `json:"kind" yaml:"kind"`
+	Description string   `json:"description" yaml:"description"`
+	TaskIDs     []string `json:"task_ids" yaml:"task_ids"`
+	Created     string   `json:"created,omitempty" yaml:"created,omitempty"`
+	Undone      bool     `json:"undone" yaml:"undone"`
+}
+
+func NewOperation(operation *proto.Operation) Operation {
+	taskIDs := operation.TaskIds
+	if taskIDs == nil {
+		taskIDs = []string{}
+	}
+
+	return Operation{
+		ID:          operation.Id,
+		Kind:        enum(operation.Kind.String()),
+		Description: operation.Description,
+		TaskIDs:     taskIDs,
+		Created:     timestamp(operation.Created),
+		Undone:      operation.Undone,
+	}
+}
+
+type TaskStats struct {
+	Open                  int64          `json:"open" yaml:"open"`
+	Completed             int64          `json:"completed" yaml:"completed"`
+	AverageTimeToComplete string         `json:"average_time_to_complete" yaml:"average_time_to_complete"`
+	Timezone              string         `json:"timezone" yaml:"timezone"`
+	CompletedPerDay       []PeriodCount  `json:"completed_per_day" yaml:"completed_per_day"`
+	CompletedPerWeek      []PeriodCount  `json:"completed_per_week" yaml:"completed_per_week"`
+	OpenByAge             []AgeBucket    `json:"open_by_age" yaml:"open_by_age"`
+	Progress              []TaskProgress `json:"progress" yaml:"progress"`
+}
+
+type PeriodCount struct {
+	Start string `json:"start" yaml:"start"`
+	Count int64  `json:"count" yaml:"count"`
+}
+
+type AgeBucket struct {
+	Label string `json:"label" yaml:"label"`
+	Count int64  `json:"count" yaml:"count"`
+}
+
+type TaskProgress struct {
+	ID        string `json:"id" yaml:"id"`
+	Title     string `json:"title" yaml:"title"`
+	Completed int64  `json:"completed" yaml:"completed"`
+	Total     int64  `json:"total" yaml:"total"`
+}
+
+// NewTaskStats converts task stats. Durations are given as Go duration strings, ex: "50h3m0s".
+func NewTaskStats(stats *proto.GetTaskStatsResponse) TaskStats {
+	converted := TaskStats{
+		Open:                  stats.Open,
+		Completed:             stats.Completed,
+		AverageTimeToComplete: (time.Duration(stats.AverageTimeToComplete) * time.Millisecond).String(),
+		Timezone:              stats.Timezone,
+		CompletedPerDay:       newPeriodCounts(stats.CompletedPerDay),
+		CompletedPerWeek:      newPeriodCounts(stats.CompletedPerWeek),
+		OpenByAge:             []AgeBucket{},
+		Progress:              []TaskProgress{},
+	}
+
+	for _, bucket := range stats.OpenByAge {
+		converted.OpenByAge = append(converted.OpenByAge, AgeBucket{Label: bucket.Label, Count: bucket.Count})
+	}
+
+	for _, progress := range stats.Progress {
+		converted.Progress = append(converted.Progress, TaskProgress{
+			ID:        progress.Id,
+			Title:     progress.Title,
+			Completed: progress.Completed,
+			Total:     progress.Total,
+		})
+	}
+
+	return converted
+}
+
+func newPeriodCounts(periods []*proto.PeriodCount) []PeriodCount {
+	converted := []PeriodCount{}
+	for _, period := range periods {
+		converted = append(converted, PeriodCount{Start: timestamp(period.Start), Count: period.Count})
+	}
+	return converted
+}
+
+func timestamp(unixMilli int64) string {
+	if unixMilli == 0 {
+		return ""
+	}
+
+	return time.UnixMilli(unixMilli).UTC().Format(time.RFC3339)
+}
+
+func timestamps(unixMillis []int64) []string {
+	converted := []string{}
+	for _, unixMilli := range unixMillis {
+		converted = append(converted, timestamp(unixMilli))
+	}
+	return converted
+}
+
+// enum lower cases the name of an enum value, leaving unset values empty rather than calling them unknown.
+func enum(value string) string {
+	if strings.Contains(value, "UNKNOWN") {
+		return ""
+	}
+
+	return strings.ToLower(value)
+}
@@ -5,9 +5,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
 	"github.com/clintjedwards/todo/proto"
 	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -15,6 +18,11 @@ import (
 // UnixMilli returns a humanized version of time given in unix millisecond. The zeroMsg is the string returned when
 // the time is 0 and assumed to be not set.
 func UnixMilli(unix int64, zeroMsg string, detail bool) string {
+	return UnixMilliIn(unix, zeroMsg, detail, time.Local)
+}
+
+// UnixMilliIn is UnixMilli with detailed times shown in the time zone given rather than the local one.
+func UnixMilliIn(unix int64, zeroMsg string, detail bool, location *time.Location) string {
 	if unix == 0 {
 		return zeroMsg
 	}
@@ -24,7 +32,7 @@ func UnixMilli(unix int64, zeroMsg string, detail bool) string {
 	}
 
 	relativeTime := humanize.Time(time.UnixMilli(unix))
-	realTime := time.UnixMilli(unix).Format(time.RFC850)
+	realTime := time.UnixMilli(unix).In(location).Format(time.RFC850)
 
 	return fmt.Sprintf("%s (%s)", realTime, relativeTime)
 }
@@ -53,3 +61,67 @@ func ColorizeTaskState(state string) string {
 		return state
 	}
 }
+
+// Table renders rows of data as a borderless table. When colored is set the headers are blue and the first column,
+// usually an ID, is yellow.
+func Table(headers []string, data [][]string, colored bool) string {
+	tableString := &strings.Builder{}
+	table := tablewriter.NewWriter(tableString)
+
+	table.SetHeader(headers)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeaderLine(true)
+	table.SetBorder(false)
+	table.SetAutoFormatHeaders(false)
+	table.SetRowSeparator("―")
+	table.SetRowLine(false)
+	table.SetColumnSeparator("")
+	table.SetCenterSeparator("")
+
+	if colored {
+		headerColors := []tablewriter.Colors{}
+		columnColors := []tablewriter.Colors{tablewriter.Color(tablewriter.FgYellowColor)}
+		for i := range headers {
+			headerColors = append(headerColors, tablewriter.Color(tablewriter.FgBlueColor))
+			if i > 0 {
+				columnColors = append(columnColors, tablewriter.Color(0))
+			}
+		}
+
+		table.SetHeaderColor(headerColors...)
+		table.SetColumnColor(columnColors...)
+	}
+
+	table.AppendBulk(data)
+
+	table.Render()
+	return tableString.String()
+}
+
+// Markdown renders text written in Markdown for the terminal, indented to sit under a heading. Without color it still
+// lays the text out but leaves out the styling. Text that can't be rendered is returned as it was.
+func Markdown(text string, color bool) string {
+	style := glamour.WithStandardStyle(styles.NoTTYStyle)
+	if color {
+		style = glamour.WithAutoStyle()
+	}
+
+	renderer, err := glamour.NewTermRenderer(style)
+	if err != nil {
+		return text
+	}
+
+	rendered, err := renderer.Render(text)
+	if err != nil {
+		return text
+	}
+
+	// Lines come back padded out to the wrap width, which only gets in the way when the terminal is narrower.
+	lines := strings.Split(strings.Trim(rendered, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
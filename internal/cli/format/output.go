@@ -0,0 +1,114 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats that write a command's data for scripts rather than describing it for people. These are chosen
+// with --format alongside polyfmt's own pretty, json and silent modes.
+const (
+	JSON  = "json"
+	JSONL = "jsonl"
+	YAML  = "yaml"
+)
+
+// TemplateFlagHelp is the help text of the --template flag read commands accept.
+const TemplateFlagHelp = "Go template used to print each item, ex: '{{.ID}} {{.Title}}'; " +
+	"fields are those of the json output, named in Go style"
+
+// IsStructured returns true if the output format given writes data for scripts.
+func IsStructured(format string) bool {
+	switch format {
+	case JSON, JSONL, YAML:
+		return true
+	default:
+		return false
+	}
+}
+
+// Write writes data, which is either a single item or a slice of them, to w. If tmpl is given it's executed against
+// each item in turn with each result on its own line. Otherwise data is written in the output format given: JSON,
+// JSON lines with one item per line, or YAML.
+func Write(w io.Writer, format, tmpl string, data any) error {
+	if tmpl != "" {
+		return writeTemplate(w, tmpl, items(data))
+	}
+
+	switch format {
+	case JSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	case JSONL:
+		encoder := json.NewEncoder(w)
+		for _, item := range items(data) {
+			err := encoder.Encode(item)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case YAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		err := encoder.Encode(data)
+		if err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		return fmt.Errorf("format %q does not write data; should be one of %s, %s or %s", format, JSON, JSONL, YAML)
+	}
+}
+
+func writeTemplate(w io.Writer, tmpl string, items []any) error {
+	t, err := template.New("output").Funcs(template.FuncMap{
+		"join":  strings.Join,
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+		"json": func(v any) (string, error) {
+			raw, err := json.Marshal(v)
+			return string(raw), err
+		},
+	}).Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("could not parse template: %w", err)
+	}
+
+	for _, item := range items {
+		var b strings.Builder
+		err := t.Execute(&b, item)
+		if err != nil {
+			return fmt.Errorf("could not execute template: %w", err)
+		}
+
+		_, err = fmt.Fprintln(w, strings.TrimSuffix(b.String(), "\n"))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// items returns the elements of data if it is a slice, otherwise data on its own.
+func items(data any) []any {
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Slice {
+		return []any{data}
+	}
+
+	items := make([]any, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		items = append(items, value.Index(i).Interface())
+	}
+
+	return items
+}
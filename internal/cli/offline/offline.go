@@ -0,0 +1,510 @@
+// Package offline keeps the cli's local copy of a server's tasks so that it can still be used while the server can't
+// be reached. Reads are served from the copy and changes are queued in it, to be sent once the server is back.
+package offline
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/clintjedwards/todo/proto"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3" // Provides sqlite3 lib
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	// ErrNotFound is returned when a task isn't in the local copy.
+	ErrNotFound = errors.New("task not found in the local copy of tasks")
+
+	// ErrAmbiguous is returned when an ID prefix matches more than one task in the local copy.
+	ErrAmbiguous = errors.New("id matches more than one task in the local copy of tasks")
+
+	// ErrParentCycle is returned when a task would be moved beneath itself or one of its own children.
+	ErrParentCycle = errors.New("a task cannot be moved beneath itself or one of its own children")
+)
+
+// LocalIDPrefix starts the IDs given to tasks created while offline. They are only known to the local copy and are
+// replaced by the server's own IDs once the tasks are synced.
+const LocalIDPrefix = "local-"
+
+const (
+	changeCreate = "create"
+	changeUpdate = "update"
+)
+
+// Tasks are stored as they are sent by the server so that the local copy never needs to change along with them.
+const schema = `
+CREATE TABLE IF NOT EXISTS tasks (
+	id   TEXT NOT NULL PRIMARY KEY,
+	task TEXT NOT NULL
+) STRICT;
+CREATE TABLE IF NOT EXISTS queue (
+	id       INTEGER PRIMARY KEY AUTOINCREMENT,
+	kind     TEXT    NOT NULL,
+	local_id TEXT    NOT NULL DEFAULT '',
+	request  TEXT    NOT NULL,
+	queued   INTEGER NOT NULL
+) STRICT;
+CREATE TABLE IF NOT EXISTS local_ids (
+	local_id TEXT NOT NULL PRIMARY KEY,
+	id       TEXT NOT NULL
+) STRICT;
+CREATE TABLE IF NOT EXISTS meta (
+	key   TEXT    NOT NULL PRIMARY KEY,
+	value INTEGER NOT NULL
+) STRICT;
+`
+
+// Replica is the local copy of a server's tasks along with the changes queued for it.
+type Replica struct {
+	db *sqlx.DB
+}
+
+// Open opens the local copy kept at path, creating it if it doesn't exist yet.
+func Open(path string) (*Replica, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sqlx.Connect("sqlite3", fmt.Sprintf("%s?_journal=wal&_timeout=5000", path))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = db.Exec(schema)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Replica{db: db}, nil
+}
+
+func (r *Replica) Close() error {
+	return r.db.Close()
+}
+
+// Unreachable returns true if err means the server couldn't be reached at all, as opposed to it turning the request
+// down.
+func Unreachable(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
+
+// Synced returns when the local copy was last refreshed from the server; the zero time if it never has been.
+func (r *Replica) Synced() (time.Time, error) {
+	var synced int64
+	err := r.db.Get(&synced, `SELECT value FROM meta WHERE key = 'synced'`)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+
+	return time.UnixMilli(synced), nil
+}
+
+// Refresh replaces the local copy with the tasks given, as fetched from the server at the time given. The tasks must
+// be every task on the server, not a single page of them, or the rest are dropped from the copy. It does nothing while
+// changes are queued, since the tasks they create or change would be lost until they're synced.
+func (r *Replica) Refresh(tasks []*proto.Task, synced time.Time) error {
+	pending, err := r.Pending()
+	if err != nil {
+		return err
+	}
+
+	if pending > 0 {
+		return nil
+	}
+
+	return r.insideTx(func(tx *sqlx.Tx) error {
+		_, err := tx.Exec(`DELETE FROM tasks`)
+		if err != nil {
+			return err
+		}
+
+		for _, task := range tasks {
+			err = putTask(tx, task)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Exec(`INSERT INTO meta (key, value) VALUES ('synced', ?)
+			ON CONFLICT (key) DO UPDATE SET value = excluded.value`, synced.UnixMilli())
+		return err
+	})
+}
+
+// ListTasks returns every task in the local copy, including changes that are queued.
+func (r *Replica) ListTasks() ([]*proto.Task, error) {
+	encoded := []string{}
+	err := r.db.Select(&encoded, `SELECT task FROM tasks ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []*proto.Task{}
+	for _, e := range encoded {
+		task := &proto.Task{}
+		err = protojson.Unmarshal([]byte(e), task)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// GetTask returns the task in the local copy with the ID, or the unique ID prefix, given.
+func (r *Replica) GetTask(prefix string) (*proto.Task, error) {
+	return getTask(r.db, prefix)
+}
+
+// Pending returns how many changes are queued.
+func (r *Replica) Pending() (int, error) {
+	var pending int
+	err := r.db.Get(&pending, `SELECT COUNT(*) FROM queue`)
+	if err != nil {
+		return 0, err
+	}
+
+	return pending, nil
+}
+
+// QueueCreate queues a task to be created and adds it to the local copy under a local ID, which is returned.
+func (r *Replica) QueueCreate(request *proto.CreateTaskRequest) (string, error) {
+	localID := ""
+
+	err := r.insideTx(func(tx *sqlx.Tx) error {
+		if request.Parent != "" {
+			parent, err := getTask(tx, request.Parent)
+			if err != nil {
+				return fmt.Errorf("parent task %q: %w", request.Parent, err)
+			}
+			request.Parent = parent.Id
+		}
+
+		// The request ID lets the server recognize the create if it has to be sent again, ex. after the cli exits
+		// between the server creating the task and the change being taken off the queue.
+		if request.RequestId == "" {
+			requestID, err := newRequestID()
+			if err != nil {
+				return err
+			}
+			request.RequestId = requestID
+		}
+
+		now := time.Now().UnixMilli()
+
+		queued, err := queue(tx, changeCreate, request, now)
+		if err != nil {
+			return err
+		}
+
+		localID = fmt.Sprintf("%s%d", LocalIDPrefix, queued)
+		_, err = tx.Exec(`UPDATE queue SET local_id = ? WHERE id = ?`, localID, queued)
+		if err != nil {
+			return err
+		}
+
+		return putTask(tx, &proto.Task{
+			Id:          localID,
+			Title:       request.Title,
+			Description: request.Description,
+			State:       proto.Task_UNRESOLVED,
+			Created:     now,
+			Modified:    now,
+			Parent:      request.Parent,
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return localID, nil
+}
+
+// QueueUpdate queues an update to a task and applies it to the local copy, returning the task as it is now.
+func (r *Replica) QueueUpdate(request *proto.UpdateTaskRequest) (*proto.Task, error) {
+	var updated *proto.Task
+
+	err := r.insideTx(func(tx *sqlx.Tx) error {
+		task, err := getTask(tx, request.Id)
+		if err != nil {
+			return err
+		}
+		request.Id = task.Id
+
+		if request.Parent != nil && *request.Parent != "" {
+			parent, err := getTask(tx, *request.Parent)
+			if err != nil {
+				return fmt.Errorf("parent task %q: %w", *request.Parent, err)
+			}
+			request.Parent = &parent.Id
+
+			err = checkParent(tx, task.Id, parent)
+			if err != nil {
+				return err
+			}
+		}
+
+		// The change is made against the version of the task in the local copy and is dropped when it is synced if the
+		// server's version has moved on by then. Tasks created offline have no version on the server until they're
+		// synced, so anything they get then is what they're expected to be at.
+		if request.ExpectedVersion == nil && !isLocalID(task.Id) {
+			request.ExpectedVersion = ptr(task.Version)
+		}
+
+		now := time.Now().UnixMilli()
+
+		_, err = queue(tx, changeUpdate, request, now)
+		if err != nil {
+			return err
+		}
+
+		if request.Title != nil {
+			task.Title = *request.Title
+		}
+		if request.Description != nil {
+			task.Description = *request.Description
+		}
+		if request.Parent != nil {
+			task.Parent = *request.Parent
+		}
+		if request.State == nil && request.CompletionNote != nil && task.State == proto.Task_COMPLETED {
+			task.CompletionNote = *request.CompletionNote
+		}
+		task.Modified = now
+
+		// Versions are bumped just as the server bumps them when the change is synced, so that a later change to the
+		// same task expects the version this one leaves it at.
+		task.Version++
+
+		err = putTask(tx, task)
+		if err != nil {
+			return err
+		}
+
+		if request.State != nil {
+			// Like the server, completing a task completes all of its children along with it.
+			err = setState(tx, task.Id, *request.State, request.CompletionNote, now, map[string]bool{})
+			if err != nil {
+				return err
+			}
+		}
+
+		updated, err = getTask(tx, task.Id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// checkParent returns ErrParentCycle if making parent the parent of the task with the ID given would put the task
+// beneath itself.
+func checkParent(tx *sqlx.Tx, id string, parent *proto.Task) error {
+	seen := map[string]bool{}
+
+	for {
+		if parent.Id == id {
+			return ErrParentCycle
+		}
+
+		if parent.Parent == "" || seen[parent.Id] {
+			return nil
+		}
+		seen[parent.Id] = true
+
+		var err error
+		parent, err = getTask(tx, parent.Parent)
+		if err != nil {
+			// Parents missing from the local copy can't lead back to a task that is in it.
+			if errors.Is(err, ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// setState sets the state of a task and, when completing it, all of the tasks beneath it. Seen holds the tasks
+// already set so that tasks that are each other's parents can't loop forever.
+func setState(tx *sqlx.Tx, id string, state proto.UpdateTaskRequest_TaskState, note *string, now int64,
+	seen map[string]bool,
+) error {
+	if seen[id] {
+		return nil
+	}
+	seen[id] = true
+
+	task, err := getTask(tx, id)
+	if err != nil {
+		return err
+	}
+
+	switch state {
+	case proto.UpdateTaskRequest_COMPLETED:
+		if task.State != proto.Task_COMPLETED {
+			task.CompletedAt = now
+		}
+		// The server updates every task it completes, the one completed directly included, once more.
+		task.Version++
+		task.State = proto.Task_COMPLETED
+		if note != nil {
+			task.CompletionNote = *note
+		}
+	case proto.UpdateTaskRequest_UNRESOLVED:
+		task.State = proto.Task_UNRESOLVED
+		task.CompletedAt = 0
+		task.CompletionNote = ""
+	}
+	task.Modified = now
+
+	err = putTask(tx, task)
+	if err != nil {
+		return err
+	}
+
+	if state != proto.UpdateTaskRequest_COMPLETED {
+		return nil
+	}
+
+	tasks := []string{}
+	err = tx.Select(&tasks, `SELECT task FROM tasks`)
+	if err != nil {
+		return err
+	}
+
+	for _, encoded := range tasks {
+		child := &proto.Task{}
+		err = protojson.Unmarshal([]byte(encoded), child)
+		if err != nil {
+			return err
+		}
+
+		if child.Parent != id {
+			continue
+		}
+
+		err = setState(tx, child.Id, state, note, now, seen)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// queue adds a change to the end of the queue, returning its position in it.
+func queue(tx *sqlx.Tx, kind string, request protoreflect.ProtoMessage, queued int64) (int64, error) {
+	encoded, err := protojson.Marshal(request)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := tx.Exec(`INSERT INTO queue (kind, request, queued) VALUES (?, ?, ?)`, kind, string(encoded), queued)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+func getTask(conn sqlx.Queryer, prefix string) (*proto.Task, error) {
+	if prefix == "" {
+		return nil, ErrNotFound
+	}
+
+	ids := []string{}
+	err := sqlx.Select(conn, &ids, `SELECT id FROM tasks WHERE substr(id, 1, ?) = ? ORDER BY id != ? LIMIT 2`,
+		len(prefix), prefix, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(ids) == 0:
+		return nil, ErrNotFound
+	case len(ids) > 1 && ids[0] != prefix:
+		return nil, ErrAmbiguous
+	}
+
+	var encoded string
+	err = sqlx.Get(conn, &encoded, `SELECT task FROM tasks WHERE id = ?`, ids[0])
+	if err != nil {
+		return nil, err
+	}
+
+	task := &proto.Task{}
+	err = protojson.Unmarshal([]byte(encoded), task)
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func putTask(tx *sqlx.Tx, task *proto.Task) error {
+	encoded, err := protojson.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`INSERT INTO tasks (id, task) VALUES (?, ?) ON CONFLICT (id) DO UPDATE SET task = excluded.task`,
+		task.Id, string(encoded))
+	return err
+}
+
+// insideTx runs fn inside a transaction, committing it if fn succeeds and rolling it back otherwise.
+func (r *Replica) insideTx(fn func(tx *sqlx.Tx) error) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	err = fn(tx)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: rolling back transaction: %v", err, rerr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// newRequestID returns a random ID for a create request.
+func newRequestID() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// isLocalID returns true for the IDs given to tasks created while offline.
+func isLocalID(id string) bool {
+	return strings.HasPrefix(id, LocalIDPrefix)
+}
@@ -0,0 +1,350 @@
+package offline
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/clintjedwards/todo/internal/api"
+	"github.com/clintjedwards/todo/internal/config"
+	"github.com/clintjedwards/todo/internal/storage"
+	"github.com/clintjedwards/todo/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testClient sends the calls the replica makes straight to an API backed by a fresh database.
+type testClient struct {
+	proto.TodoClient
+	api *api.API
+
+	// loseCreates makes creates succeed on the server but return as if it couldn't be reached, like a connection
+	// dropping before the response arrives.
+	loseCreates bool
+}
+
+func (c *testClient) CreateTask(ctx context.Context, in *proto.CreateTaskRequest, _ ...grpc.CallOption,
+) (*proto.CreateTaskResponse, error) {
+	resp, err := c.api.CreateTask(ctx, in)
+	if err == nil && c.loseCreates {
+		return nil, status.Error(codes.Unavailable, "connection lost")
+	}
+	return resp, err
+}
+
+func (c *testClient) GetTask(ctx context.Context, in *proto.GetTaskRequest, _ ...grpc.CallOption,
+) (*proto.GetTaskResponse, error) {
+	return c.api.GetTask(ctx, in)
+}
+
+func (c *testClient) UpdateTask(ctx context.Context, in *proto.UpdateTaskRequest, _ ...grpc.CallOption,
+) (*proto.UpdateTaskResponse, error) {
+	return c.api.UpdateTask(ctx, in)
+}
+
+func (c *testClient) ListTasks(ctx context.Context, in *proto.ListTasksRequest, _ ...grpc.CallOption,
+) (*proto.ListTasksResponse, error) {
+	return c.api.ListTasks(ctx, in)
+}
+
+func newTestClient(t *testing.T) *testClient {
+	t.Helper()
+
+	db, err := storage.New(storage.DriverSQLite, filepath.Join(t.TempDir(), "todo.db"), 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	todo, err := api.NewAPI(config.DefaultAPIConfig(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testClient{api: todo}
+}
+
+func newTestReplica(t *testing.T) *Replica {
+	t.Helper()
+
+	replica, err := Open(filepath.Join(t.TempDir(), "offline.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { replica.Close() })
+
+	return replica
+}
+
+// createServerTask creates a task on the server, returning its ID.
+func createServerTask(t *testing.T, client *testClient, title, parent string) string {
+	t.Helper()
+
+	resp, err := client.CreateTask(context.Background(), &proto.CreateTaskRequest{Title: title, Parent: parent})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resp.Id
+}
+
+// refresh replaces the local copy with the server's tasks.
+func refresh(t *testing.T, client *testClient, replica *Replica) {
+	t.Helper()
+
+	resp, err := client.ListTasks(context.Background(), &proto.ListTasksRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = replica.Refresh(resp.Tasks, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func getServerTask(t *testing.T, client *testClient, id string) *proto.Task {
+	t.Helper()
+
+	resp, err := client.GetTask(context.Background(), &proto.GetTaskRequest{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resp.Task
+}
+
+func push(t *testing.T, client *testClient, replica *Replica) []Result {
+	t.Helper()
+
+	results, err := replica.Push(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return results
+}
+
+func TestRefreshSkippedWhilePending(t *testing.T) {
+	client := newTestClient(t)
+	replica := newTestReplica(t)
+
+	id := createServerTask(t, client, "before", "")
+	refresh(t, client, replica)
+
+	title := "after"
+	_, err := replica.QueueUpdate(&proto.UpdateTaskRequest{Id: id, Title: &title})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Refreshing now would throw away the queued change in the local copy.
+	refresh(t, client, replica)
+
+	task, err := replica.GetTask(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Title != "after" {
+		t.Errorf("expected queued title %q to survive refresh; got %q", "after", task.Title)
+	}
+
+	push(t, client, replica)
+	refresh(t, client, replica)
+
+	synced, err := replica.GetTask(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if synced.Version != getServerTask(t, client, id).Version {
+		t.Errorf("expected refresh to take the server's version %d once nothing is queued; got %d",
+			getServerTask(t, client, id).Version, synced.Version)
+	}
+}
+
+func TestPushRemapsLocalIDs(t *testing.T) {
+	client := newTestClient(t)
+	replica := newTestReplica(t)
+
+	parent, err := replica.QueueCreate(&proto.CreateTaskRequest{Title: "parent"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	child, err := replica.QueueCreate(&proto.CreateTaskRequest{Title: "child", Parent: parent})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	title := "renamed child"
+	_, err = replica.QueueUpdate(&proto.UpdateTaskRequest{Id: child, Title: &title})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, result := range push(t, client, replica) {
+		if result.Err != nil {
+			t.Fatalf("could not sync %s: %v", result.Change, result.Err)
+		}
+	}
+
+	parentID, childID := replica.ServerID(parent), replica.ServerID(child)
+	if parentID == parent || childID == child {
+		t.Fatalf("expected local ids to map to server ids; got %s and %s", parentID, childID)
+	}
+
+	task := getServerTask(t, client, childID)
+	if task.Parent != parentID {
+		t.Errorf("expected child's parent to be %s; got %s", parentID, task.Parent)
+	}
+	if task.Title != title {
+		t.Errorf("expected child's title to be %q; got %q", title, task.Title)
+	}
+}
+
+func TestPushDoesNotCreateTwice(t *testing.T) {
+	client := newTestClient(t)
+	replica := newTestReplica(t)
+
+	_, err := replica.QueueCreate(&proto.CreateTaskRequest{Title: "once"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The server creates the task but the cli never hears back, so the create stays queued.
+	client.loseCreates = true
+	_, err = replica.Push(context.Background(), client)
+	if !Unreachable(err) {
+		t.Fatalf("expected push to stop as unreachable; got %v", err)
+	}
+
+	client.loseCreates = false
+	push(t, client, replica)
+
+	resp, err := client.ListTasks(context.Background(), &proto.ListTasksRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Tasks) != 1 {
+		t.Errorf("expected the task to be created once; found %d tasks", len(resp.Tasks))
+	}
+}
+
+func TestQueueUpdateCompletesChildren(t *testing.T) {
+	client := newTestClient(t)
+	replica := newTestReplica(t)
+
+	parent := createServerTask(t, client, "parent", "")
+	child := createServerTask(t, client, "child", parent)
+	grandchild := createServerTask(t, client, "grandchild", child)
+	other := createServerTask(t, client, "other", "")
+	refresh(t, client, replica)
+
+	_, err := replica.QueueUpdate(&proto.UpdateTaskRequest{
+		Id:    parent,
+		State: proto.UpdateTaskRequest_COMPLETED.Enum(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for id, want := range map[string]proto.Task_TaskState{
+		parent:     proto.Task_COMPLETED,
+		child:      proto.Task_COMPLETED,
+		grandchild: proto.Task_COMPLETED,
+		other:      proto.Task_UNRESOLVED,
+	} {
+		task, err := replica.GetTask(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if task.State != want {
+			t.Errorf("expected %s to be %v in the local copy; got %v", task.Title, want, task.State)
+		}
+	}
+
+	// A change to a child after completing it is made on top of the completion and has to sync along with it.
+	title := "renamed grandchild"
+	_, err = replica.QueueUpdate(&proto.UpdateTaskRequest{Id: grandchild, Title: &title})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, result := range push(t, client, replica) {
+		if result.Err != nil {
+			t.Fatalf("could not sync %s: %v", result.Change, result.Err)
+		}
+	}
+
+	task := getServerTask(t, client, grandchild)
+	if task.State != proto.Task_COMPLETED || task.Title != title {
+		t.Errorf("expected grandchild to be completed and renamed on the server; got %v %q", task.State, task.Title)
+	}
+}
+
+func TestPushConflicts(t *testing.T) {
+	tests := []struct {
+		name string
+		// changedOnServer is changed on the server after the local copy was refreshed.
+		changedOnServer bool
+		// edits is how many changes are queued for the task.
+		edits    int
+		conflict bool
+	}{
+		{name: "unchanged on server", edits: 1},
+		{name: "several edits", edits: 3},
+		{name: "changed on server", changedOnServer: true, edits: 1, conflict: true},
+		{name: "several edits changed on server", changedOnServer: true, edits: 2, conflict: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newTestClient(t)
+			replica := newTestReplica(t)
+
+			id := createServerTask(t, client, "original", "")
+			refresh(t, client, replica)
+
+			if tc.changedOnServer {
+				title := "changed on server"
+				_, err := client.UpdateTask(context.Background(), &proto.UpdateTaskRequest{Id: id, Title: &title})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			titles := []string{}
+			for i := 0; i < tc.edits; i++ {
+				title := "edit " + string(rune('a'+i))
+				titles = append(titles, title)
+
+				_, err := replica.QueueUpdate(&proto.UpdateTaskRequest{Id: id, Title: &title})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for _, result := range push(t, client, replica) {
+				if result.Conflict != tc.conflict {
+					t.Errorf("expected conflict to be %v for %s; got %v (%v)", tc.conflict, result.Change,
+						result.Conflict, result.Err)
+				}
+				if tc.conflict && !errors.Is(result.Err, errConflict) {
+					t.Errorf("expected conflict error for %s; got %v", result.Change, result.Err)
+				}
+			}
+
+			want := titles[len(titles)-1]
+			if tc.conflict {
+				want = "changed on server"
+			}
+
+			if got := getServerTask(t, client, id).Title; got != want {
+				t.Errorf("expected server's title to be %q; got %q", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package offline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/clintjedwards/todo/proto"
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Result is what became of a queued change once it was sent to the server.
+type Result struct {
+	// Change describes the change, ex. `create "Buy milk"`.
+	Change string
+	// ID is the server's ID for the task changed, or for created tasks the ID the server gave them.
+	ID string
+	// Err is set if the change couldn't be applied. Either way the change is taken off the queue.
+	Err error
+	// Conflict is set if the change was dropped because the task was changed on the server after it was made.
+	Conflict bool
+}
+
+// errConflict is the error given for changes dropped because the task was changed on the server after they were made.
+var errConflict = errors.New("the task was changed on the server after this change was made; kept the server's version")
+
+// change is a row of the queue.
+type change struct {
+	ID      int64  `db:"id"`
+	Kind    string `db:"kind"`
+	LocalID string `db:"local_id"`
+	Request string `db:"request"`
+	Queued  int64  `db:"queued"`
+}
+
+// Push sends the queued changes to the server in the order they were made, taking each off the queue as it goes. If
+// the server stops being reachable part way through it returns what was sent so far along with the error, leaving
+// the rest queued.
+//
+// Conflicts are settled by version: a change to a task whose version on the server has moved on from the one the
+// change was made against is dropped, keeping the server's version, along with any later changes to the same task.
+// Creates are sent with a request ID so that sending one again after the server created the task returns that task
+// rather than creating another.
+func (r *Replica) Push(ctx context.Context, client proto.TodoClient) ([]Result, error) {
+	changes := []change{}
+	err := r.db.Select(&changes, `SELECT id, kind, local_id, request, queued FROM queue ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tasks whose changes conflicted; later changes to them were made on top of the dropped ones.
+	conflicted := map[string]bool{}
+
+	results := []Result{}
+	for _, c := range changes {
+		var result Result
+
+		switch c.Kind {
+		case changeCreate:
+			result, err = r.pushCreate(ctx, client, c)
+		case changeUpdate:
+			result, err = r.pushUpdate(ctx, client, c, conflicted)
+		default:
+			result = Result{Change: c.Kind, Err: fmt.Errorf("unknown change %q", c.Kind)}
+		}
+		if err != nil {
+			return results, err
+		}
+
+		if result.Conflict {
+			conflicted[result.ID] = true
+		}
+
+		err = r.insideTx(func(tx *sqlx.Tx) error {
+			if c.LocalID != "" && result.Err == nil {
+				_, err := tx.Exec(`INSERT INTO local_ids (local_id, id) VALUES (?, ?)`, c.LocalID, result.ID)
+				if err != nil {
+					return err
+				}
+			}
+
+			_, err := tx.Exec(`DELETE FROM queue WHERE id = ?`, c.ID)
+			return err
+		})
+		if err != nil {
+			return results, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// pushCreate sends a queued task creation. It only returns an error if the change should stay queued.
+func (r *Replica) pushCreate(ctx context.Context, client proto.TodoClient, c change) (Result, error) {
+	request := &proto.CreateTaskRequest{}
+	err := protojson.Unmarshal([]byte(c.Request), request)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{Change: fmt.Sprintf("create %q", request.Title)}
+
+	request.Parent, err = r.serverID(request.Parent)
+	if err != nil {
+		result.Err = err
+		return result, nil
+	}
+
+	resp, err := client.CreateTask(ctx, request)
+	if err != nil {
+		if Unreachable(err) {
+			return Result{}, err
+		}
+		result.Err = err
+		return result, nil
+	}
+
+	result.ID = resp.Id
+	return result, nil
+}
+
+// pushUpdate sends a queued task update unless the task has been changed on the server since. It only returns an
+// error if the change should stay queued.
+func (r *Replica) pushUpdate(ctx context.Context, client proto.TodoClient, c change, conflicted map[string]bool,
+) (Result, error) {
+	request := &proto.UpdateTaskRequest{}
+	err := protojson.Unmarshal([]byte(c.Request), request)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{Change: fmt.Sprintf("update %s", request.Id)}
+	if request.State != nil && *request.State == proto.UpdateTaskRequest_COMPLETED {
+		result.Change = fmt.Sprintf("complete %s", request.Id)
+	}
+
+	request.Id, err = r.serverID(request.Id)
+	if err != nil {
+		result.Err = err
+		return result, nil
+	}
+	result.ID = request.Id
+
+	if conflicted[request.Id] {
+		result.Conflict = true
+		result.Err = errConflict
+		return result, nil
+	}
+
+	if request.Parent != nil {
+		parent, err := r.serverID(*request.Parent)
+		if err != nil {
+			result.Err = err
+			return result, nil
+		}
+		request.Parent = &parent
+	}
+
+	// Changes to tasks created offline aren't made against a version the server knows of, so they're made against
+	// whatever version the task is at now. The version still guards against it changing in the meantime.
+	if request.ExpectedVersion == nil {
+		current, err := client.GetTask(ctx, &proto.GetTaskRequest{Id: request.Id})
+		if err != nil {
+			if Unreachable(err) {
+				return Result{}, err
+			}
+			result.Err = err
+			return result, nil
+		}
+		request.ExpectedVersion = &current.Task.Version
+	}
+
+	_, err = client.UpdateTask(ctx, request)
+	if err != nil {
+		if Unreachable(err) {
+			return Result{}, err
+		}
+		result.Err = err
+		if status.Code(err) == codes.Aborted {
+			result.Conflict = true
+			result.Err = errConflict
+		}
+		return result, nil
+	}
+
+	return result, nil
+}
+
+// ServerID returns the ID the server gave a task created while offline, once it has been synced. Any other ID is
+// returned as it is.
+func (r *Replica) ServerID(id string) string {
+	serverID, err := r.serverID(id)
+	if err != nil {
+		return id
+	}
+
+	return serverID
+}
+
+// serverID returns the server's ID for a task created while offline, or the ID given if it isn't a local one.
+func (r *Replica) serverID(id string) (string, error) {
+	if !isLocalID(id) {
+		return id, nil
+	}
+
+	var serverID string
+	err := r.db.Get(&serverID, `SELECT id FROM local_ids WHERE local_id = ?`, id)
+	if err != nil {
+		return "", fmt.Errorf("task %s was never created on the server", id)
+	}
+
+	return serverID, nil
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/clintjedwards/todo/internal/cli/cl"
 	"github.com/clintjedwards/todo/internal/cli/service"
 	"github.com/clintjedwards/todo/internal/cli/task"
+	"github.com/clintjedwards/todo/internal/cli/task/archive"
 	"github.com/clintjedwards/todo/internal/cli/task/scheduled"
+	"github.com/clintjedwards/todo/internal/cli/template"
 	"github.com/clintjedwards/todo/internal/config"
 	"github.com/spf13/cobra"
 )
@@ -20,6 +22,9 @@ var RootCmd = &cobra.Command{
 	Short: "Yet another simple todo app",
 	Long: `Yet another simple todo app
 
+Commands that take an ID also accept any prefix of it that is unique, ex: 'todo get 62a'. With shell completion set up
+through 'todo completion <shell>', pressing tab offers the IDs of open tasks along with their titles.
+
 ### Environment Variables supported:
 
 	` + strings.Join(config.GetCLIEnvVars(), "\n"),
@@ -38,12 +43,23 @@ func init() {
 	RootCmd.AddCommand(task.CmdTaskList)
 	RootCmd.AddCommand(task.CmdTaskComplete)
 	RootCmd.AddCommand(task.CmdTaskUpdate)
+	RootCmd.AddCommand(task.CmdTaskEdit)
 	RootCmd.AddCommand(task.CmdTaskSchedule)
+	RootCmd.AddCommand(task.CmdTaskUndo)
+	RootCmd.AddCommand(task.CmdTaskTUI)
+	RootCmd.AddCommand(task.CmdTaskStats)
+	RootCmd.AddCommand(task.CmdTaskComment)
+	RootCmd.AddCommand(task.CmdTaskAttach)
+	RootCmd.AddCommand(task.CmdTaskAttachments)
+	RootCmd.AddCommand(task.CmdTaskSync)
 	RootCmd.AddCommand(scheduled.CmdScheduled)
+	RootCmd.AddCommand(archive.CmdArchive)
+	RootCmd.AddCommand(template.CmdTemplate)
 
 	RootCmd.PersistentFlags().String("config", "", "configuration file path")
 	RootCmd.PersistentFlags().Bool("no-color", false, "disable color output")
 	RootCmd.PersistentFlags().String("host", "", "specify the URL of the server to communicate to")
+	RootCmd.PersistentFlags().String("format", "", "output format: pretty, json, jsonl, yaml or silent")
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var cmdServiceInfo = &cobra.Command{
+	Use:     "info",
+	Short:   "Describe the running Todo service.",
+	Example: `$ todo service info`,
+	RunE:    serviceInfo,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	CmdService.AddCommand(cmdServiceInfo)
+}
+
+func serviceInfo(_ *cobra.Command, _ []string) error {
+	cl.State.Fmt.Print("Getting Service Details")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.GetSystemInfo(context.Background(), &proto.GetSystemInfoRequest{})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get service info: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	cl.State.Fmt.Println(formatServiceInfo(resp))
+	cl.State.Fmt.Finish()
+	return nil
+}
+
+type serviceInfoData struct {
+	Semver        string
+	Commit        string
+	DevMode       bool
+	Uptime        string
+	SchemaVersion string
+	Driver        string
+	Path          string
+	Size          string
+	Unresolved    int64
+	Completed     int64
+	Scheduled     int64
+	Scheduler     string
+}
+
+func formatServiceInfo(info *proto.GetSystemInfoResponse) string {
+	scheduler := color.YellowString("restoring")
+	if info.Scheduler.GetRestored() {
+		scheduler = color.GreenString("running %d", info.Scheduler.GetRunning())
+	}
+	if info.Scheduler.GetFailed() > 0 {
+		scheduler += color.RedString(" (%d failed to start)", info.Scheduler.GetFailed())
+	}
+
+	data := serviceInfoData{
+		Semver:        color.BlueString(info.Semver),
+		Commit:        info.Commit,
+		DevMode:       info.DevModeEnabled,
+		Uptime:        (time.Duration(info.UptimeSeconds) * time.Second).String(),
+		SchemaVersion: info.SchemaVersion,
+		Driver:        info.StorageDriver,
+		Path:          info.StoragePath,
+		Size:          humanize.IBytes(uint64(info.StorageSizeBytes)),
+		Unresolved:    info.UnresolvedTasks,
+		Completed:     info.CompletedTasks,
+		Scheduled:     info.ScheduledTasks,
+		Scheduler:     scheduler,
+	}
+
+	const formatTmpl = `Todo {{.Semver}} ({{.Commit}}){{if .DevMode}} :: dev mode{{end}}
+
+  Uptime:     {{.Uptime}}
+  Storage:    {{.Driver}} :: {{.Path}} :: {{.Size}} :: schema version {{.SchemaVersion}}
+  Tasks:      {{.Unresolved}} unresolved :: {{.Completed}} completed
+  Scheduled:  {{.Scheduled}} :: {{.Scheduler}}`
+
+	var tpl bytes.Buffer
+	t := template.Must(template.New("tmp").Parse(formatTmpl))
+	_ = t.Execute(&tpl, data)
+	return tpl.String()
+}
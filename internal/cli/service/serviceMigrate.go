@@ -0,0 +1,40 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/clintjedwards/todo/internal/app"
+	"github.com/clintjedwards/todo/internal/config"
+	"github.com/clintjedwards/todo/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var cmdServiceMigrate = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage the Todo database schema.",
+	Long: `Manage the Todo database schema.
+
+The Todo service automatically applies all pending migrations when it starts. These commands operate on the
+database configured for the Todo service (the same configuration used by 'todo service start') and allow
+migrations to be inspected, applied or rolled back by hand.`,
+}
+
+func init() {
+	CmdService.AddCommand(cmdServiceMigrate)
+}
+
+// openServiceStorage opens the database configured for the service without applying any migrations.
+func openServiceStorage(cmd *cobra.Command) (*storage.DB, error) {
+	configPath, _ := cmd.Flags().GetString("config")
+	conf, err := config.InitAPIConfig(configPath, true, true, false)
+	if err != nil {
+		return nil, fmt.Errorf("could not load service config: %w", err)
+	}
+
+	db, err := app.OpenStorage(conf.Server)
+	if err != nil {
+		return nil, fmt.Errorf("could not open database: %w", err)
+	}
+
+	return db, nil
+}
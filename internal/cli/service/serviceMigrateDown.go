@@ -0,0 +1,82 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var cmdServiceMigrateDown = &cobra.Command{
+	Use:   "down",
+	Short: "Roll back applied migrations.",
+	Long: `Roll back applied migrations.
+
+By default only the latest migration is rolled back. Use --to to roll back every migration applied after a
+specific migration. Rolling back a migration can permanently remove data; take a backup first.
+
+The Todo service should be stopped before rolling back migrations.`,
+	Example: `$ todo service migrate down
+$ todo service migrate down --to 0`,
+	RunE:        serviceMigrateDown,
+	Annotations: map[string]string{cl.ConfigAnnotation: cl.ConfigAnnotationAPI},
+}
+
+func init() {
+	cmdServiceMigrateDown.Flags().String("to", "", "ID of the migration to roll back to; it will remain applied")
+	cmdServiceMigrateDown.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+	cmdServiceMigrate.AddCommand(cmdServiceMigrateDown)
+}
+
+func serviceMigrateDown(cmd *cobra.Command, _ []string) error {
+	to, _ := cmd.Flags().GetString("to")
+	force, _ := cmd.Flags().GetBool("force")
+
+	cl.State.Fmt.Print("Rolling back migrations")
+	if !force {
+		cl.State.Fmt.Finish()
+
+		var input string
+
+		fmt.Printf("%s\n", color.YellowString("[Caution] Rolling back migrations can permanently remove data."))
+		fmt.Print("Continue? [y/N]: ")
+		fmt.Scanln(&input)
+		if !strings.EqualFold(input, "y") {
+			return nil
+		}
+	}
+
+	cl.State.NewFormatter()
+
+	db, err := openServiceStorage(cmd)
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+	defer db.Close()
+
+	err = db.MigrateDown(to)
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not roll back migrations: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	version, err := db.SchemaVersion()
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not retrieve schema version: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if version == "" {
+		version = "none"
+	}
+
+	cl.State.Fmt.PrintSuccess(fmt.Sprintf("Database is at schema version %s", version))
+	cl.State.Fmt.Finish()
+	return nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var cmdServiceMigrateStatus = &cobra.Command{
+	Use:         "status",
+	Short:       "List all migrations and whether they have been applied.",
+	Example:     `$ todo service migrate status`,
+	RunE:        serviceMigrateStatus,
+	Annotations: map[string]string{cl.ConfigAnnotation: cl.ConfigAnnotationAPI},
+}
+
+func init() {
+	cmdServiceMigrate.AddCommand(cmdServiceMigrateStatus)
+}
+
+func serviceMigrateStatus(cmd *cobra.Command, _ []string) error {
+	cl.State.Fmt.Print("Retrieving migration status")
+
+	db, err := openServiceStorage(cmd)
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+	defer db.Close()
+
+	statuses, err := db.MigrationStatus()
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not retrieve migration status: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	version, err := db.SchemaVersion()
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not retrieve schema version: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+	cl.State.Fmt.Finish()
+
+	data := [][]string{}
+	for _, status := range statuses {
+		state := color.YellowString("pending")
+		if status.Applied {
+			state = color.GreenString("applied")
+		}
+		if !status.Known {
+			state = color.RedString("unknown")
+		}
+
+		reversible := "no"
+		if status.Reversible {
+			reversible = "yes"
+		}
+
+		data = append(data, []string{status.ID, state, reversible})
+	}
+
+	if version == "" {
+		version = "none"
+	}
+
+	fmt.Printf("Schema version: %s\n\n", color.MagentaString(version))
+	fmt.Println(formatMigrationTable(data))
+
+	return nil
+}
+
+func formatMigrationTable(data [][]string) string {
+	tableString := &strings.Builder{}
+	table := tablewriter.NewWriter(tableString)
+
+	table.SetHeader([]string{"ID", "State", "Reversible"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeaderLine(true)
+	table.SetBorder(false)
+	table.SetAutoFormatHeaders(false)
+	table.SetRowSeparator("―")
+	table.SetRowLine(false)
+	table.SetColumnSeparator("")
+	table.SetCenterSeparator("")
+
+	table.AppendBulk(data)
+
+	table.Render()
+	return tableString.String()
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/spf13/cobra"
+)
+
+var cmdServiceMigrateUp = &cobra.Command{
+	Use:   "up",
+	Short: "Apply pending migrations.",
+	Long: `Apply pending migrations.
+
+By default all pending migrations are applied. Use --to to stop once a specific migration has been applied.`,
+	Example: `$ todo service migrate up
+$ todo service migrate up --to 2`,
+	RunE:        serviceMigrateUp,
+	Annotations: map[string]string{cl.ConfigAnnotation: cl.ConfigAnnotationAPI},
+}
+
+func init() {
+	cmdServiceMigrateUp.Flags().String("to", "", "ID of the last migration to apply")
+	cmdServiceMigrate.AddCommand(cmdServiceMigrateUp)
+}
+
+func serviceMigrateUp(cmd *cobra.Command, _ []string) error {
+	to, _ := cmd.Flags().GetString("to")
+
+	cl.State.Fmt.Print("Applying migrations")
+
+	db, err := openServiceStorage(cmd)
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+	defer db.Close()
+
+	err = db.MigrateUp(to)
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not apply migrations: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	version, err := db.SchemaVersion()
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not retrieve schema version: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	cl.State.Fmt.PrintSuccess(fmt.Sprintf("Database is at schema version %s", version))
+	cl.State.Fmt.Finish()
+	return nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/config"
+	"github.com/clintjedwards/todo/internal/storage"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var cmdServiceRestore = &cobra.Command{
+	Use:   "restore <src>",
+	Short: "Replace the Todo database with a backup.",
+	Long: `Replace the Todo database with a backup.
+
+The backup is checked for integrity and to make sure it was not created by a newer version of Todo
+before it is swapped in. The database being replaced is kept next to it with the suffix ".pre-restore-"
+followed by the time of the restore.
+
+The Todo service must be stopped before running a restore.`,
+	Example:     `$ todo service restore /var/backups/todo.db`,
+	RunE:        serviceRestore,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{cl.ConfigAnnotation: cl.ConfigAnnotationAPI},
+}
+
+func init() {
+	cmdServiceRestore.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+	CmdService.AddCommand(cmdServiceRestore)
+}
+
+func serviceRestore(cmd *cobra.Command, args []string) error {
+	src := args[0]
+
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not restore database: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	configPath, _ := cmd.Flags().GetString("config")
+	conf, err := config.InitAPIConfig(configPath, true, true, false)
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not load service config: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	cl.State.Fmt.Print("Restoring database")
+
+	if conf.Server.StorageDriver != string(storage.DriverSQLite) {
+		err = fmt.Errorf("restores are only supported for the sqlite storage driver")
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if !force {
+		cl.State.Fmt.Finish()
+
+		var input string
+
+		fmt.Printf("%s\n", color.YellowString("[Caution] Restoring will replace the database at %s. "+
+			"Make sure the todo service is stopped.", conf.Server.StoragePath))
+		fmt.Print("Continue? [y/N]: ")
+		fmt.Scanln(&input)
+		if !strings.EqualFold(input, "y") {
+			return nil
+		}
+	}
+
+	cl.State.NewFormatter()
+
+	kept, err := storage.Restore(src, conf.Server.StoragePath)
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not restore database: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if kept != "" {
+		cl.State.Fmt.PrintSuccess(fmt.Sprintf("Restored %s from %s; the database it replaced was kept at %s",
+			conf.Server.StoragePath, src, kept))
+	} else {
+		cl.State.Fmt.PrintSuccess(fmt.Sprintf("Restored %s from %s", conf.Server.StoragePath, src))
+	}
+	cl.State.Fmt.Finish()
+	return nil
+}
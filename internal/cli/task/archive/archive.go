@@ -0,0 +1,15 @@
+package archive
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var CmdArchive = &cobra.Command{
+	Use:   "archive",
+	Short: "Manage archived tasks",
+	Long: `Manage archived tasks.
+
+When the server's archive_policy is set to "archive", trees of tasks that have been completed for longer than
+archive_after are moved out of the task list and into the archive. Archived tasks can be listed and restored here, or
+shown alongside the rest with 'todo list --include-archived'.`,
+}
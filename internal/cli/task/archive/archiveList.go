@@ -0,0 +1,72 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/cli/format"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/spf13/cobra"
+)
+
+var CmdArchiveList = &cobra.Command{
+	Use:   "list",
+	Short: "List archived tasks",
+	Long:  `List archived tasks, most recently archived first.`,
+	Example: `$ todo archive list
+$ todo archive list --format json`,
+	RunE: archiveList,
+}
+
+func init() {
+	CmdArchive.AddCommand(CmdArchiveList)
+	cl.AddOutputFlags(CmdArchiveList)
+}
+
+func archiveList(_ *cobra.Command, _ []string) error {
+	cl.State.Fmt.Print("Collecting Archived Tasks")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.ListArchivedTasks(context.Background(), &proto.ListArchivedTasksRequest{
+		Offset: 0,
+		Limit:  0,
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not list archived tasks: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if cl.State.WritesData() {
+		err = cl.State.WriteData(format.NewTasks(resp.Tasks))
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not list archived tasks: %v", err))
+			return err
+		}
+		return nil
+	}
+
+	data := [][]string{}
+	for _, task := range resp.Tasks {
+		data = append(data, []string{
+			task.Id, task.Title, task.Parent,
+			format.UnixMilli(task.CompletedAt, "Unknown", cl.State.Config.Detail),
+			format.UnixMilli(task.Archived, "Unknown", cl.State.Config.Detail),
+		})
+	}
+
+	headers := []string{"ID", "Title", "Parent", "Completed", "Archived"}
+	cl.State.Fmt.Println(format.Table(headers, data, !cl.State.Config.NoColor))
+	cl.State.Fmt.Finish()
+
+	return nil
+}
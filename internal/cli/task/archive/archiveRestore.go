@@ -0,0 +1,62 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var CmdArchiveRestore = &cobra.Command{
+	Use:   "restore <id>",
+	Short: "Move an archived tree of tasks back into the task list",
+	Long: `Move an archived tree of tasks back into the task list. Trees are archived and restored whole, so giving any
+task in a tree restores all of it.
+
+A restored tree that is still completed is archived again the next time the server applies its archive policy, so
+reopen any task in it that should stay in the task list.`,
+	Example: `$ todo archive restore 62arz`,
+	RunE:    archiveRestore,
+	Args:    cobra.ExactArgs(1),
+}
+
+func init() {
+	CmdArchive.AddCommand(CmdArchiveRestore)
+}
+
+func archiveRestore(_ *cobra.Command, args []string) error {
+	id := args[0]
+
+	cl.State.Fmt.Print("Restoring Archived Tasks")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.RestoreArchivedTask(context.Background(), &proto.RestoreArchivedTaskRequest{
+		Id: id,
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not restore archived task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	ids := []string{}
+	for _, id := range resp.RestoredIds {
+		ids = append(ids, color.MagentaString(id))
+	}
+
+	cl.State.Fmt.PrintSuccess(fmt.Sprintf("Restored tasks: %s", strings.Join(ids, ", ")))
+	cl.State.Fmt.Finish()
+	return nil
+}
@@ -1,8 +1,93 @@
 package scheduled
 
-import "github.com/spf13/cobra"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/clintjedwards/todo/proto"
+	"github.com/spf13/cobra"
+)
 
 var CmdScheduled = &cobra.Command{
 	Use:   "scheduled",
 	Short: "Manage scheduled tasks",
 }
+
+// ExpressionTypeFlagHelp is the help text for flags that take an expression type.
+const ExpressionTypeFlagHelp = "Type of expression; one of auto, avail, cron, rrule or natural"
+
+// AfterCompletionFlagHelp is the help text for flags that switch an expression to the after completion recurrence.
+const AfterCompletionFlagHelp = "Treat the expression as a delay after the previous task is completed; ex. \"3 days\""
+
+// TimezoneFlagHelp is the help text for flags that set the time zone an expression is evaluated in.
+const TimezoneFlagHelp = "IANA time zone to evaluate the expression in, ex. America/New_York; defaults to the server's"
+
+// DisplayLocation returns the time zone times should be shown to the user in: the one named or, if no name is given,
+// the local time zone.
+func DisplayLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("time zone %q not recognized", name)
+	}
+
+	return location, nil
+}
+
+// ParseRecurrence returns the recurrence to request given the value of an after completion flag. Without the flag the
+// recurrence is detected by the server.
+func ParseRecurrence(afterCompletion bool) proto.ScheduledTask_Recurrence {
+	if afterCompletion {
+		return proto.ScheduledTask_AFTER_COMPLETION
+	}
+
+	return proto.ScheduledTask_RECURRENCE_UNKNOWN
+}
+
+// ParseExpressionType turns the value of an expression type flag into its proto equivalent. "auto" leaves the
+// expression type to be detected by the server.
+func ParseExpressionType(value string) (proto.ScheduledTask_ExpressionType, error) {
+	if strings.EqualFold(value, "auto") || value == "" {
+		return proto.ScheduledTask_EXPRESSION_TYPE_UNKNOWN, nil
+	}
+
+	expressionType, exists := proto.ScheduledTask_ExpressionType_value[strings.ToUpper(value)]
+	if !exists || expressionType == int32(proto.ScheduledTask_EXPRESSION_TYPE_UNKNOWN) {
+		return proto.ScheduledTask_EXPRESSION_TYPE_UNKNOWN,
+			fmt.Errorf("expression type %q not recognized; must be one of auto, avail, cron, rrule or natural", value)
+	}
+
+	return proto.ScheduledTask_ExpressionType(expressionType), nil
+}
+
+// formatScheduledTaskType describes how the scheduled task given decides when to create new tasks.
+func formatScheduledTaskType(scheduledTask *proto.ScheduledTask) string {
+	description := formatExpressionType(scheduledTask.ExpressionType)
+	if scheduledTask.Recurrence == proto.ScheduledTask_AFTER_COMPLETION {
+		description = "After completion"
+	} else if scheduledTask.SkipIfOpen {
+		description += ", skips while open"
+	}
+
+	return description
+}
+
+// formatExpressionType returns a human readable name for the expression type given.
+func formatExpressionType(expressionType proto.ScheduledTask_ExpressionType) string {
+	switch expressionType {
+	case proto.ScheduledTask_AVAIL:
+		return "Avail"
+	case proto.ScheduledTask_CRON:
+		return "Cron"
+	case proto.ScheduledTask_RRULE:
+		return "RRULE"
+	case proto.ScheduledTask_NATURAL:
+		return "Natural"
+	default:
+		return "Unknown"
+	}
+}
@@ -11,11 +11,12 @@ import (
 )
 
 var CmdScheduledTaskDelete = &cobra.Command{
-	Use:     "delete <id>",
-	Short:   "Delete a new scheduled task",
-	Example: `$ todo scheduled delete 62arz`,
-	RunE:    scheduledtaskDelete,
-	Args:    cobra.ExactArgs(1),
+	Use:               "delete <id>",
+	Short:             "Delete a new scheduled task",
+	Example:           `$ todo scheduled delete 62arz`,
+	RunE:              scheduledtaskDelete,
+	ValidArgsFunction: cl.CompleteScheduledTaskID,
+	Args:              cobra.ExactArgs(1),
 }
 
 func init() {
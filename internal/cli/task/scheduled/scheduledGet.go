@@ -5,28 +5,55 @@ import (
 	"context"
 	"fmt"
 	"text/template"
+	"time"
 
 	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/cli/format"
 	"github.com/clintjedwards/todo/proto"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
 var CmdScheduledTaskGet = &cobra.Command{
-	Use:     "get <id>",
-	Short:   "Describe a scheduled task",
-	Example: `$ todo scheduled get 62arz`,
-	RunE:    scheduledtaskGet,
-	Args:    cobra.ExactArgs(1),
+	Use:               "get <id>",
+	Short:             "Describe a scheduled task",
+	Example:           `$ todo scheduled get 62arz`,
+	RunE:              scheduledtaskGet,
+	ValidArgsFunction: cl.CompleteScheduledTaskID,
+	Args:              cobra.ExactArgs(1),
 }
 
 func init() {
 	CmdScheduled.AddCommand(CmdScheduledTaskGet)
+	cl.AddOutputFlags(CmdScheduledTaskGet)
+	CmdScheduledTaskGet.Flags().IntP("next", "n", 3, "Number of upcoming fire times to show")
+	CmdScheduledTaskGet.Flags().String("tz", "", "IANA time zone to show times in; defaults to your local time zone")
 }
 
-func scheduledtaskGet(_ *cobra.Command, args []string) error {
+func scheduledtaskGet(cmd *cobra.Command, args []string) error {
 	id := args[0]
 
+	next, err := cmd.Flags().GetInt("next")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get scheduled task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	timezone, err := cmd.Flags().GetString("tz")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get scheduled task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	displayLocation, err := DisplayLocation(timezone)
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get scheduled task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
 	cl.State.Fmt.Print("Getting Scheduled Task Details")
 
 	conn, err := cl.State.Connect()
@@ -39,7 +66,8 @@ func scheduledtaskGet(_ *cobra.Command, args []string) error {
 	client := proto.NewTodoClient(conn)
 
 	resp, err := client.GetScheduledTask(context.Background(), &proto.GetScheduledTaskRequest{
-		Id: id,
+		Id:            id,
+		NextFireCount: int64(next),
 	})
 	if err != nil {
 		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get scheduled task: %v", err))
@@ -47,7 +75,16 @@ func scheduledtaskGet(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	cl.State.Fmt.Println(formatScheduledTaskInfo(resp.ScheduledTask))
+	if cl.State.WritesData() {
+		err = cl.State.WriteData(format.NewScheduledTask(resp.ScheduledTask, resp.FireTimes))
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not get scheduled task: %v", err))
+			return err
+		}
+		return nil
+	}
+
+	cl.State.Fmt.Println(formatScheduledTaskInfo(resp.ScheduledTask, resp.FireTimes, displayLocation))
 	cl.State.Fmt.Finish()
 	return nil
 }
@@ -58,25 +95,54 @@ type data struct {
 	Description string
 	Parent      string
 	Expression  string
+	Type        string
+	Timezone    string
+	Template    string
+	State       string
+	FireTimes   string
 }
 
-func formatScheduledTaskInfo(scheduledtask *proto.ScheduledTask) string {
+func formatScheduledTaskInfo(scheduledtask *proto.ScheduledTask, fireTimes *proto.FireTimes,
+	displayLocation *time.Location,
+) string {
+	timezone := scheduledtask.Timezone
+	if timezone == "" {
+		timezone = "Server default"
+	}
+
 	data := data{
 		ID:          color.MagentaString(scheduledtask.Id),
 		Title:       color.BlueString(scheduledtask.Title),
 		Description: scheduledtask.Description,
 		Parent:      scheduledtask.Parent,
 		Expression:  scheduledtask.Expression,
+		Type:        formatScheduledTaskType(scheduledtask),
+		State:       formatScheduledTaskState(scheduledtask.Paused),
+		Timezone:    timezone,
+		Template:    scheduledtask.TemplateId,
+		FireTimes:   formatFireTimes(fireTimes, displayLocation),
 	}
 
-	const formatTmpl = `ScheduledTask [{{.ID}}] :: {{.Title}} :: {{.Expression}}
+	const formatTmpl = `ScheduledTask [{{.ID}}] :: {{.Title}} :: {{.Expression}} :: {{.State}}
 
   {{if .Description}}{{.Description}}{{- end}}
 
-{{if .Parent}}Parent: {{.Parent}}{{- end}}`
+Type: {{.Type}}
+Time zone: {{.Timezone}}
+{{if .Template}}Creates template: {{.Template}}
+{{end}}{{if .Parent}}Parent: {{.Parent}}
+{{end}}{{.FireTimes}}`
 
 	var tpl bytes.Buffer
 	t := template.Must(template.New("tmp").Parse(formatTmpl))
 	_ = t.Execute(&tpl, data)
 	return tpl.String()
 }
+
+func formatScheduledTaskState(paused bool) string {
+	if paused {
+		return color.YellowString("Paused")
+	}
+
+	return color.GreenString("Active")
+}
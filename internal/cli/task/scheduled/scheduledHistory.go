@@ -0,0 +1,110 @@
+package scheduled
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/cli/format"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var CmdScheduledTaskHistory = &cobra.Command{
+	Use:   "history <id>",
+	Short: "Show the tasks a scheduled task has created",
+	Long: `Show the tasks a scheduled task has created, newest first, along with when each was due and completed.
+
+The summary shows how many tasks have been completed in a row and the share of tasks completed overall. The newest
+task isn't counted against you while it's still open.`,
+	Example: `$ todo scheduled history 62arz
+$ todo scheduled history 62arz --limit 50`,
+	RunE:              scheduledtaskHistory,
+	ValidArgsFunction: cl.CompleteScheduledTaskID,
+	Args:              cobra.ExactArgs(1),
+}
+
+func init() {
+	CmdScheduled.AddCommand(CmdScheduledTaskHistory)
+	cl.AddOutputFlags(CmdScheduledTaskHistory)
+	CmdScheduledTaskHistory.Flags().IntP("limit", "l", 20, "Number of tasks to show; 0 shows all of them")
+}
+
+func scheduledtaskHistory(cmd *cobra.Command, args []string) error {
+	id := args[0]
+
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get scheduled task history: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	cl.State.Fmt.Print("Collecting Scheduled Task History")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.GetScheduledTaskHistory(context.Background(), &proto.GetScheduledTaskHistoryRequest{
+		Id:    id,
+		Limit: int64(limit),
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get scheduled task history: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+	cl.State.Fmt.Finish()
+
+	if cl.State.WritesData() {
+		err = cl.State.WriteData(format.NewScheduledTaskHistory(resp))
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not get scheduled task history: %v", err))
+			return err
+		}
+		return nil
+	}
+
+	data := [][]string{}
+	for _, task := range resp.Tasks {
+		completed := "-"
+		if task.State == proto.Task_COMPLETED {
+			completed = format.UnixMilli(task.CompletedAt, "Unknown", cl.State.Config.Detail)
+		}
+
+		data = append(data, []string{
+			task.Id,
+			format.UnixMilli(task.ScheduledFor, "Unknown", cl.State.Config.Detail),
+			format.UnixMilli(task.Created, "Unknown", cl.State.Config.Detail),
+			format.ColorizeTaskState(format.NormalizeEnumValue(task.State.String(), "Unknown")),
+			completed,
+		})
+	}
+
+	cl.State.Fmt.Println(fmt.Sprintf("ScheduledTask [%s] :: %s\n\n%s\n", color.MagentaString(resp.ScheduledTask.Id),
+		color.BlueString(resp.ScheduledTask.Title), formatHistorySummary(resp.Summary)))
+
+	if len(data) > 0 {
+		headers := []string{"ID", "Due", "Created", "State", "Completed"}
+		cl.State.Fmt.Println(format.Table(headers, data, !cl.State.Config.NoColor))
+	}
+
+	cl.State.Fmt.Finish()
+	return nil
+}
+
+func formatHistorySummary(summary *proto.ScheduledTaskHistorySummary) string {
+	if summary.Total == 0 {
+		return "No tasks have been created yet."
+	}
+
+	return fmt.Sprintf("Tasks created: %d\nCompletion rate: %.0f%% (%d completed)\nCurrent streak: %d\nLongest streak: %d",
+		summary.Total, summary.CompletionRate*100, summary.Completed, summary.CurrentStreak, summary.LongestStreak)
+}
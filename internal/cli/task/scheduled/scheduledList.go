@@ -6,8 +6,8 @@ import (
 	"strings"
 
 	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/cli/format"
 	"github.com/clintjedwards/todo/proto"
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
@@ -20,9 +20,18 @@ var CmdScheduledTaskList = &cobra.Command{
 
 func init() {
 	CmdScheduled.AddCommand(CmdScheduledTaskList)
+	cl.AddOutputFlags(CmdScheduledTaskList)
+	CmdScheduledTaskList.Flags().IntP("next", "n", 1, "Number of upcoming fire times to show for each scheduled task")
 }
 
-func scheduledList(_ *cobra.Command, _ []string) error {
+func scheduledList(cmd *cobra.Command, _ []string) error {
+	next, err := cmd.Flags().GetInt("next")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not list task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
 	cl.State.Fmt.Print("Collecting Tasks")
 
 	conn, err := cl.State.Connect()
@@ -35,8 +44,9 @@ func scheduledList(_ *cobra.Command, _ []string) error {
 	client := proto.NewTodoClient(conn)
 
 	resp, err := client.ListScheduledTasks(context.Background(), &proto.ListScheduledTasksRequest{
-		Offset: 0,
-		Limit:  0,
+		Offset:        0,
+		Limit:         0,
+		NextFireCount: int64(next),
 	})
 	if err != nil {
 		cl.State.Fmt.PrintErr(fmt.Sprintf("could not list task: %v", err))
@@ -45,49 +55,43 @@ func scheduledList(_ *cobra.Command, _ []string) error {
 	}
 	cl.State.Fmt.Finish()
 
+	if cl.State.WritesData() {
+		scheduledTasks := []format.ScheduledTask{}
+		for _, task := range resp.ScheduledTasks {
+			scheduledTasks = append(scheduledTasks, format.NewScheduledTask(task, resp.FireTimes[task.Id]))
+		}
+
+		err = cl.State.WriteData(scheduledTasks)
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not list task: %v", err))
+			return err
+		}
+		return nil
+	}
+
 	data := [][]string{}
 	for _, task := range resp.ScheduledTasks {
+		fireTimes := resp.FireTimes[task.Id]
+
+		nextFires := []string{}
+		for _, next := range fireTimes.GetNext() {
+			nextFires = append(nextFires, format.UnixMilli(next, "Never", false))
+		}
+
+		state := "Active"
+		if task.Paused {
+			state = "Paused"
+		}
+
 		data = append(data, []string{
-			task.Id, task.Title, task.Expression,
+			task.Id, task.Title, task.Expression, formatScheduledTaskType(task), state,
+			format.UnixMilli(fireTimes.GetLast(), "", false), strings.Join(nextFires, ", "),
 		})
 	}
 
-	cl.State.Fmt.Println(formatTable(data, !cl.State.Config.NoColor))
+	headers := []string{"ID", "Title", "Expression", "Type", "State", "Last Fired", "Next Fires"}
+	cl.State.Fmt.Println(format.Table(headers, data, !cl.State.Config.NoColor))
 	cl.State.Fmt.Finish()
 
 	return nil
 }
-
-func formatTable(data [][]string, color bool) string {
-	tableString := &strings.Builder{}
-	table := tablewriter.NewWriter(tableString)
-
-	table.SetHeader([]string{"ID", "Title", "Expression"})
-	table.SetAlignment(tablewriter.ALIGN_LEFT)
-	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
-	table.SetHeaderLine(true)
-	table.SetBorder(false)
-	table.SetAutoFormatHeaders(false)
-	table.SetRowSeparator("―")
-	table.SetRowLine(false)
-	table.SetColumnSeparator("")
-	table.SetCenterSeparator("")
-
-	if color {
-		table.SetHeaderColor(
-			tablewriter.Color(tablewriter.FgBlueColor),
-			tablewriter.Color(tablewriter.FgBlueColor),
-			tablewriter.Color(tablewriter.FgBlueColor),
-		)
-		table.SetColumnColor(
-			tablewriter.Color(tablewriter.FgYellowColor),
-			tablewriter.Color(0),
-			tablewriter.Color(0),
-		)
-	}
-
-	table.AppendBulk(data)
-
-	table.Render()
-	return tableString.String()
-}
@@ -0,0 +1,54 @@
+package scheduled
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var CmdScheduledTaskPause = &cobra.Command{
+	Use:   "pause <id>",
+	Short: "Stop a scheduled task from creating new tasks",
+	Long: `Stop a scheduled task from creating new tasks.
+
+The scheduled task is kept as is and can be started again with 'todo scheduled resume'.`,
+	Example:           `$ todo scheduled pause 62arz`,
+	RunE:              scheduledtaskPause,
+	ValidArgsFunction: cl.CompleteScheduledTaskID,
+	Args:              cobra.ExactArgs(1),
+}
+
+func init() {
+	CmdScheduled.AddCommand(CmdScheduledTaskPause)
+}
+
+func scheduledtaskPause(_ *cobra.Command, args []string) error {
+	id := args[0]
+
+	cl.State.Fmt.Print("Pausing Scheduled Task")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.PauseScheduledTask(context.Background(), &proto.PauseScheduledTaskRequest{
+		Id: id,
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not pause scheduled task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+	cl.State.Fmt.PrintSuccess(fmt.Sprintf("Paused scheduled task: %s", color.MagentaString(resp.Id)))
+	cl.State.Fmt.Finish()
+	return nil
+}
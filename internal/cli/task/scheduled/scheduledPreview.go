@@ -0,0 +1,153 @@
+package scheduled
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/cli/format"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var CmdScheduledTaskPreview = &cobra.Command{
+	Use:   "preview <expression>",
+	Short: "Show when an expression would fire without scheduling anything",
+	Long: `Show when an expression would fire without scheduling anything.
+
+Useful for sanity checking an expression before passing it to 'todo schedule'. The type of the expression is
+detected unless given with --type; see 'todo schedule --help' for the kinds of expressions understood.`,
+	Example: `$ todo scheduled preview "0 9 * * 1-5 *"
+$ todo scheduled preview "0 0 1 * * *" --next 12
+$ todo scheduled preview "every 2nd tuesday at 9am"
+$ todo scheduled preview "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO" --type rrule
+$ todo scheduled preview "every 3 days after completion"
+$ todo scheduled preview "every day at 9am" --tz America/New_York`,
+	RunE: scheduledtaskPreview,
+	Args: cobra.ExactArgs(1),
+}
+
+func init() {
+	CmdScheduled.AddCommand(CmdScheduledTaskPreview)
+	cl.AddOutputFlags(CmdScheduledTaskPreview)
+	CmdScheduledTaskPreview.Flags().IntP("next", "n", 5, "Number of upcoming fire times to show")
+	CmdScheduledTaskPreview.Flags().StringP("type", "t", "auto", ExpressionTypeFlagHelp)
+	CmdScheduledTaskPreview.Flags().Bool("after-completion", false, AfterCompletionFlagHelp)
+	CmdScheduledTaskPreview.Flags().String("tz", "", TimezoneFlagHelp+"; times are also shown in this time zone")
+}
+
+func scheduledtaskPreview(cmd *cobra.Command, args []string) error {
+	expression := args[0]
+
+	next, err := cmd.Flags().GetInt("next")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not preview expression: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	typeFlag, err := cmd.Flags().GetString("type")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not preview expression: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	expressionType, err := ParseExpressionType(typeFlag)
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not preview expression: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	afterCompletion, err := cmd.Flags().GetBool("after-completion")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not preview expression: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	timezone, err := cmd.Flags().GetString("tz")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not preview expression: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	displayLocation, err := DisplayLocation(timezone)
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not preview expression: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	cl.State.Fmt.Print("Previewing Expression")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.PreviewSchedule(context.Background(), &proto.PreviewScheduleRequest{
+		Expression:     expression,
+		NextFireCount:  int64(next),
+		ExpressionType: expressionType,
+		Recurrence:     ParseRecurrence(afterCompletion),
+		Timezone:       timezone,
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not preview expression: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if cl.State.WritesData() {
+		err = cl.State.WriteData(format.NewSchedulePreview(expression, resp))
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not preview expression: %v", err))
+			return err
+		}
+		return nil
+	}
+
+	if resp.Recurrence == proto.ScheduledTask_AFTER_COMPLETION {
+		cl.State.Fmt.Println(fmt.Sprintf("%s (After completion)\n\nA new task is created %s after the previous one "+
+			"is completed.", color.BlueString(expression), resp.Delay))
+		cl.State.Fmt.Finish()
+		return nil
+	}
+
+	cl.State.Fmt.Println(fmt.Sprintf("%s (%s)\n\n%s", color.BlueString(expression),
+		formatExpressionType(resp.ExpressionType), formatFireTimes(resp.FireTimes, displayLocation)))
+	cl.State.Fmt.Finish()
+	return nil
+}
+
+// formatFireTimes lists the last and upcoming fire times of a schedule, one per line, in the time zone given. The last
+// fire time is left out if the schedule has never fired.
+func formatFireTimes(fireTimes *proto.FireTimes, location *time.Location) string {
+	var b strings.Builder
+
+	if fireTimes.GetLast() != 0 {
+		fmt.Fprintf(&b, "Last fired: %s\n", format.UnixMilliIn(fireTimes.GetLast(), "", true, location))
+	}
+
+	if len(fireTimes.GetNext()) == 0 {
+		b.WriteString("Next fires: Never")
+		return b.String()
+	}
+
+	b.WriteString("Next fires:")
+	for _, next := range fireTimes.GetNext() {
+		fmt.Fprintf(&b, "\n  • %s", format.UnixMilliIn(next, "Never", true, location))
+	}
+
+	return b.String()
+}
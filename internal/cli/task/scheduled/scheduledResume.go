@@ -0,0 +1,51 @@
+package scheduled
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var CmdScheduledTaskResume = &cobra.Command{
+	Use:               "resume <id>",
+	Short:             "Allow a paused scheduled task to create new tasks again",
+	Example:           `$ todo scheduled resume 62arz`,
+	RunE:              scheduledtaskResume,
+	ValidArgsFunction: cl.CompleteScheduledTaskID,
+	Args:              cobra.ExactArgs(1),
+}
+
+func init() {
+	CmdScheduled.AddCommand(CmdScheduledTaskResume)
+}
+
+func scheduledtaskResume(_ *cobra.Command, args []string) error {
+	id := args[0]
+
+	cl.State.Fmt.Print("Resuming Scheduled Task")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.ResumeScheduledTask(context.Background(), &proto.ResumeScheduledTaskRequest{
+		Id: id,
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not resume scheduled task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+	cl.State.Fmt.PrintSuccess(fmt.Sprintf("Resumed scheduled task: %s", color.MagentaString(resp.Id)))
+	cl.State.Fmt.Finish()
+	return nil
+}
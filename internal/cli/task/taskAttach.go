@@ -0,0 +1,129 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var CmdTaskAttach = &cobra.Command{
+	Use:   "attach <id> <file>",
+	Short: "Attach a file to a task",
+	Long: `Attach a file to a task, such as a receipt or a screenshot. The server limits how large each file and all of a
+task's attachments together can be.
+
+Attachments are listed and downloaded with 'todo attachments'. They're deleted along with their task and brought
+back with it by 'todo undo'.`,
+	Example: `$ todo attach 62arz ~/Downloads/receipt.pdf`,
+	RunE:    taskAttach,
+	Args:    cobra.ExactArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		// The task comes first and is completed like any other; the file after it is left to the shell.
+		if len(args) == 1 {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		return cl.CompleteTaskID(cmd, args, toComplete)
+	},
+}
+
+func init() {
+	CmdTaskAttach.Flags().String("name", "", "Name to attach the file under; defaults to the file's own name")
+}
+
+// The most bytes sent in a single message when uploading an attachment.
+const attachmentChunkSize = 64 << 10
+
+func taskAttach(cmd *cobra.Command, args []string) error {
+	id, path := args[0], args[1]
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not attach file: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if name == "" {
+		name = filepath.Base(path)
+	}
+
+	cl.State.Fmt.Print("Attaching File")
+
+	file, err := os.Open(path)
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not attach file: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+	defer file.Close()
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := uploadAttachment(client, id, name, file)
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not attach file: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	cl.State.Fmt.PrintSuccess(fmt.Sprintf("Attached %s (%s) to task %s as %s", resp.Attachment.Name,
+		humanize.IBytes(uint64(resp.Attachment.Size)), color.MagentaString(resp.Attachment.TaskId),
+		color.MagentaString(resp.Attachment.Id)))
+	cl.State.Fmt.Finish()
+	return nil
+}
+
+// uploadAttachment streams the contents of the file given to the server, details first.
+func uploadAttachment(client proto.TodoClient, id, name string, file io.Reader) (*proto.UploadAttachmentResponse, error) {
+	stream, err := client.UploadAttachment(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	err = stream.Send(&proto.UploadAttachmentRequest{
+		Data: &proto.UploadAttachmentRequest_Details_{Details: &proto.UploadAttachmentRequest_Details{
+			TaskId: id,
+			Name:   name,
+		}},
+	})
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	buffer := make([]byte, attachmentChunkSize)
+	for err == nil {
+		var n int
+		n, err = file.Read(buffer)
+		if n > 0 {
+			sendErr := stream.Send(&proto.UploadAttachmentRequest{
+				Data: &proto.UploadAttachmentRequest_Chunk{Chunk: buffer[:n]},
+			})
+			if sendErr != nil {
+				// The server has stopped listening, likely because it rejected the upload; its reason comes with
+				// the response below.
+				break
+			}
+		}
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	return stream.CloseAndRecv()
+}
@@ -0,0 +1,188 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/cli/format"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var CmdTaskAttachments = &cobra.Command{
+	Use:   "attachments <id>",
+	Short: "List or download the files attached to a task",
+	Long: `List the files attached to a task or, with --get, download one of them.
+
+Downloads are written to the current directory under the attachment's name unless --output says otherwise. Existing
+files are never overwritten.`,
+	Example: `$ todo attachments 62arz
+$ todo attachments 62arz --get k3m9d
+$ todo attachments 62arz --get k3m9d --output - | less`,
+	RunE:              taskAttachments,
+	ValidArgsFunction: cl.CompleteTaskID,
+	Args:              cobra.ExactArgs(1),
+}
+
+func init() {
+	cl.AddOutputFlags(CmdTaskAttachments)
+	CmdTaskAttachments.Flags().String("get", "", "ID of an attachment to download")
+	CmdTaskAttachments.Flags().StringP("output", "o", "", "Path to download the attachment to, or - for stdout")
+}
+
+func taskAttachments(cmd *cobra.Command, args []string) error {
+	id := args[0]
+
+	get, err := cmd.Flags().GetString("get")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get attachments: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get attachments: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if get != "" {
+		return downloadAttachment(get, output)
+	}
+
+	if output != "" {
+		err := errors.New("--output can only be used with --get")
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get attachments: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	cl.State.Fmt.Print("Collecting Attachments")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.ListAttachments(context.Background(), &proto.ListAttachmentsRequest{
+		TaskId: id,
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get attachments: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if cl.State.WritesData() {
+		err = cl.State.WriteData(format.NewAttachments(resp.Attachments))
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not get attachments: %v", err))
+			return err
+		}
+		return nil
+	}
+
+	data := [][]string{}
+	for _, attachment := range resp.Attachments {
+		data = append(data, []string{
+			attachment.Id, attachment.Name, humanize.IBytes(uint64(attachment.Size)),
+			format.UnixMilli(attachment.Created, "Unknown", cl.State.Config.Detail),
+		})
+	}
+
+	headers := []string{"ID", "Name", "Size", "Attached"}
+	cl.State.Fmt.Println(format.Table(headers, data, !cl.State.Config.NoColor))
+	cl.State.Fmt.Finish()
+
+	return nil
+}
+
+// downloadAttachment writes the attachment given to output, or to a file named after it if output is empty.
+func downloadAttachment(id, output string) error {
+	cl.State.Fmt.Print("Downloading Attachment")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	stream, err := client.DownloadAttachment(context.Background(), &proto.DownloadAttachmentRequest{
+		Id: id,
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not download attachment: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not download attachment: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	attachment := first.GetAttachment()
+	if attachment == nil {
+		err := fmt.Errorf("server sent the attachment's contents before describing it")
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not download attachment: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if output == "" {
+		output = attachment.Name
+	}
+
+	var file io.Writer = os.Stdout
+	if output != "-" {
+		created, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not download attachment: %v", err))
+			cl.State.Fmt.Finish()
+			return err
+		}
+		defer created.Close()
+		file = created
+	}
+
+	for {
+		message, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err == nil {
+			_, err = file.Write(message.GetChunk())
+		}
+		if err != nil {
+			if output != "-" {
+				os.Remove(output)
+			}
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not download attachment: %v", err))
+			cl.State.Fmt.Finish()
+			return err
+		}
+	}
+
+	if output != "-" {
+		cl.State.Fmt.PrintSuccess(fmt.Sprintf("Downloaded %s (%s) to %s", color.MagentaString(attachment.Id),
+			humanize.IBytes(uint64(attachment.Size)), output))
+	}
+	cl.State.Fmt.Finish()
+	return nil
+}
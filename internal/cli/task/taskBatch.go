@@ -0,0 +1,104 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/fatih/color"
+)
+
+// filterFlagHelp is the help text for flags that select tasks with a filter instead of by ID.
+const filterFlagHelp = `Act on every task matching a filter instead of tasks given by ID. Filters are key=value pairs and
+can be repeated or comma separated; tasks must match all of them:
+  state=unresolved|completed
+  subtree=<id>              tasks below the task given
+  created-before=<when>     a date like 2024-03-01, a time like 2024-03-01T15:04:05Z or a duration ago like 720h`
+
+// parseFilter turns the values of a filter flag into a task filter. It returns nil if no filters were given.
+func parseFilter(values []string) (*proto.TaskFilter, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	filter := &proto.TaskFilter{}
+
+	for _, value := range values {
+		key, arg, ok := strings.Cut(value, "=")
+		if !ok || arg == "" {
+			return nil, fmt.Errorf("filter %q should be in the form key=value", value)
+		}
+
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "state":
+			state, exists := proto.Task_TaskState_value[strings.ToUpper(arg)]
+			if !exists || state == int32(proto.Task_TASK_STATE_UNKNOWN) {
+				return nil, fmt.Errorf("state %q not recognized; should be one of unresolved or completed", arg)
+			}
+			filter.State = proto.Task_TaskState(state)
+		case "subtree", "parent":
+			filter.Subtree = arg
+		case "created-before", "created_before":
+			before, err := parseTime(arg)
+			if err != nil {
+				return nil, err
+			}
+			filter.CreatedBefore = before.UnixMilli()
+		default:
+			return nil, fmt.Errorf("filter %q not recognized; should be one of state, subtree or created-before", key)
+		}
+	}
+
+	return filter, nil
+}
+
+// parseTime reads a date, a time or a duration meaning that long ago.
+func parseTime(value string) (time.Time, error) {
+	if duration, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-duration), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("could not understand time %q; expected a date like 2024-03-01, a time like "+
+		"2024-03-01T15:04:05Z or a duration like 720h", value)
+}
+
+// printBatchResults prints the tasks a batch operation failed for and a summary of the ones it succeeded for. It
+// returns an error if the operation failed for any task.
+func printBatchResults(action string, results []*proto.BatchTaskResult) error {
+	affected := 0
+	failed := 0
+
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+			cl.State.Fmt.PrintErr(fmt.Sprintf("%s: %s", color.MagentaString(result.Id), result.Error))
+			continue
+		}
+
+		affected += len(result.AffectedIds)
+	}
+
+	if len(results) == 0 {
+		cl.State.Fmt.PrintSuccess("No tasks matched")
+		return nil
+	}
+
+	cl.State.Fmt.PrintSuccess(fmt.Sprintf("%s %d of %d tasks (%d including children)", action,
+		len(results)-failed, len(results), affected))
+
+	if failed > 0 {
+		return fmt.Errorf("%d tasks could not be %s", failed, strings.ToLower(action))
+	}
+
+	return nil
+}
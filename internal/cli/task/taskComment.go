@@ -0,0 +1,56 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var CmdTaskComment = &cobra.Command{
+	Use:   "comment <id> <text>",
+	Short: "Add a comment to a task",
+	Long: `Add a comment to a task's running log. Comments are kept in the order they were added and shown beneath the
+description by 'todo get'; they can't be changed once added.
+
+Comments are left under the author set in the cli config, which defaults to the name of the user running the cli.`,
+	Example: `$ todo comment 62arz "called plumber, waiting on quote"
+$ todo comment 62arz quote came in at $300`,
+	RunE:              taskComment,
+	ValidArgsFunction: cl.CompleteTaskID,
+	Args:              cobra.MinimumNArgs(2),
+}
+
+func taskComment(_ *cobra.Command, args []string) error {
+	id := args[0]
+	text := strings.Join(args[1:], " ")
+
+	cl.State.Fmt.Print("Adding Comment")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.AddTaskComment(context.Background(), &proto.AddTaskCommentRequest{
+		TaskId: id,
+		Text:   text,
+		Author: cl.State.Config.Author,
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not add comment: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+	cl.State.Fmt.PrintSuccess(fmt.Sprintf("Added comment to task: %s", color.MagentaString(resp.Comment.TaskId)))
+	cl.State.Fmt.Finish()
+	return nil
+}
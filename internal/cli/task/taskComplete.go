@@ -5,55 +5,118 @@ import (
 	"fmt"
 
 	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/cli/offline"
 	"github.com/clintjedwards/todo/proto"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
 var CmdTaskComplete = &cobra.Command{
-	Use:     "complete <id>",
-	Short:   "Mark a task as complete",
-	Example: `$ todo complete 62arz`,
-	RunE:    taskComplete,
-	Args:    cobra.ExactArgs(1),
+	Use:   "complete <id>...",
+	Short: "Mark tasks as complete",
+	Long: `Mark tasks as complete. Completing a task also completes all of its children.
+
+Tasks can be given by ID or chosen with --filter. All tasks are completed in a single round trip and a task that can't
+be completed doesn't stop the others.`,
+	Example: `$ todo complete 62arz
+$ todo complete 62arz 7bq1x k3m9d
+$ todo complete 62arz -m "Paid by check"
+$ todo complete --filter subtree=62arz`,
+	RunE:              taskComplete,
+	ValidArgsFunction: cl.CompleteTaskIDs,
 }
 
-func taskComplete(_ *cobra.Command, args []string) error {
-	id := args[0]
+func init() {
+	CmdTaskComplete.Flags().StringSlice("filter", []string{}, filterFlagHelp)
+	CmdTaskComplete.Flags().StringP("note", "m", "", "Note on why the task was completed; given to its children too")
+}
 
+func taskComplete(cmd *cobra.Command, args []string) error {
 	cl.State.Fmt.Print("Completing Task")
 
-	conn, err := cl.State.Connect()
+	filterValues, err := cmd.Flags().GetStringSlice("filter")
 	if err != nil {
-		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not complete task: %v", err))
 		cl.State.Fmt.Finish()
 		return err
 	}
 
-	client := proto.NewTodoClient(conn)
+	filter, err := parseFilter(filterValues)
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not complete task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	note, err := cmd.Flags().GetString("note")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not complete task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if (len(args) == 0) == (filter == nil) {
+		err := fmt.Errorf("either task ids or --filter are required, but not both")
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not complete task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
 
-	resp, err := client.GetTask(context.Background(), &proto.GetTaskRequest{
-		Id: id,
-	})
+	conn, err := cl.State.Connect()
 	if err != nil {
-		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get task: %v", err))
+		cl.State.Fmt.PrintErr(err)
 		cl.State.Fmt.Finish()
 		return err
 	}
 
-	_, err = client.UpdateTask(context.Background(), &proto.UpdateTaskRequest{
-		Id:          id,
-		Title:       resp.Task.Title,
-		Description: resp.Task.Description,
-		Parent:      resp.Task.Parent,
-		State:       proto.UpdateTaskRequest_COMPLETED,
-	})
+	client := proto.NewTodoClient(conn)
+
+	request := &proto.BatchUpdateTasksRequest{
+		Ids:    args,
+		Filter: filter,
+		State:  proto.UpdateTaskRequest_COMPLETED.Enum(),
+	}
+	if note != "" {
+		request.CompletionNote = &note
+	}
+
+	replica := openReplica()
+	if replica != nil {
+		defer replica.Close()
+	}
+
+	pushQueued(client, replica)
+
+	for i := range request.Ids {
+		request.Ids[i] = serverID(replica, request.Ids[i])
+	}
+
+	resp, err := client.BatchUpdateTasks(context.Background(), request)
 	if err != nil {
+		// Filters are worked out by the server, so only tasks given by ID can be completed offline.
+		if offline.Unreachable(err) && replica != nil && filter == nil {
+			updates := []*proto.UpdateTaskRequest{}
+			for _, id := range request.Ids {
+				updates = append(updates, &proto.UpdateTaskRequest{
+					Id:             id,
+					State:          request.State,
+					CompletionNote: request.CompletionNote,
+				})
+			}
+			return queueUpdates(replica, "completion", updates...)
+		}
 		cl.State.Fmt.PrintErr(fmt.Sprintf("could not complete task: %v", err))
 		cl.State.Fmt.Finish()
 		return err
 	}
-	cl.State.Fmt.PrintSuccess(fmt.Sprintf("Completed task: %s", color.MagentaString(id)))
+
+	if len(resp.Results) == 1 && resp.Results[0].Error == "" && filter == nil {
+		cl.State.Fmt.PrintSuccess(fmt.Sprintf("Completed task: %s", color.MagentaString(resp.Results[0].Id)))
+		cl.State.Fmt.Finish()
+		return nil
+	}
+
+	err = printBatchResults("Completed", resp.Results)
 	cl.State.Fmt.Finish()
-	return nil
+	return err
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/cli/offline"
 	"github.com/clintjedwards/todo/proto"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -24,6 +25,7 @@ $ todo create "New Task" --description="my new task"
 func init() {
 	CmdTaskCreate.Flags().StringP("description", "d", "", "Description about task")
 	CmdTaskCreate.Flags().StringP("parent", "p", "", "Link this task as the child of another task")
+	_ = CmdTaskCreate.RegisterFlagCompletionFunc("parent", cl.CompleteTaskIDFlag)
 }
 
 func taskCreate(cmd *cobra.Command, args []string) error {
@@ -54,12 +56,24 @@ func taskCreate(cmd *cobra.Command, args []string) error {
 
 	client := proto.NewTodoClient(conn)
 
-	resp, err := client.CreateTask(context.Background(), &proto.CreateTaskRequest{
+	replica := openReplica()
+	if replica != nil {
+		defer replica.Close()
+	}
+
+	pushQueued(client, replica)
+
+	request := &proto.CreateTaskRequest{
 		Title:       title,
 		Description: description,
-		Parent:      parent,
-	})
+		Parent:      serverID(replica, parent),
+	}
+
+	resp, err := client.CreateTask(context.Background(), request)
 	if err != nil {
+		if offline.Unreachable(err) && replica != nil {
+			return queueCreate(replica, request)
+		}
 		cl.State.Fmt.PrintErr(fmt.Sprintf("could not create task: %v", err))
 		cl.State.Fmt.Finish()
 		return err
@@ -68,3 +82,18 @@ func taskCreate(cmd *cobra.Command, args []string) error {
 	cl.State.Fmt.Finish()
 	return nil
 }
+
+// queueCreate queues a task to be created once the server can be reached again.
+func queueCreate(replica *offline.Replica, request *proto.CreateTaskRequest) error {
+	id, err := replica.QueueCreate(request)
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not create task: server unreachable and could not queue task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	cl.State.Fmt.PrintSuccess(fmt.Sprintf("Server unreachable; queued task to be created: [%s] %s",
+		color.MagentaString(id), "\""+color.BlueString(request.Title)+"\""))
+	cl.State.Fmt.Finish()
+	return nil
+}
@@ -12,20 +12,26 @@ import (
 )
 
 var CmdTaskDelete = &cobra.Command{
-	Use:     "delete <id>",
-	Short:   "Delete a new task",
-	Example: `$ todo delete 62arz`,
-	RunE:    taskDelete,
-	Args:    cobra.ExactArgs(1),
+	Use:   "delete <id>...",
+	Short: "Delete tasks",
+	Long: `Delete tasks along with all of their children, their comments and their attachments. A delete can be undone
+with 'todo undo', which brings all of them back.
+
+Tasks can be given by ID or chosen with --filter. All tasks are deleted in a single round trip and a task that can't
+be deleted doesn't stop the others.`,
+	Example: `$ todo delete 62arz
+$ todo delete 62arz 7bq1x k3m9d
+$ todo delete --filter state=completed,created-before=2024-01-01`,
+	RunE:              taskDelete,
+	ValidArgsFunction: cl.CompleteTaskIDs,
 }
 
 func init() {
 	CmdTaskDelete.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+	CmdTaskDelete.Flags().StringSlice("filter", []string{}, filterFlagHelp)
 }
 
 func taskDelete(cmd *cobra.Command, args []string) error {
-	id := args[0]
-
 	force, err := cmd.Flags().GetBool("force")
 	if err != nil {
 		cl.State.Fmt.PrintErr(fmt.Sprintf("could not delete tasks: %v", err))
@@ -33,6 +39,102 @@ func taskDelete(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	filterValues, err := cmd.Flags().GetStringSlice("filter")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not delete tasks: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	filter, err := parseFilter(filterValues)
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not delete tasks: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if (len(args) == 0) == (filter == nil) {
+		err := fmt.Errorf("either task ids or --filter are required, but not both")
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not delete tasks: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if len(args) == 1 && filter == nil {
+		return deleteSingleTask(args[0], force)
+	}
+
+	cl.State.Fmt.Print("Deleting Tasks")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	if !force {
+		// Show what would be deleted first since a filter can match more than expected.
+		preview, err := client.BatchDeleteTasks(context.Background(), &proto.BatchDeleteTasksRequest{
+			Ids:    args,
+			Filter: filter,
+			DryRun: true,
+		})
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not delete tasks: %v", err))
+			cl.State.Fmt.Finish()
+			return err
+		}
+		cl.State.Fmt.Finish()
+
+		affected := []string{}
+		for _, result := range preview.Results {
+			affected = append(affected, result.AffectedIds...)
+		}
+
+		if len(affected) == 0 {
+			cl.State.NewFormatter()
+			err := printBatchResults("Deleted", preview.Results)
+			cl.State.Fmt.Finish()
+			return err
+		}
+
+		fmt.Printf("%s\n", color.YellowString("[Caution] The following %d tasks, including children, will be deleted (undo with 'todo undo'):",
+			len(affected)))
+		fmt.Printf("  %s\n", strings.Join(affected, ", "))
+
+		var input string
+		fmt.Print("Please type 'yes' to confirm: ")
+		fmt.Scanln(&input)
+		if !strings.EqualFold(input, "yes") {
+			err := fmt.Errorf("deletion cancelled")
+			cl.State.NewFormatter()
+			cl.State.Fmt.PrintErr(err)
+			cl.State.Fmt.Finish()
+			return err
+		}
+
+		cl.State.NewFormatter()
+	}
+
+	resp, err := client.BatchDeleteTasks(context.Background(), &proto.BatchDeleteTasksRequest{
+		Ids:    args,
+		Filter: filter,
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not delete tasks: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	err = printBatchResults("Deleted", resp.Results)
+	cl.State.Fmt.Finish()
+	return err
+}
+
+func deleteSingleTask(id string, force bool) error {
 	cl.State.Fmt.Print("Deleting Task")
 	if !force {
 		cl.State.Fmt.Finish()
@@ -40,7 +142,7 @@ func taskDelete(cmd *cobra.Command, args []string) error {
 		var input string
 
 		for {
-			fmt.Printf("%s\n", color.YellowString("[Caution] Deleting a task will also delete all it's children permanently."))
+			fmt.Printf("%s\n", color.YellowString("[Caution] Deleting a task will also delete all it's children. Use 'todo undo' to bring them back."))
 			fmt.Print("Please type the ID of the task to confirm: ")
 			fmt.Scanln(&input)
 			if strings.EqualFold(input, id) {
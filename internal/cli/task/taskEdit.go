@@ -0,0 +1,276 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+)
+
+var CmdTaskEdit = &cobra.Command{
+	Use:   "edit <id>",
+	Short: "Edit a task in your editor",
+	Long: `Edit a task in your editor.
+
+The task is opened in $VISUAL or $EDITOR (vi if neither is set) as a Markdown document. The title, state and parent
+sit in a front matter block between two --- lines and everything after it is the description. Save and quit to apply
+the changes; only the fields that changed are updated.
+
+If the task was changed by someone else while it was open the update is refused rather than overwriting their change.
+Edits that can't be applied are kept in a file so they aren't lost.`,
+	Example: `$ todo edit 62arz
+$ EDITOR="code --wait" todo edit 62arz`,
+	RunE:              taskEdit,
+	ValidArgsFunction: cl.CompleteTaskID,
+	Args:              cobra.ExactArgs(1),
+}
+
+// editFrontMatter holds the fields of a task that are edited in the front matter of the document.
+type editFrontMatter struct {
+	Title  string `yaml:"title"`
+	State  string `yaml:"state"`
+	Parent string `yaml:"parent"`
+}
+
+const frontMatterDelimiter = "---"
+
+func taskEdit(_ *cobra.Command, args []string) error {
+	cl.State.Fmt.Print("Getting Task Details")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.GetTask(context.Background(), &proto.GetTaskRequest{
+		Id: args[0],
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+	task := resp.Task
+
+	original := editFrontMatter{
+		Title:  task.Title,
+		State:  strings.ToLower(task.State.String()),
+		Parent: task.Parent,
+	}
+
+	document, err := encodeEditDocument(task.Id, original, task.Description)
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not edit task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	file, err := os.CreateTemp("", fmt.Sprintf("todo-%s-*.md", task.Id))
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not edit task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+	path := file.Name()
+
+	_, err = file.Write(document)
+	file.Close()
+	if err != nil {
+		os.Remove(path)
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not edit task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	// The spinner writes to the terminal on its own so it has to be stopped while the editor has the screen.
+	cl.State.Fmt.Finish()
+
+	err = runEditor(path)
+	cl.State.NewFormatter()
+	if err != nil {
+		os.Remove(path)
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not edit task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		os.Remove(path)
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not edit task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	request, err := editRequest(task, original, edited)
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not edit task: %v; your edits are kept in %s", err, path))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if request == nil {
+		os.Remove(path)
+		cl.State.Fmt.PrintSuccess("No changes made")
+		cl.State.Fmt.Finish()
+		return nil
+	}
+
+	cl.State.Fmt.Print("Updating Task")
+
+	updated, err := client.UpdateTask(context.Background(), request)
+	if err != nil {
+		if status.Code(err) == codes.Aborted {
+			err = fmt.Errorf("task was changed by someone else while it was being edited")
+		}
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not update task: %v; your edits are kept in %s", err, path))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	os.Remove(path)
+	cl.State.Fmt.PrintSuccess(fmt.Sprintf("Updated task: %s (version %d)", color.MagentaString(updated.Task.Id),
+		updated.Task.Version))
+	cl.State.Fmt.Finish()
+	return nil
+}
+
+// encodeEditDocument writes out a task as the front matter and Markdown document that is handed to the editor.
+func encodeEditDocument(id string, fields editFrontMatter, description string) ([]byte, error) {
+	frontMatter, err := yaml.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var document bytes.Buffer
+	fmt.Fprintln(&document, frontMatterDelimiter)
+	fmt.Fprintf(&document, "# Editing task %s. State is one of unresolved or completed and an empty parent\n", id)
+	fmt.Fprintln(&document, "# makes it a top level task. The description goes below the closing ---.")
+	document.Write(frontMatter)
+	fmt.Fprintln(&document, frontMatterDelimiter)
+	document.WriteString(description)
+	if description != "" && !strings.HasSuffix(description, "\n") {
+		document.WriteString("\n")
+	}
+
+	return document.Bytes(), nil
+}
+
+// decodeEditDocument splits a document written by encodeEditDocument back into its front matter and description.
+func decodeEditDocument(document []byte) (editFrontMatter, string, error) {
+	lines := strings.SplitAfter(string(document), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != frontMatterDelimiter {
+		return editFrontMatter{}, "", errors.New("document must start with a --- line")
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) != frontMatterDelimiter {
+			continue
+		}
+
+		fields := editFrontMatter{}
+		decoder := yaml.NewDecoder(strings.NewReader(strings.Join(lines[1:i], "")))
+		decoder.KnownFields(true)
+		err := decoder.Decode(&fields)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return editFrontMatter{}, "", fmt.Errorf("could not read front matter: %w", err)
+		}
+
+		return fields, strings.Join(lines[i+1:], ""), nil
+	}
+
+	return editFrontMatter{}, "", errors.New("front matter must end with a --- line")
+}
+
+// editRequest works out the update needed to turn the task into the edited document. It returns nil if nothing
+// changed.
+func editRequest(task *proto.Task, original editFrontMatter, document []byte) (*proto.UpdateTaskRequest, error) {
+	edited, description, err := decodeEditDocument(document)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &proto.UpdateTaskRequest{
+		Id:              task.Id,
+		ExpectedVersion: &task.Version,
+	}
+	changed := false
+
+	if edited.Title != original.Title {
+		if strings.TrimSpace(edited.Title) == "" {
+			return nil, errors.New("title cannot be empty")
+		}
+		request.Title = &edited.Title
+		changed = true
+	}
+
+	if edited.State != original.State {
+		state, exists := proto.UpdateTaskRequest_TaskState_value[strings.ToUpper(edited.State)]
+		if !exists {
+			return nil, fmt.Errorf("state %q not recognized; should be one of unresolved or completed", edited.State)
+		}
+		request.State = proto.UpdateTaskRequest_TaskState(state).Enum()
+		changed = true
+	}
+
+	if edited.Parent != original.Parent {
+		request.Parent = &edited.Parent
+		changed = true
+	}
+
+	// Editors usually end files with a newline whether or not it was there before, so trailing whitespace is never
+	// counted as a change.
+	description = strings.TrimRight(description, " \t\n")
+	if description != strings.TrimRight(task.Description, " \t\n") {
+		request.Description = &description
+		changed = true
+	}
+
+	if !changed {
+		return nil, nil
+	}
+
+	return request, nil
+}
+
+// runEditor opens the file given in the user's editor and waits for them to close it.
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// Editors are often set along with arguments, such as "code --wait".
+	args := strings.Fields(editor)
+
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("editor %q failed: %w", editor, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/clintjedwards/todo/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestDecodeEditDocument(t *testing.T) {
+	tests := []struct {
+		name        string
+		document    string
+		fields      editFrontMatter
+		description string
+		err         bool
+	}{
+		{
+			name:        "front matter and description",
+			document:    "---\n# comment\ntitle: Pay bills\nstate: unresolved\nparent: abc\n---\nBefore Friday.\n",
+			fields:      editFrontMatter{Title: "Pay bills", State: "unresolved", Parent: "abc"},
+			description: "Before Friday.\n",
+		},
+		{
+			name:     "empty front matter",
+			document: "---\n---\n",
+		},
+		{
+			name:        "description containing the delimiter",
+			document:    "---\ntitle: a\n---\nfirst\n---\nsecond\n",
+			fields:      editFrontMatter{Title: "a"},
+			description: "first\n---\nsecond\n",
+		},
+		{
+			name:        "delimiters with surrounding whitespace",
+			document:    "--- \ntitle: a\n  ---\r\nb",
+			fields:      editFrontMatter{Title: "a"},
+			description: "b",
+		},
+		{name: "no opening delimiter", document: "title: a\n---\n", err: true},
+		{name: "no closing delimiter", document: "---\ntitle: a\n", err: true},
+		{name: "empty document", document: "", err: true},
+		{name: "unknown field", document: "---\ntitel: a\n---\n", err: true},
+		{name: "invalid yaml", document: "---\ntitle: [a\n---\n", err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fields, description, err := decodeEditDocument([]byte(tc.document))
+			if (err != nil) != tc.err {
+				t.Fatalf("expected error to be %v; got %v", tc.err, err)
+			}
+
+			if diff := cmp.Diff(tc.fields, fields); diff != "" {
+				t.Errorf("unexpected front matter (-want +got):\n%s", diff)
+			}
+
+			if description != tc.description {
+				t.Errorf("expected description %q; got %q", tc.description, description)
+			}
+		})
+	}
+}
+
+func TestEncodeEditDocumentRoundTrips(t *testing.T) {
+	fields := editFrontMatter{Title: "title: with a colon", State: "completed", Parent: "abc"}
+
+	document, err := encodeEditDocument("xyz", fields, "line one\n---\nline two")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, description, err := decodeEditDocument(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(fields, decoded); diff != "" {
+		t.Errorf("unexpected front matter (-want +got):\n%s", diff)
+	}
+
+	if description != "line one\n---\nline two\n" {
+		t.Errorf("unexpected description %q", description)
+	}
+}
+
+func TestEditRequest(t *testing.T) {
+	task := &proto.Task{Id: "xyz", Title: "title", Description: "description\n", Parent: "abc", Version: 4}
+	original := editFrontMatter{Title: "title", State: "unresolved", Parent: "abc"}
+
+	document := func(frontMatter, description string) string {
+		return "---\n" + frontMatter + "---\n" + description
+	}
+	unchanged := "title: title\nstate: unresolved\nparent: abc\n"
+
+	// request is an update of the task carrying the version it was edited at.
+	request := func(update *proto.UpdateTaskRequest) *proto.UpdateTaskRequest {
+		update.Id = task.Id
+		update.ExpectedVersion = &task.Version
+		return update
+	}
+
+	tests := []struct {
+		name     string
+		document string
+		request  *proto.UpdateTaskRequest
+		err      bool
+	}{
+		{name: "unchanged", document: document(unchanged, "description\n")},
+		{name: "only trailing whitespace changed", document: document(unchanged, "description \n\n")},
+		{
+			name:     "title",
+			document: document("title: new\nstate: unresolved\nparent: abc\n", "description\n"),
+			request:  request(&proto.UpdateTaskRequest{Title: ptr("new")}),
+		},
+		{
+			name:     "empty title",
+			document: document("title: \" \"\nstate: unresolved\nparent: abc\n", "description\n"),
+			err:      true,
+		},
+		{
+			name:     "state",
+			document: document("title: title\nstate: Completed\nparent: abc\n", "description\n"),
+			request:  request(&proto.UpdateTaskRequest{State: proto.UpdateTaskRequest_COMPLETED.Enum()}),
+		},
+		{
+			name:     "unknown state",
+			document: document("title: title\nstate: done\nparent: abc\n", "description\n"),
+			err:      true,
+		},
+		{
+			name:     "parent removed",
+			document: document("title: title\nstate: unresolved\n", "description\n"),
+			request:  request(&proto.UpdateTaskRequest{Parent: ptr("")}),
+		},
+		{
+			name:     "description",
+			document: document(unchanged, "new description\n\n"),
+			request:  request(&proto.UpdateTaskRequest{Description: ptr("new description")}),
+		},
+		{
+			name:     "several fields",
+			document: document("title: new\nstate: unresolved\nparent: def\n", ""),
+			request: request(&proto.UpdateTaskRequest{
+				Title:       ptr("new"),
+				Parent:      ptr("def"),
+				Description: ptr(""),
+			}),
+		},
+		{name: "malformed document", document: unchanged, err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := editRequest(task, original, []byte(tc.document))
+			if (err != nil) != tc.err {
+				t.Fatalf("expected error to be %v; got %v", tc.err, err)
+			}
+
+			if diff := cmp.Diff(tc.request, got, protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected request (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
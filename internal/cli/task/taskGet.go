@@ -8,17 +8,23 @@ import (
 
 	"github.com/clintjedwards/todo/internal/cli/cl"
 	"github.com/clintjedwards/todo/internal/cli/format"
+	"github.com/clintjedwards/todo/internal/cli/offline"
 	"github.com/clintjedwards/todo/proto"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
 var CmdTaskGet = &cobra.Command{
-	Use:     "get <id>",
-	Short:   "Describe a task",
-	Example: `$ todo 62arz`,
-	RunE:    taskGet,
-	Args:    cobra.ExactArgs(1),
+	Use:               "get <id>",
+	Short:             "Describe a task",
+	Example:           `$ todo 62arz`,
+	RunE:              taskGet,
+	ValidArgsFunction: cl.CompleteTaskID,
+	Args:              cobra.ExactArgs(1),
+}
+
+func init() {
+	cl.AddOutputFlags(CmdTaskGet)
 }
 
 func taskGet(_ *cobra.Command, args []string) error {
@@ -35,16 +41,84 @@ func taskGet(_ *cobra.Command, args []string) error {
 
 	client := proto.NewTodoClient(conn)
 
+	replica := openReplica()
+	if replica != nil {
+		defer replica.Close()
+	}
+
+	pushQueued(client, replica)
+
+	id = serverID(replica, id)
+
 	resp, err := client.GetTask(context.Background(), &proto.GetTaskRequest{
 		Id: id,
 	})
+	if offline.Unreachable(err) && replica != nil {
+		return getOfflineTask(replica, id, err)
+	}
 	if err != nil {
 		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get task: %v", err))
 		cl.State.Fmt.Finish()
 		return err
 	}
 
-	cl.State.Fmt.Println(formatTaskInfo(resp.Task))
+	comments, err := client.ListTaskComments(context.Background(), &proto.ListTaskCommentsRequest{
+		TaskId: resp.Task.Id,
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get task comments: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if cl.State.WritesData() {
+		task := format.NewTask(resp.Task)
+		task.Comments = format.NewTaskComments(comments.Comments)
+
+		err = cl.State.WriteData(task)
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not get task: %v", err))
+			return err
+		}
+		return nil
+	}
+
+	cl.State.Fmt.Println(formatTaskInfo(resp.Task, comments.Comments))
+	cl.State.Fmt.Finish()
+	return nil
+}
+
+// getOfflineTask describes the task as it is in the local copy, after noting how old that is. Comments aren't kept in
+// the copy so none are shown. The error given, from trying the server, is returned if there is no copy to show.
+func getOfflineTask(replica *offline.Replica, id string, serverErr error) error {
+	notice, err := offlineNotice(replica)
+	if err != nil {
+		err = fmt.Errorf("%w; %v", serverErr, err)
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	task, err := replica.GetTask(id)
+	if err != nil {
+		err = fmt.Errorf("%w; %v", serverErr, err)
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	printOfflineNotice(notice)
+
+	if cl.State.WritesData() {
+		err = cl.State.WriteData(format.NewTask(task))
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not get task: %v", err))
+			return err
+		}
+		return nil
+	}
+
+	cl.State.Fmt.Println(formatTaskInfo(task, nil))
 	cl.State.Fmt.Finish()
 	return nil
 }
@@ -57,26 +131,68 @@ type data struct {
 	Created     string
 	Modified    string
 	Parent      string
+	ScheduledBy string
+	Version     int64
+	Completed   string
+	Note        string
+	Comments    []comment
+}
+
+type comment struct {
+	Author  string
+	Created string
+	Text    string
 }
 
-func formatTaskInfo(task *proto.Task) string {
+func formatTaskInfo(task *proto.Task, comments []*proto.TaskComment) string {
 	data := data{
 		ID:          color.MagentaString(task.Id),
 		Title:       color.BlueString(task.Title),
-		Description: task.Description,
+		Description: format.Markdown(task.Description, !cl.State.Config.NoColor),
 		State: format.ColorizeTaskState(
 			format.NormalizeEnumValue(task.State.String(), "Unknown"),
 		),
 		Created:  format.UnixMilli(task.Created, "Unknown", cl.State.Config.Detail),
 		Modified: format.UnixMilli(task.Modified, "Unknown", cl.State.Config.Detail),
 		Parent:   task.Parent,
+		Version:  task.Version,
+		Note:     task.CompletionNote,
 	}
 
-	const formatTmpl = `Task [{{.ID}}] :: {{.Title}} :: {{.State}}
+	if task.CompletedAt != 0 {
+		data.Completed = format.UnixMilli(task.CompletedAt, "Unknown", cl.State.Config.Detail)
+	}
+
+	for _, c := range comments {
+		author := c.Author
+		if author == "" {
+			author = "unknown"
+		}
 
-  {{if .Description}}{{.Description}}{{- end}}
+		data.Comments = append(data.Comments, comment{
+			Author:  color.CyanString(author),
+			Created: format.UnixMilli(c.Created, "Unknown", cl.State.Config.Detail),
+			Text:    c.Text,
+		})
+	}
+
+	if task.ScheduledTaskId != "" {
+		data.ScheduledBy = fmt.Sprintf("%s (due %s)", color.MagentaString(task.ScheduledTaskId),
+			format.UnixMilli(task.ScheduledFor, "Unknown", cl.State.Config.Detail))
+	}
+
+	const formatTmpl = `Task [{{.ID}}] :: {{.Title}} :: {{.State}}
 
-Created {{.Created}}`
+{{if .Description}}{{.Description}}{{- end}}
+{{if .Comments}}
+Comments:
+{{- range .Comments}}
+  {{.Author}} {{.Created}}: {{.Text}}
+{{- end}}
+{{end}}
+Created {{.Created}} :: Version {{.Version}}{{if .Completed}}
+Completed {{.Completed}}{{if .Note}} :: {{.Note}}{{end}}{{end}}{{if .ScheduledBy}}
+Scheduled by {{.ScheduledBy}}{{end}}`
 
 	var tpl bytes.Buffer
 	t := template.Must(template.New("tmp").Parse(formatTmpl))
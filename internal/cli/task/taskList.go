@@ -5,22 +5,33 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/cli/format"
+	"github.com/clintjedwards/todo/internal/cli/offline"
 	"github.com/clintjedwards/todo/proto"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
 var CmdTaskList = &cobra.Command{
-	Use:     "list",
-	Short:   "List all tasks",
-	Example: `$ todo list`,
-	RunE:    taskList,
+	Use:   "list",
+	Short: "List all tasks",
+	Long: `List all tasks as a tree, with each task's children beneath it.
+
+With --format json, jsonl or yaml the tasks are written as a flat list instead, each naming its parent.`,
+	Example: `$ todo list
+$ todo list --all --format json
+$ todo list --all --include-archived
+$ todo list --template '{{.ID}} {{.State}} {{.Title}}'`,
+	RunE: taskList,
 }
 
 func init() {
+	cl.AddOutputFlags(CmdTaskList)
 	CmdTaskList.Flags().BoolP("all", "a", false, "Show normally hidden tasks like those that have been completed")
+	CmdTaskList.Flags().Bool("include-archived", false, "Also show tasks that have been archived")
 }
 
 func taskList(cmd *cobra.Command, _ []string) error {
@@ -33,6 +44,13 @@ func taskList(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	includeArchived, err := cmd.Flags().GetBool("include-archived")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not list tasks: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
 	conn, err := cl.State.Connect()
 	if err != nil {
 		cl.State.Fmt.PrintErr(err)
@@ -42,23 +60,77 @@ func taskList(cmd *cobra.Command, _ []string) error {
 
 	client := proto.NewTodoClient(conn)
 
-	resp, err := client.ListTasks(context.Background(), &proto.ListTasksRequest{
-		Offset:           0,
-		Limit:            0,
-		ExcludeCompleted: !all,
+	replica := openReplica()
+	if replica != nil {
+		defer replica.Close()
+	}
+
+	pushQueued(client, replica)
+
+	// Completed tasks are always fetched so parents can show how many of their children are done; they're only
+	// left out of what's shown. Every page is fetched since the server only returns so many tasks at once.
+	allTasks, err := cl.ListAllTasks(context.Background(), client, &proto.ListTasksRequest{
+		ExcludeCompleted: false,
+		IncludeArchived:  includeArchived,
 	})
+	switch {
+	case err == nil && replica != nil && !includeArchived:
+		// Every task is fetched anyway, so this is a good time to refresh the local copy. It's only a fallback, so
+		// failing to is no reason to fail the command.
+		_ = replica.Refresh(allTasks, time.Now())
+	case offline.Unreachable(err) && replica != nil:
+		allTasks, err = listOfflineTasks(replica, err)
+	}
 	if err != nil {
 		cl.State.Fmt.PrintErr(fmt.Sprintf("could not list task: %v", err))
 		cl.State.Fmt.Finish()
 		return err
 	}
-	cl.State.Fmt.Finish()
 
-	fmt.Println(stringifyTasks(resp.Tasks))
+	progress := taskProgress(allTasks)
+
+	tasks := allTasks
+	if !all {
+		tasks = []*proto.Task{}
+		for _, task := range allTasks {
+			if task.State != proto.Task_COMPLETED {
+				tasks = append(tasks, task)
+			}
+		}
+	}
+
+	if cl.State.WritesData() {
+		err = cl.State.WriteData(format.NewTasks(tasks))
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not list tasks: %v", err))
+			return err
+		}
+		return nil
+	}
+
+	cl.State.Fmt.Println(stringifyTasks(tasks, progress))
+	cl.State.Fmt.Finish()
 
 	return nil
 }
 
+// listOfflineTasks returns the tasks in the local copy in place of the server's, after noting how old they are. The
+// error given, from trying the server, is returned if there is no copy to show.
+func listOfflineTasks(replica *offline.Replica, serverErr error) ([]*proto.Task, error) {
+	notice, err := offlineNotice(replica)
+	if err != nil {
+		return nil, fmt.Errorf("%w; %v", serverErr, err)
+	}
+
+	tasks, err := replica.ListTasks()
+	if err != nil {
+		return nil, fmt.Errorf("%w; could not read local copy of tasks: %v", serverErr, err)
+	}
+
+	printOfflineNotice(notice)
+	return tasks, nil
+}
+
 type taskNode struct {
 	task     *proto.Task
 	children map[string]struct{}
@@ -124,11 +196,46 @@ func stringifyTask(task *proto.Task) string {
 	}
 
 	taskStr := fmt.Sprintf("[%s] %s", id, title)
+	if task.Archived != 0 {
+		taskStr += faint(" (archived)")
+	}
 
 	return taskStr
 }
 
-func stringifyTasks(tasks []*proto.Task) string {
+// progress is how many of the tasks beneath a task, at any depth, are completed.
+type progress struct {
+	completed int64
+	total     int64
+}
+
+// taskProgress works out the progress of every task that has children.
+func taskProgress(tasks []*proto.Task) map[string]progress {
+	parents := map[string]string{}
+	for _, task := range tasks {
+		parents[task.Id] = task.Parent
+	}
+
+	progressMap := map[string]progress{}
+	for _, task := range tasks {
+		seen := map[string]bool{}
+		for parent := task.Parent; parent != "" && !seen[parent]; parent = parents[parent] {
+			// Guards against looping forever should the tree ever contain a cycle.
+			seen[parent] = true
+
+			p := progressMap[parent]
+			p.total++
+			if task.State == proto.Task_COMPLETED {
+				p.completed++
+			}
+			progressMap[parent] = p
+		}
+	}
+
+	return progressMap
+}
+
+func stringifyTasks(tasks []*proto.Task, progress map[string]progress) string {
 	taskTree, keys := toTaskTree(tasks)
 
 	// We opt to not use strings.Builder here because to do fancy styling we
@@ -156,7 +263,7 @@ func stringifyTasks(tasks []*proto.Task) string {
 		}
 
 		// Recursively process all children of that top level task.
-		stringifyTaskTreeBranch(&sb, taskTree, taskID, 0, firstNode)
+		stringifyTaskTreeBranch(&sb, taskTree, progress, taskID, 0, firstNode)
 
 		if firstNode {
 			firstNode = false
@@ -172,7 +279,9 @@ func stringifyTasks(tasks []*proto.Task) string {
 	return strings.Join(sb, "")
 }
 
-func stringifyTaskTreeBranch(sb *[]string, taskTree map[string]taskNode, id string, lvl int, firstNode bool) {
+func stringifyTaskTreeBranch(sb *[]string, taskTree map[string]taskNode, progress map[string]progress, id string,
+	lvl int, firstNode bool,
+) {
 	taskString := ""
 
 	if firstNode {
@@ -188,7 +297,11 @@ func stringifyTaskTreeBranch(sb *[]string, taskTree map[string]taskNode, id stri
 
 	task := taskTree[id].task
 	children := taskTree[id].children
-	taskString += stringifyTask(task) + "\n"
+	taskString += stringifyTask(task)
+	if p, exists := progress[task.Id]; exists {
+		taskString += " " + formatProgress(p.completed, p.total)
+	}
+	taskString += "\n"
 
 	*sb = append(*sb, taskString)
 
@@ -205,6 +318,6 @@ func stringifyTaskTreeBranch(sb *[]string, taskTree map[string]taskNode, id stri
 	sort.Strings(sortedChildren)
 
 	for _, childID := range sortedChildren {
-		stringifyTaskTreeBranch(sb, taskTree, childID, lvl+1, false)
+		stringifyTaskTreeBranch(sb, taskTree, progress, childID, lvl+1, false)
 	}
 }
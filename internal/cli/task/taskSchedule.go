@@ -5,38 +5,92 @@ import (
 	"fmt"
 
 	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/cli/task/scheduled"
 	"github.com/clintjedwards/todo/proto"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
 var CmdTaskSchedule = &cobra.Command{
-	Use:   "schedule <title> <expression>",
+	Use:   "schedule [title] <expression>",
 	Short: "Schedule a new task",
 	Long: `Todo allows you to schedule a task that reoccurs. Useful for tasks that need to be done on some sort of schedule.
-For example, if I need to re-lube my bike chain every month I can schedule a task with the expression: "0 0 1 * * *".
+For example, if I need to re-lube my bike chain every month I can schedule a task with the expression: "monthly".
 
-The syntax for the expression is a subset of the cron syntax; you can find more about it here: https://github.com/clintjedwards/avail.
+Expressions can be written in any of the following; the type is detected from the expression unless given with --type.
+
+  avail    A six field subset of the cron syntax: minute hour day month weekday year.
+           You can find more about it here: https://github.com/clintjedwards/avail.
+           Ex: "0 9 * * 1-5 *"
+  cron     The standard five field cron syntax including names, steps and descriptors like @daily.
+           Ex: "30 8 1,15 * *"
+  rrule    An iCalendar (RFC 5545) recurrence rule.
+           Ex: "FREQ=MONTHLY;BYDAY=+2TU;BYHOUR=9;BYMINUTE=0"
+  natural  A plain English description.
+           Ex: "every 2nd tuesday at 9am", "every weekday at 17:30", "every 3 weeks on friday"
+
+Instead of following a calendar, a scheduled task can create its next task a set time after the previous one is
+completed. Use an expression like "every 3 days after completion" or pass --after-completion with a delay like
+"3 days" or "36h". Use --skip-if-open to stop calendar schedules creating a new task while the previous one is still
+unresolved.
+
+Expressions are evaluated in the time zone given with --tz or, without it, the server's default time zone. Schedules
+follow daylight saving changes: a time skipped when the clocks go forward fires just after the jump and a time repeated
+when they go back only fires once.
+
+A scheduled task can create a whole tree of tasks from a template each time it fires instead of a single task; see
+'todo template'. Pass the template's name or ID with --template. The title can then be left out and defaults to the
+template's name.
+
+You can check when an expression will fire before scheduling it with 'todo scheduled preview'.
 
 Scheduled tasks will automatically be created for you on the timeline that you set.`,
 	Example: `$ todo schedule "New Task" "0 0 1 * * *"
 $ todo schedule "New Task" "* * * * * *" --description="my new task"
+$ todo schedule "Water plants" "every other day at 8am"
+$ todo schedule "Pay rent" "FREQ=MONTHLY;BYMONTHDAY=-1" --type rrule
+$ todo schedule "Clean the fridge" "every 2 weeks after completion"
+$ todo schedule "Change air filter" "90 days" --after-completion
+$ todo schedule "Weekly review" "every friday at 4pm" --skip-if-open
+$ todo schedule "Take out the bins" "every tuesday at 7pm" --tz Europe/London
+$ todo schedule "on the 1st at 9am" --template "Monthly bills"
 `,
 	RunE: taskSchedule,
-	Args: cobra.ExactArgs(2),
+	Args: cobra.RangeArgs(1, 2),
 }
 
 func init() {
 	CmdTaskSchedule.Flags().StringP("description", "d", "", "Description about task")
 	CmdTaskSchedule.Flags().StringP("parent", "p", "", "Link this task as the child of another task")
+	_ = CmdTaskSchedule.RegisterFlagCompletionFunc("parent", cl.CompleteTaskIDFlag)
+	CmdTaskSchedule.Flags().StringP("type", "t", "auto", scheduled.ExpressionTypeFlagHelp)
+	CmdTaskSchedule.Flags().Bool("after-completion", false, scheduled.AfterCompletionFlagHelp)
+	CmdTaskSchedule.Flags().String("tz", "", scheduled.TimezoneFlagHelp)
+	CmdTaskSchedule.Flags().String("template", "", "Create the tasks in this template, by name or ID, instead of a single task")
+	CmdTaskSchedule.Flags().Bool("skip-if-open", false, "Don't create a new task while the previous one is still unresolved")
 }
 
 func taskSchedule(cmd *cobra.Command, args []string) error {
-	title := args[0]
-	expression := args[1]
-
 	cl.State.Fmt.Print("Creating Task")
 
+	templateName, err := cmd.Flags().GetString("template")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not schedule task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	// The title can only be left out when there's a template to take it from.
+	title, expression := "", args[len(args)-1]
+	if len(args) == 2 {
+		title = args[0]
+	} else if templateName == "" {
+		err := fmt.Errorf("a title and expression are required")
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not schedule task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
 	description, err := cmd.Flags().GetString("description")
 	if err != nil {
 		cl.State.Fmt.PrintErr(fmt.Sprintf("could not schedule task: %v", err))
@@ -51,6 +105,41 @@ func taskSchedule(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	typeFlag, err := cmd.Flags().GetString("type")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not schedule task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	expressionType, err := scheduled.ParseExpressionType(typeFlag)
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not schedule task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	afterCompletion, err := cmd.Flags().GetBool("after-completion")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not schedule task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	skipIfOpen, err := cmd.Flags().GetBool("skip-if-open")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not schedule task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	timezone, err := cmd.Flags().GetString("tz")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not schedule task: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
 	conn, err := cl.State.Connect()
 	if err != nil {
 		cl.State.Fmt.PrintErr(err)
@@ -61,16 +150,24 @@ func taskSchedule(cmd *cobra.Command, args []string) error {
 	client := proto.NewTodoClient(conn)
 
 	resp, err := client.CreateScheduledTask(context.Background(), &proto.CreateScheduledTaskRequest{
-		Title:       title,
-		Description: description,
-		Parent:      parent,
-		Expression:  expression,
+		Title:          title,
+		Description:    description,
+		Parent:         parent,
+		Expression:     expression,
+		ExpressionType: expressionType,
+		Recurrence:     scheduled.ParseRecurrence(afterCompletion),
+		SkipIfOpen:     skipIfOpen,
+		Timezone:       timezone,
+		Template:       templateName,
 	})
 	if err != nil {
 		cl.State.Fmt.PrintErr(fmt.Sprintf("could not schedule task: %v", err))
 		cl.State.Fmt.Finish()
 		return err
 	}
+	if title == "" {
+		title = templateName
+	}
 	cl.State.Fmt.PrintSuccess(fmt.Sprintf("Scheduled task: [%s] %s", color.MagentaString(resp.Id), "\""+color.BlueString(title)+"\""))
 	cl.State.Fmt.Finish()
 	return nil
@@ -0,0 +1,198 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/cli/format"
+	"github.com/clintjedwards/todo/internal/cli/task/scheduled"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var CmdTaskStats = &cobra.Command{
+	Use:   "stats",
+	Short: "Show how tasks are being completed",
+	Long: `Show how tasks are being completed: how many were completed each day and week, how long tasks take to
+complete on average, how long open tasks have been waiting and how far along each open tree of tasks is.`,
+	Example: `$ todo stats
+$ todo stats --days 30 --weeks 12
+$ todo stats --tz America/New_York`,
+	RunE: taskStats,
+}
+
+func init() {
+	cl.AddOutputFlags(CmdTaskStats)
+	CmdTaskStats.Flags().Int("days", 14, "Number of days, counting today, to chart completions for")
+	CmdTaskStats.Flags().Int("weeks", 8, "Number of weeks, counting this one, to chart completions for")
+	CmdTaskStats.Flags().String("tz", "", "IANA time zone to count days and weeks in; defaults to the server's")
+}
+
+// The widest a bar in any of the charts gets.
+const maxBarWidth = 40
+
+func taskStats(cmd *cobra.Command, _ []string) error {
+	days, err := cmd.Flags().GetInt("days")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get stats: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	weeks, err := cmd.Flags().GetInt("weeks")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get stats: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	timezone, err := cmd.Flags().GetString("tz")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get stats: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	cl.State.Fmt.Print("Collecting Stats")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.GetTaskStats(context.Background(), &proto.GetTaskStatsRequest{
+		Days:     int64(days),
+		Weeks:    int64(weeks),
+		Timezone: timezone,
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get stats: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if cl.State.WritesData() {
+		err = cl.State.WriteData(format.NewTaskStats(resp))
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not get stats: %v", err))
+			return err
+		}
+		return nil
+	}
+
+	// Periods are labeled in the time zone the server counted them in so the dates line up with the counts.
+	location, err := scheduled.DisplayLocation(resp.Timezone)
+	if err != nil {
+		location = time.Local
+	}
+
+	cl.State.Fmt.Println(formatTaskStats(resp, location))
+	cl.State.Fmt.Finish()
+	return nil
+}
+
+func formatTaskStats(stats *proto.GetTaskStatsResponse, location *time.Location) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Tasks: %s open, %s completed\n", color.YellowString("%d", stats.Open),
+		color.GreenString("%d", stats.Completed))
+
+	if stats.AverageTimeToComplete > 0 {
+		created := time.Now()
+		completed := created.Add(time.Duration(stats.AverageTimeToComplete) * time.Millisecond)
+		fmt.Fprintf(&b, "Average time to complete: %s\n",
+			strings.TrimSpace(humanize.RelTime(created, completed, "", "")))
+	}
+
+	b.WriteString("\nCompleted per day\n")
+	dayLabels, dayCounts := []string{}, []int64{}
+	for _, day := range stats.CompletedPerDay {
+		dayLabels = append(dayLabels, time.UnixMilli(day.Start).In(location).Format("Mon Jan 02"))
+		dayCounts = append(dayCounts, day.Count)
+	}
+	b.WriteString(barChart(dayLabels, dayCounts))
+
+	b.WriteString("\nCompleted per week\n")
+	weekLabels, weekCounts := []string{}, []int64{}
+	for _, week := range stats.CompletedPerWeek {
+		weekLabels = append(weekLabels, "Week of "+time.UnixMilli(week.Start).In(location).Format("Jan 02"))
+		weekCounts = append(weekCounts, week.Count)
+	}
+	b.WriteString(barChart(weekLabels, weekCounts))
+
+	b.WriteString("\nOpen tasks by age\n")
+	ageLabels, ageCounts := []string{}, []int64{}
+	for _, bucket := range stats.OpenByAge {
+		ageLabels = append(ageLabels, bucket.Label)
+		ageCounts = append(ageCounts, bucket.Count)
+	}
+	b.WriteString(barChart(ageLabels, ageCounts))
+
+	if len(stats.Progress) > 0 {
+		b.WriteString("\nProgress\n")
+		for _, progress := range stats.Progress {
+			fmt.Fprintf(&b, "  %s %s %s\n", progressBar(progress.Completed, progress.Total),
+				formatProgress(progress.Completed, progress.Total),
+				fmt.Sprintf("[%s] %s", color.YellowString(progress.Id), color.BlueString(progress.Title)))
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// barChart draws a horizontal bar for each count, scaled so the largest fills the chart.
+func barChart(labels []string, counts []int64) string {
+	var largest int64
+	labelWidth := 0
+	for i, count := range counts {
+		largest = max(largest, count)
+		labelWidth = max(labelWidth, len(labels[i]))
+	}
+
+	var b strings.Builder
+	for i, count := range counts {
+		width := 0
+		if largest > 0 {
+			width = int(count * maxBarWidth / largest)
+		}
+		if count > 0 && width == 0 {
+			width = 1
+		}
+
+		fmt.Fprintf(&b, "  %-*s %s %d\n", labelWidth, labels[i], color.BlueString(strings.Repeat("█", width)), count)
+	}
+
+	return b.String()
+}
+
+// progressBar draws a short bar filled in proportion to how many of the tasks given are completed.
+func progressBar(completed, total int64) string {
+	const width = 10
+
+	filled := 0
+	if total > 0 {
+		filled = int(completed * width / total)
+	}
+
+	faint := color.New(color.Faint).SprintFunc()
+	return color.GreenString(strings.Repeat("█", filled)) + faint(strings.Repeat("░", width-filled))
+}
+
+// formatProgress describes how many of a task's children are completed, ex: "3/7".
+func formatProgress(completed, total int64) string {
+	progress := fmt.Sprintf("%d/%d", completed, total)
+	if completed == total {
+		return color.GreenString(progress)
+	}
+
+	return progress
+}
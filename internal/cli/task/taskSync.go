@@ -0,0 +1,183 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/cli/offline"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var CmdTaskSync = &cobra.Command{
+	Use:   "sync",
+	Short: "Send changes made offline and refresh the local copy of tasks",
+	Long: `Send changes made offline and refresh the local copy of tasks.
+
+Offline mode is off unless turned on with the offline setting, ex. offline = true in the cli config or
+TODO_CLI_OFFLINE=true. With it on the cli keeps a local copy of the server's tasks, refreshed every time 'todo list'
+runs. While the server can't be reached 'todo list' and 'todo get' show that copy instead, marked with how old it is,
+and 'todo create', 'todo update' and 'todo complete' queue their changes in it. Tasks created offline are given IDs
+like local-1 until they're synced.
+
+Queued changes are sent by the first of those commands to reach the server again, or by running this one. A change to
+a task that was modified on the server after the change was made offline is dropped in favor of the server's version.
+
+Other commands, and updates chosen with --filter, always need the server.`,
+	Example: `$ todo sync`,
+	RunE:    taskSync,
+}
+
+func taskSync(_ *cobra.Command, _ []string) error {
+	cl.State.Fmt.Print("Syncing Tasks")
+
+	replica := openReplica()
+	if replica == nil {
+		err := errors.New("offline mode is turned off or the local copy of tasks could not be opened")
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not sync tasks: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+	defer replica.Close()
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	results, err := replica.Push(context.Background(), client)
+	printSyncResults(results)
+	if err != nil {
+		pending, _ := replica.Pending()
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not sync tasks: %v; %d changes still queued", err, pending))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	// The local copy is replaced wholesale, so every page has to be fetched for it to hold every task.
+	tasks, err := cl.ListAllTasks(context.Background(), client, &proto.ListTasksRequest{})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not refresh local copy of tasks: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	err = replica.Refresh(tasks, time.Now())
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not refresh local copy of tasks: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if len(results) == 0 {
+		cl.State.Fmt.PrintSuccess(fmt.Sprintf("Nothing queued; local copy has %d tasks", len(tasks)))
+	} else {
+		cl.State.Fmt.PrintSuccess(fmt.Sprintf("Sent %d queued changes; local copy has %d tasks", len(results),
+			len(tasks)))
+	}
+	cl.State.Fmt.Finish()
+	return nil
+}
+
+// openReplica opens the local copy of the server's tasks. It returns nil if offline mode is turned off or the copy
+// can't be opened, in which case commands carry on as if there were no copy.
+func openReplica() *offline.Replica {
+	if !cl.State.Config.Offline {
+		return nil
+	}
+
+	replica, err := offline.Open(cl.CacheFile(cl.State.Config.Host, "offline.db"))
+	if err != nil {
+		return nil
+	}
+
+	return replica
+}
+
+// pushQueued sends the changes queued while the server couldn't be reached. Any that can't be sent stay queued for
+// the next command to try again.
+func pushQueued(client proto.TodoClient, replica *offline.Replica) {
+	if replica == nil {
+		return
+	}
+
+	pending, err := replica.Pending()
+	if err != nil || pending == 0 {
+		return
+	}
+
+	results, _ := replica.Push(context.Background(), client)
+	printSyncResults(results)
+}
+
+// serverID returns the ID the server knows a task by, for tasks created offline that have since been synced.
+func serverID(replica *offline.Replica, id string) string {
+	if replica == nil {
+		return id
+	}
+
+	return replica.ServerID(id)
+}
+
+func printSyncResults(results []offline.Result) {
+	for _, result := range results {
+		switch {
+		case result.Conflict:
+			cl.State.Fmt.PrintErr(fmt.Sprintf("Conflict syncing %s: %v", result.Change, result.Err))
+		case result.Err != nil:
+			cl.State.Fmt.PrintErr(fmt.Sprintf("Could not sync %s: %v", result.Change, result.Err))
+		case strings.HasSuffix(result.Change, " "+result.ID):
+			cl.State.Fmt.PrintSuccess(fmt.Sprintf("Synced %s", result.Change))
+		default:
+			// Created tasks, and changes to them, only now have the IDs the server gave them.
+			cl.State.Fmt.PrintSuccess(fmt.Sprintf("Synced %s as %s", result.Change, color.MagentaString(result.ID)))
+		}
+	}
+}
+
+// offlineNotice describes the local copy of tasks being shown in place of the server's. It returns an error if
+// there is no copy to show.
+func offlineNotice(replica *offline.Replica) (string, error) {
+	synced, err := replica.Synced()
+	if err != nil {
+		return "", err
+	}
+
+	if synced.IsZero() {
+		return "", errors.New("no local copy of tasks has been made yet; run 'todo list' while the server can be reached")
+	}
+
+	notice := fmt.Sprintf("Server unreachable; showing tasks as of %s", humanize.Time(synced))
+
+	pending, err := replica.Pending()
+	if err != nil {
+		return "", err
+	}
+
+	if pending > 0 {
+		notice += fmt.Sprintf(" with %d changes waiting to sync", pending)
+	}
+
+	return notice, nil
+}
+
+// printOfflineNotice shows the notice for the local copy of tasks where it can't be mistaken for the data itself.
+func printOfflineNotice(notice string) {
+	if cl.State.WritesData() {
+		fmt.Fprintln(os.Stderr, notice)
+		return
+	}
+
+	cl.State.Fmt.Println(color.YellowString(notice))
+}
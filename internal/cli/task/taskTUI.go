@@ -0,0 +1,698 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var CmdTaskTUI = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse and triage tasks in a full-screen interface",
+	Long: `Browse and triage tasks in a full-screen interface.
+
+Tasks are shown as the same tree "todo list" prints, kept up to date by polling the server. Move with the arrow keys
+(or j/k) and act on the selected task:
+
+  a         create a new top level task       A   create a child of the selected task
+  space, c  complete or reopen the task        r   rename the task
+  e, enter  edit the description               d   delete the task and its children
+  tab, >    nest under the task above          <   move up a level, out of its parent
+  f         cycle the state filter             u   undo the last change
+  q         quit`,
+	Example: `$ todo tui
+$ todo tui --refresh 10s`,
+	RunE: taskTUI,
+}
+
+func init() {
+	CmdTaskTUI.Flags().Duration("refresh", 2*time.Second, "How often to poll the server for changes")
+}
+
+func taskTUI(cmd *cobra.Command, _ []string) error {
+	cl.State.Fmt.Print("Connecting")
+
+	refresh, err := cmd.Flags().GetDuration("refresh")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not start tui: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if refresh <= 0 {
+		err := fmt.Errorf("refresh interval must be positive")
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not start tui: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+	defer conn.Close()
+
+	// The spinner writes to the terminal on its own so it has to be stopped before the interface takes over the screen.
+	cl.State.Fmt.Finish()
+
+	program := tea.NewProgram(newTUIModel(proto.NewTodoClient(conn), refresh), tea.WithAltScreen())
+	_, err = program.Run()
+	if err != nil {
+		return fmt.Errorf("could not run tui: %w", err)
+	}
+
+	return nil
+}
+
+// How long any single call to the server may take before the interface gives up on it.
+const tuiRequestTimeout = 10 * time.Second
+
+// Number of lines given to the pane showing the selected task's description.
+const tuiPaneHeight = 8
+
+type tuiMode int
+
+const (
+	tuiBrowsing tuiMode = iota
+	tuiPrompting
+	tuiEditing
+	tuiConfirmingDelete
+)
+
+// The state filters the interface cycles through; TASK_STATE_UNKNOWN shows every task.
+var tuiFilters = []proto.Task_TaskState{
+	proto.Task_TASK_STATE_UNKNOWN,
+	proto.Task_UNRESOLVED,
+	proto.Task_COMPLETED,
+}
+
+type (
+	tuiTickMsg  time.Time
+	tuiTasksMsg []*proto.Task
+	tuiErrMsg   struct{ err error }
+
+	// tuiDoneMsg reports a change made to the tasks. The tree is refreshed afterwards and, if set, selectID is
+	// selected once it shows up.
+	tuiDoneMsg struct {
+		status   string
+		selectID string
+	}
+)
+
+// outlineRow is a single task as it appears in the outline.
+type outlineRow struct {
+	task  *proto.Task
+	depth int
+}
+
+type tuiModel struct {
+	client  proto.TodoClient
+	refresh time.Duration
+
+	tasks    []*proto.Task
+	rows     []outlineRow
+	progress map[string]progress
+	cursor   int
+	offset   int
+
+	// The ID of the selected task, so the selection follows the task as the tree changes around it.
+	selected string
+	filter   int
+
+	mode     tuiMode
+	input    textinput.Model
+	onSubmit func(value string) tea.Cmd
+	editor   textarea.Model
+	editing  *proto.Task
+
+	status    string
+	statusErr bool
+	loaded    bool
+
+	width  int
+	height int
+}
+
+var (
+	tuiHeaderStyle = lipgloss.NewStyle().Bold(true)
+	tuiFaintStyle  = lipgloss.NewStyle().Faint(true)
+	tuiErrStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	tuiPaneStyle   = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderTop(true).
+			BorderForeground(lipgloss.Color("8"))
+)
+
+func newTUIModel(client proto.TodoClient, refresh time.Duration) tuiModel {
+	input := textinput.New()
+	input.CharLimit = 200
+
+	editor := textarea.New()
+	editor.ShowLineNumbers = false
+
+	return tuiModel{
+		client:  client,
+		refresh: refresh,
+		input:   input,
+		editor:  editor,
+		width:   80,
+		height:  24,
+	}
+}
+
+func (m tuiModel) Init() tea.Cmd {
+	return tea.Batch(m.fetchTasks(), m.tick())
+}
+
+func (m tuiModel) tick() tea.Cmd {
+	return tea.Tick(m.refresh, func(t time.Time) tea.Msg {
+		return tuiTickMsg(t)
+	})
+}
+
+func (m tuiModel) fetchTasks() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), tuiRequestTimeout)
+		defer cancel()
+
+		tasks, err := cl.ListAllTasks(ctx, m.client, &proto.ListTasksRequest{})
+		if err != nil {
+			return tuiErrMsg{fmt.Errorf("could not list tasks: %w", err)}
+		}
+
+		return tuiTasksMsg(tasks)
+	}
+}
+
+// call runs a change against the server in the background, reporting the outcome as a message.
+func (m tuiModel) call(call func(ctx context.Context) (tuiDoneMsg, error)) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), tuiRequestTimeout)
+		defer cancel()
+
+		done, err := call(ctx)
+		if err != nil {
+			if status.Code(err) == codes.Aborted {
+				return tuiErrMsg{fmt.Errorf("task was changed elsewhere while you were working on it; try again")}
+			}
+			return tuiErrMsg{err}
+		}
+
+		return done
+	}
+}
+
+func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.editor.SetWidth(msg.Width)
+		m.editor.SetHeight(tuiPaneHeight - 2)
+		m.scrollToCursor()
+		return m, nil
+
+	case tuiTickMsg:
+		return m, tea.Batch(m.fetchTasks(), m.tick())
+
+	case tuiTasksMsg:
+		m.tasks = msg
+		m.loaded = true
+		m.rebuild()
+		return m, nil
+
+	case tuiErrMsg:
+		m.status = msg.err.Error()
+		m.statusErr = true
+		return m, nil
+
+	case tuiDoneMsg:
+		m.status = msg.status
+		m.statusErr = false
+		if msg.selectID != "" {
+			m.selected = msg.selectID
+		}
+		return m, m.fetchTasks()
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+
+		switch m.mode {
+		case tuiPrompting:
+			return m.updatePrompt(msg)
+		case tuiEditing:
+			return m.updateEditor(msg)
+		case tuiConfirmingDelete:
+			return m.updateConfirmDelete(msg)
+		default:
+			return m.updateBrowsing(msg)
+		}
+	}
+
+	return m, nil
+}
+
+func (m tuiModel) updateBrowsing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.status = ""
+	m.statusErr = false
+
+	switch msg.String() {
+	case "q", "esc":
+		return m, tea.Quit
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "pgup":
+		m.moveCursor(-m.outlineHeight())
+	case "pgdown":
+		m.moveCursor(m.outlineHeight())
+	case "home", "g":
+		m.moveCursor(-len(m.rows))
+	case "end", "G":
+		m.moveCursor(len(m.rows))
+	case "f":
+		m.filter = (m.filter + 1) % len(tuiFilters)
+		m.rebuild()
+	case "R":
+		return m, m.fetchTasks()
+	case "u":
+		return m, m.call(func(ctx context.Context) (tuiDoneMsg, error) {
+			resp, err := m.client.UndoOperation(ctx, &proto.UndoOperationRequest{})
+			if err != nil {
+				return tuiDoneMsg{}, err
+			}
+			return tuiDoneMsg{status: fmt.Sprintf("Undid %s", resp.Operation.Description)}, nil
+		})
+	case "a":
+		return m.prompt("New task: ", "", func(title string) tea.Cmd {
+			return m.createTask(title, "")
+		})
+	}
+
+	task := m.selectedTask()
+	if task == nil {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "A", "o":
+		return m.prompt("New child task: ", "", func(title string) tea.Cmd {
+			return m.createTask(title, task.Id)
+		})
+	case "r":
+		return m.prompt("Rename: ", task.Title, func(title string) tea.Cmd {
+			return m.updateTask(&proto.UpdateTaskRequest{Id: task.Id, Title: &title, ExpectedVersion: &task.Version},
+				fmt.Sprintf("Renamed %s", task.Id))
+		})
+	case " ", "c":
+		state := proto.UpdateTaskRequest_COMPLETED
+		action := "Completed"
+		if task.State == proto.Task_COMPLETED {
+			state = proto.UpdateTaskRequest_UNRESOLVED
+			action = "Reopened"
+		}
+		return m, m.updateTask(&proto.UpdateTaskRequest{Id: task.Id, State: &state},
+			fmt.Sprintf("%s %s", action, task.Id))
+	case "e", "enter":
+		m.mode = tuiEditing
+		m.editing = task
+		m.editor.SetValue(task.Description)
+		m.editor.Focus()
+		return m, textarea.Blink
+	case "d", "x":
+		m.mode = tuiConfirmingDelete
+	case "tab", ">":
+		parent := m.previousSibling()
+		if parent == nil {
+			m.status = "there is no task above at the same level to nest under"
+			m.statusErr = true
+			return m, nil
+		}
+		return m, m.updateTask(&proto.UpdateTaskRequest{Id: task.Id, Parent: &parent.Id},
+			fmt.Sprintf("Moved %s under %s", task.Id, parent.Id))
+	case "shift+tab", "<":
+		if task.Parent == "" {
+			m.status = "task is already at the top level"
+			m.statusErr = true
+			return m, nil
+		}
+		grandparent := ""
+		if parent := m.taskByID(task.Parent); parent != nil {
+			grandparent = parent.Parent
+		}
+		return m, m.updateTask(&proto.UpdateTaskRequest{Id: task.Id, Parent: &grandparent},
+			fmt.Sprintf("Moved %s up a level", task.Id))
+	}
+
+	return m, nil
+}
+
+func (m tuiModel) prompt(prompt, value string, onSubmit func(value string) tea.Cmd) (tea.Model, tea.Cmd) {
+	m.mode = tuiPrompting
+	m.input.Prompt = prompt
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	m.input.Focus()
+	m.onSubmit = onSubmit
+	return m, textinput.Blink
+}
+
+func (m tuiModel) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = tuiBrowsing
+		m.input.Blur()
+		return m, nil
+	case "enter":
+		m.mode = tuiBrowsing
+		m.input.Blur()
+		value := strings.TrimSpace(m.input.Value())
+		if value == "" {
+			return m, nil
+		}
+		return m, m.onSubmit(value)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m tuiModel) updateEditor(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = tuiBrowsing
+		m.editor.Blur()
+		return m, nil
+	case "ctrl+s":
+		m.mode = tuiBrowsing
+		m.editor.Blur()
+		task := m.editing
+		description := m.editor.Value()
+		if description == task.Description {
+			return m, nil
+		}
+		// The version the edit started from is sent along so an edit made elsewhere in the meantime isn't overwritten.
+		return m, m.updateTask(&proto.UpdateTaskRequest{
+			Id: task.Id, Description: &description, ExpectedVersion: &task.Version,
+		}, fmt.Sprintf("Updated description of %s", task.Id))
+	}
+
+	var cmd tea.Cmd
+	m.editor, cmd = m.editor.Update(msg)
+	return m, cmd
+}
+
+func (m tuiModel) updateConfirmDelete(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.mode = tuiBrowsing
+
+	task := m.selectedTask()
+	if task == nil || msg.String() != "y" {
+		return m, nil
+	}
+
+	return m, m.call(func(ctx context.Context) (tuiDoneMsg, error) {
+		resp, err := m.client.DeleteTask(ctx, &proto.DeleteTaskRequest{Id: task.Id})
+		if err != nil {
+			return tuiDoneMsg{}, err
+		}
+		return tuiDoneMsg{status: fmt.Sprintf("Deleted %d tasks", len(resp.Ids))}, nil
+	})
+}
+
+func (m tuiModel) createTask(title, parent string) tea.Cmd {
+	return m.call(func(ctx context.Context) (tuiDoneMsg, error) {
+		resp, err := m.client.CreateTask(ctx, &proto.CreateTaskRequest{Title: title, Parent: parent})
+		if err != nil {
+			return tuiDoneMsg{}, err
+		}
+		return tuiDoneMsg{status: fmt.Sprintf("Created %s", resp.Id), selectID: resp.Id}, nil
+	})
+}
+
+func (m tuiModel) updateTask(request *proto.UpdateTaskRequest, done string) tea.Cmd {
+	return m.call(func(ctx context.Context) (tuiDoneMsg, error) {
+		_, err := m.client.UpdateTask(ctx, request)
+		if err != nil {
+			return tuiDoneMsg{}, err
+		}
+		return tuiDoneMsg{status: done, selectID: request.Id}, nil
+	})
+}
+
+func (m *tuiModel) rebuild() {
+	m.rows = flattenTasks(filterTasks(m.tasks, tuiFilters[m.filter]))
+	m.progress = taskProgress(m.tasks)
+
+	m.cursor = min(m.cursor, max(len(m.rows)-1, 0))
+	for i, row := range m.rows {
+		if row.task.Id == m.selected {
+			m.cursor = i
+			break
+		}
+	}
+
+	if task := m.selectedTask(); task != nil {
+		m.selected = task.Id
+	}
+	m.scrollToCursor()
+}
+
+func (m *tuiModel) moveCursor(delta int) {
+	if len(m.rows) == 0 {
+		return
+	}
+
+	m.cursor = min(max(m.cursor+delta, 0), len(m.rows)-1)
+	m.selected = m.rows[m.cursor].task.Id
+	m.scrollToCursor()
+}
+
+func (m *tuiModel) scrollToCursor() {
+	height := m.outlineHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+	m.offset = max(min(m.offset, len(m.rows)-height), 0)
+}
+
+func (m tuiModel) outlineHeight() int {
+	// Leave room for the header, the description pane, the status line and the key help.
+	return max(m.height-tuiPaneHeight-3, 1)
+}
+
+func (m tuiModel) selectedTask() *proto.Task {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return nil
+	}
+	return m.rows[m.cursor].task
+}
+
+func (m tuiModel) taskByID(id string) *proto.Task {
+	for _, task := range m.tasks {
+		if task.Id == id {
+			return task
+		}
+	}
+	return nil
+}
+
+// previousSibling returns the closest task above the selected one that shares its parent, which is the task it
+// would be nested under.
+func (m tuiModel) previousSibling() *proto.Task {
+	current := m.rows[m.cursor]
+	for i := m.cursor - 1; i >= 0; i-- {
+		if m.rows[i].depth < current.depth {
+			return nil
+		}
+		if m.rows[i].depth == current.depth {
+			return m.rows[i].task
+		}
+	}
+	return nil
+}
+
+func (m tuiModel) View() string {
+	var b strings.Builder
+
+	filter := "all"
+	if state := tuiFilters[m.filter]; state != proto.Task_TASK_STATE_UNKNOWN {
+		filter = strings.ToLower(state.String())
+	}
+	b.WriteString(tuiHeaderStyle.Render("todo"))
+	b.WriteString(tuiFaintStyle.Render(fmt.Sprintf(" :: %d tasks :: showing %s", len(m.rows), filter)))
+	b.WriteString("\n")
+
+	outline := m.viewOutline()
+	b.WriteString(outline)
+	b.WriteString(strings.Repeat("\n", max(m.outlineHeight()-strings.Count(outline, "\n"), 0)))
+
+	b.WriteString(tuiPaneStyle.Width(m.width).Render(m.viewPane()))
+	b.WriteString("\n")
+
+	switch {
+	case m.mode == tuiPrompting:
+		b.WriteString(m.input.View())
+	case m.mode == tuiConfirmingDelete:
+		b.WriteString(tuiErrStyle.Render("Delete the task and all of its children? (y/n)"))
+	case m.statusErr:
+		b.WriteString(tuiErrStyle.Render(m.status))
+	default:
+		b.WriteString(m.status)
+	}
+	b.WriteString("\n")
+
+	help := "a/A new · space complete · r rename · e edit · d delete · tab/< nest · f filter · u undo · q quit"
+	if m.mode == tuiEditing {
+		help = "ctrl+s save · esc cancel"
+	}
+	b.WriteString(tuiFaintStyle.Render(help))
+
+	return b.String()
+}
+
+// viewOutline draws the visible part of the task tree the same way "todo list" does.
+func (m tuiModel) viewOutline() string {
+	if !m.loaded {
+		return "Loading tasks...\n"
+	}
+
+	if len(m.rows) == 0 {
+		return tuiFaintStyle.Render("No tasks; press 'a' to create one") + "\n"
+	}
+
+	var b strings.Builder
+	end := min(m.offset+m.outlineHeight(), len(m.rows))
+	for i := m.offset; i < end; i++ {
+		row := m.rows[i]
+
+		branch := "├─"
+		switch {
+		case i == 0:
+			branch = "┌─"
+		case i == len(m.rows)-1:
+			branch = "└─"
+		}
+
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "▸ "
+		}
+
+		line := cursor + branch + strings.Repeat("─", row.depth) + " " + stringifyTask(row.task)
+		if p, exists := m.progress[row.task.Id]; exists {
+			line += " " + formatProgress(p.completed, p.total)
+		}
+		b.WriteString(lipgloss.NewStyle().MaxWidth(m.width).Render(line))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (m tuiModel) viewPane() string {
+	if m.mode == tuiEditing {
+		return m.editor.View()
+	}
+
+	task := m.selectedTask()
+	if task == nil {
+		return ""
+	}
+
+	description := task.Description
+	if description == "" {
+		description = tuiFaintStyle.Render("No description; press 'e' to add one")
+	}
+
+	lines := strings.Split(description, "\n")
+	if len(lines) > tuiPaneHeight-2 {
+		lines = lines[:tuiPaneHeight-2]
+	}
+
+	return fmt.Sprintf("%s\n%s", tuiFaintStyle.Render(fmt.Sprintf("%s :: %s :: version %d", task.Id,
+		strings.ToLower(task.State.String()), task.Version)), strings.Join(lines, "\n"))
+}
+
+// filterTasks returns the tasks in the given state along with their ancestors, so the tasks matched still show up
+// where they are in the tree. TASK_STATE_UNKNOWN matches every task.
+func filterTasks(tasks []*proto.Task, state proto.Task_TaskState) []*proto.Task {
+	if state == proto.Task_TASK_STATE_UNKNOWN {
+		return tasks
+	}
+
+	byID := map[string]*proto.Task{}
+	for _, task := range tasks {
+		byID[task.Id] = task
+	}
+
+	keep := map[string]bool{}
+	for _, task := range tasks {
+		if task.State != state {
+			continue
+		}
+		for current := task; current != nil && !keep[current.Id]; current = byID[current.Parent] {
+			keep[current.Id] = true
+		}
+	}
+
+	filtered := []*proto.Task{}
+	for _, task := range tasks {
+		if keep[task.Id] {
+			filtered = append(filtered, task)
+		}
+	}
+
+	return filtered
+}
+
+// flattenTasks lays the task tree out as rows in the order "todo list" prints it, with each task followed by its
+// children. Tasks whose parent isn't among those given are treated as top level tasks.
+func flattenTasks(tasks []*proto.Task) []outlineRow {
+	byID := map[string]*proto.Task{}
+	for _, task := range tasks {
+		byID[task.Id] = task
+	}
+
+	roots := []*proto.Task{}
+	children := map[string][]*proto.Task{}
+	for _, task := range tasks {
+		if _, exists := byID[task.Parent]; task.Parent == "" || !exists {
+			roots = append(roots, task)
+			continue
+		}
+		children[task.Parent] = append(children[task.Parent], task)
+	}
+
+	rows := []outlineRow{}
+
+	var walk func(tasks []*proto.Task, depth int)
+	walk = func(tasks []*proto.Task, depth int) {
+		sort.Slice(tasks, func(i, j int) bool { return tasks[i].Id < tasks[j].Id })
+		for _, task := range tasks {
+			rows = append(rows, outlineRow{task: task, depth: depth})
+			walk(children[task.Id], depth+1)
+		}
+	}
+	walk(roots, 0)
+
+	return rows
+}
@@ -0,0 +1,160 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/cli/format"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var CmdTaskUndo = &cobra.Command{
+	Use:   "undo [operation id]",
+	Short: "Undo the most recent change to tasks",
+	Long: `Undo the most recent complete, update or delete, putting every task it touched back the way it was. Deleted
+tasks are recreated with their original IDs along with their comments and attachments.
+
+Changes aren't tracked per user: the most recent change is the last one made by anyone using the server, which may not
+be your own. Check 'todo undo --list' first when others share the server.
+
+Give an operation ID from 'todo undo --list' to undo an older change instead. A change can't be undone if any of the
+tasks it touched have been changed since, unless --force is given, in which case those later changes are lost.`,
+	Example: `$ todo undo
+$ todo undo --list
+$ todo undo 7bq1x --force`,
+	RunE: taskUndo,
+	Args: cobra.MaximumNArgs(1),
+}
+
+func init() {
+	cl.AddOutputFlags(CmdTaskUndo)
+	CmdTaskUndo.Flags().BoolP("list", "l", false, "List recent changes that can be undone instead of undoing one")
+	CmdTaskUndo.Flags().IntP("limit", "n", 20, "Number of recent changes to list")
+	CmdTaskUndo.Flags().BoolP("force", "f", false, "Undo even if the tasks have been changed since")
+}
+
+func taskUndo(cmd *cobra.Command, args []string) error {
+	list, err := cmd.Flags().GetBool("list")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not undo: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if list {
+		return listOperations(cmd)
+	}
+
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not undo: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	id := ""
+	if len(args) > 0 {
+		id = args[0]
+	}
+
+	cl.State.Fmt.Print("Undoing Change")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.UndoOperation(context.Background(), &proto.UndoOperationRequest{
+		Id:    id,
+		Force: force,
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not undo: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+	if cl.State.WritesData() {
+		err = cl.State.WriteData(format.NewOperation(resp.Operation))
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not undo: %v", err))
+			return err
+		}
+		return nil
+	}
+
+	cl.State.Fmt.PrintSuccess(fmt.Sprintf("Undid [%s] %s; restored %d tasks", color.MagentaString(resp.Operation.Id),
+		resp.Operation.Description, len(resp.RestoredIds)))
+	cl.State.Fmt.Finish()
+	return nil
+}
+
+func listOperations(cmd *cobra.Command) error {
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not list changes: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	cl.State.Fmt.Print("Collecting Changes")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.ListOperations(context.Background(), &proto.ListOperationsRequest{
+		Limit: int64(limit),
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not list changes: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+	cl.State.Fmt.Finish()
+
+	if cl.State.WritesData() {
+		operations := []format.Operation{}
+		for _, operation := range resp.Operations {
+			operations = append(operations, format.NewOperation(operation))
+		}
+
+		err = cl.State.WriteData(operations)
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not list changes: %v", err))
+			return err
+		}
+		return nil
+	}
+
+	data := [][]string{}
+	for _, operation := range resp.Operations {
+		state := ""
+		if operation.Undone {
+			state = "Undone"
+		}
+
+		data = append(data, []string{
+			operation.Id, format.UnixMilli(operation.Created, "Unknown", cl.State.Config.Detail), operation.Description,
+			strconv.Itoa(len(operation.TaskIds)), state,
+		})
+	}
+
+	headers := []string{"ID", "When", "Change", "Tasks", "State"}
+	cl.State.Fmt.Println(format.Table(headers, data, !cl.State.Config.NoColor))
+	cl.State.Fmt.Finish()
+
+	return nil
+}
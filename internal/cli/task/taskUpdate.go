@@ -3,64 +3,122 @@ package task
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/cli/offline"
 	"github.com/clintjedwards/todo/proto"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
 var CmdTaskUpdate = &cobra.Command{
-	Use:     "update <id>",
-	Short:   "Update the details of a task",
-	Example: `$ todo update 62arz -d "example description"`,
-	RunE:    taskUpdate,
-	Args:    cobra.ExactArgs(1),
+	Use:   "update <id>...",
+	Short: "Update the details of tasks",
+	Long: `Update the details of tasks.
+
+Many tasks can be updated at once by giving more than one ID or choosing them with --filter; only the flags passed are
+changed. All tasks are updated in a single round trip and a task that can't be updated doesn't stop the others.
+
+Every change to a task bumps its version (shown by "todo get"). Passing --if-version makes the update fail rather than
+overwrite a change made since that version was read.`,
+	Example: `$ todo update 62arz -d "example description"
+$ todo update 62arz -t "new title" --if-version 3
+$ todo update 62arz 7bq1x --parent k3m9d
+$ todo update --filter subtree=62arz,state=completed --state unresolved`,
+	RunE:              taskUpdate,
+	ValidArgsFunction: cl.CompleteTaskIDs,
 }
 
 func init() {
 	CmdTaskUpdate.Flags().StringP("description", "d", "", "Description about task")
 	CmdTaskUpdate.Flags().StringP("parent", "p", "", "Link this task as the child of another task")
+	_ = CmdTaskUpdate.RegisterFlagCompletionFunc("parent", cl.CompleteTaskIDFlag)
 	CmdTaskUpdate.Flags().StringP("title", "t", "", "Task title")
 	CmdTaskUpdate.Flags().StringP("state", "s", "", "Manipulate task state")
+	CmdTaskUpdate.Flags().StringP("note", "m", "", "Note on why the task was completed")
+	CmdTaskUpdate.Flags().StringSlice("filter", []string{}, filterFlagHelp)
+	CmdTaskUpdate.Flags().Int64("if-version", 0,
+		"Only update the task if it is still at this version; guards against overwriting someone else's change")
 }
 
 func taskUpdate(cmd *cobra.Command, args []string) error {
-	id := args[0]
-
 	cl.State.Fmt.Print("Updating Task")
 
-	description := ""
-	parent := ""
-	title := ""
-	state := ""
-
-	description, err := cmd.Flags().GetString("description")
+	filterValues, err := cmd.Flags().GetStringSlice("filter")
 	if err != nil {
 		cl.State.Fmt.PrintErr(fmt.Sprintf("could not update task: %v", err))
 		cl.State.Fmt.Finish()
 		return err
 	}
 
-	parent, err = cmd.Flags().GetString("parent")
+	filter, err := parseFilter(filterValues)
 	if err != nil {
 		cl.State.Fmt.PrintErr(fmt.Sprintf("could not update task: %v", err))
 		cl.State.Fmt.Finish()
 		return err
 	}
 
-	title, err = cmd.Flags().GetString("title")
-	if err != nil {
+	if (len(args) == 0) == (filter == nil) {
+		err := fmt.Errorf("either task ids or --filter are required, but not both")
 		cl.State.Fmt.PrintErr(fmt.Sprintf("could not update task: %v", err))
 		cl.State.Fmt.Finish()
 		return err
 	}
 
-	state, err = cmd.Flags().GetString("state")
-	if err != nil {
-		cl.State.Fmt.PrintErr(fmt.Sprintf("could not update task: %v", err))
-		cl.State.Fmt.Finish()
-		return err
+	if len(args) > 1 || filter != nil {
+		return batchUpdate(cmd, args, filter)
+	}
+
+	request := &proto.UpdateTaskRequest{
+		Id: args[0],
+	}
+
+	for flag, field := range map[string]**string{
+		"title":       &request.Title,
+		"description": &request.Description,
+		"parent":      &request.Parent,
+		"note":        &request.CompletionNote,
+	} {
+		if !cmd.Flags().Changed(flag) {
+			continue
+		}
+
+		value, err := cmd.Flags().GetString(flag)
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not update task: %v", err))
+			cl.State.Fmt.Finish()
+			return err
+		}
+		*field = &value
+	}
+
+	if cmd.Flags().Changed("state") {
+		value, err := cmd.Flags().GetString("state")
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not update task: %v", err))
+			cl.State.Fmt.Finish()
+			return err
+		}
+
+		state, exists := proto.UpdateTaskRequest_TaskState_value[strings.ToUpper(value)]
+		if !exists {
+			err := fmt.Errorf("state %q not recognized; should be one of unresolved or completed", value)
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not update task: %v", err))
+			cl.State.Fmt.Finish()
+			return err
+		}
+		request.State = proto.UpdateTaskRequest_TaskState(state).Enum()
+	}
+
+	if cmd.Flags().Changed("if-version") {
+		version, err := cmd.Flags().GetInt64("if-version")
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not update task: %v", err))
+			cl.State.Fmt.Finish()
+			return err
+		}
+		request.ExpectedVersion = &version
 	}
 
 	conn, err := cl.State.Connect()
@@ -72,44 +130,122 @@ func taskUpdate(cmd *cobra.Command, args []string) error {
 
 	client := proto.NewTodoClient(conn)
 
-	resp, err := client.GetTask(context.Background(), &proto.GetTaskRequest{
-		Id: id,
-	})
+	replica := openReplica()
+	if replica != nil {
+		defer replica.Close()
+	}
+
+	pushQueued(client, replica)
+
+	request.Id = serverID(replica, request.Id)
+	if request.Parent != nil {
+		parent := serverID(replica, *request.Parent)
+		request.Parent = &parent
+	}
+
+	resp, err := client.UpdateTask(context.Background(), request)
 	if err != nil {
-		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get task: %v", err))
+		if offline.Unreachable(err) && replica != nil {
+			return queueUpdates(replica, "update", request)
+		}
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not update task: %v", err))
 		cl.State.Fmt.Finish()
 		return err
 	}
+	cl.State.Fmt.PrintSuccess(fmt.Sprintf("Updated task: %s (version %d)", color.MagentaString(resp.Task.Id),
+		resp.Task.Version))
+	cl.State.Fmt.Finish()
+	return nil
+}
 
-	if description == "" {
-		description = resp.Task.Description
+// batchUpdate updates many tasks at once, changing only the fields whose flags were passed.
+func batchUpdate(cmd *cobra.Command, ids []string, filter *proto.TaskFilter) error {
+	request := &proto.BatchUpdateTasksRequest{
+		Ids:    ids,
+		Filter: filter,
 	}
 
-	if title == "" {
-		title = resp.Task.Title
+	for flag, field := range map[string]**string{
+		"title":       &request.Title,
+		"description": &request.Description,
+		"parent":      &request.Parent,
+		"note":        &request.CompletionNote,
+	} {
+		if !cmd.Flags().Changed(flag) {
+			continue
+		}
+
+		value, err := cmd.Flags().GetString(flag)
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not update tasks: %v", err))
+			cl.State.Fmt.Finish()
+			return err
+		}
+		*field = &value
 	}
 
-	if parent == "" {
-		parent = resp.Task.Parent
+	if cmd.Flags().Changed("state") {
+		value, err := cmd.Flags().GetString("state")
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not update tasks: %v", err))
+			cl.State.Fmt.Finish()
+			return err
+		}
+
+		state, exists := proto.UpdateTaskRequest_TaskState_value[strings.ToUpper(value)]
+		if !exists {
+			err := fmt.Errorf("state %q not recognized; should be one of unresolved or completed", value)
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not update tasks: %v", err))
+			cl.State.Fmt.Finish()
+			return err
+		}
+		request.State = proto.UpdateTaskRequest_TaskState(state).Enum()
 	}
 
-	if state == "" {
-		state = resp.Task.State.String()
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
 	}
 
-	_, err = client.UpdateTask(context.Background(), &proto.UpdateTaskRequest{
-		Id:          id,
-		Title:       title,
-		Description: description,
-		Parent:      parent,
-		State:       proto.UpdateTaskRequest_TaskState(proto.UpdateTaskRequest_TaskState_value[state]),
-	})
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.BatchUpdateTasks(context.Background(), request)
 	if err != nil {
-		cl.State.Fmt.PrintErr(fmt.Sprintf("could not update task: %v", err))
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not update tasks: %v", err))
 		cl.State.Fmt.Finish()
 		return err
 	}
-	cl.State.Fmt.PrintSuccess(fmt.Sprintf("Updated task: %s", color.MagentaString(id)))
+
+	err = printBatchResults("Updated", resp.Results)
+	cl.State.Fmt.Finish()
+	return err
+}
+
+// queueUpdates queues updates to be made once the server can be reached again. The action is the word used for
+// them, ex. "update".
+func queueUpdates(replica *offline.Replica, action string, requests ...*proto.UpdateTaskRequest) error {
+	failed := 0
+	for _, request := range requests {
+		id := request.Id
+
+		task, err := replica.QueueUpdate(request)
+		if err != nil {
+			failed++
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not %s task %s: server unreachable and could not queue change: %v",
+				action, id, err))
+			continue
+		}
+
+		cl.State.Fmt.PrintSuccess(fmt.Sprintf("Server unreachable; queued %s to task: %s", action,
+			color.MagentaString(task.Id)))
+	}
 	cl.State.Fmt.Finish()
+
+	if failed > 0 {
+		return fmt.Errorf("%d changes could not be queued", failed)
+	}
+
 	return nil
 }
@@ -0,0 +1,35 @@
+package template
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var CmdTemplate = &cobra.Command{
+	Use:   "template",
+	Short: "Manage task templates",
+	Long: `Templates describe a tree of tasks that can be created all at once, either by hand with 'todo template apply'
+or every time a scheduled task fires with 'todo schedule --template'.
+
+Templates are written as an indented outline where every line is a task and indentation makes a task the child of the
+one above it. A description can follow the title after a "|". List markers are optional and lines starting with "#"
+are ignored:
+
+  Pay bills for {{month}} {{year}}
+    - Electricity | Account 1234
+    - Water
+    - Internet
+
+Titles and descriptions can contain variables which are filled in when the template is applied. The following are
+always available and describe when the template is applied or, for scheduled tasks, when the tasks were due:
+
+  {{date}}          2024-03-01
+  {{day}}           1
+  {{weekday}}       Friday
+  {{week}}          9, the ISO week number
+  {{month}}         March
+  {{month_number}}  3
+  {{year}}          2024
+  {{time}}          15:04
+
+Other variables can be given with 'todo template apply --var name=value'.`,
+}
@@ -0,0 +1,91 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var CmdTemplateApply = &cobra.Command{
+	Use:   "apply <name|id>",
+	Short: "Create the tasks in a template",
+	Long: `Create every task in a template, filling in its variables. Either all of the tasks are created or, if anything
+goes wrong, none of them are.`,
+	Example: `$ todo template apply "Monthly bills"
+$ todo template apply trip --var place=Lisbon --parent 62arz`,
+	RunE: templateApply,
+	Args: cobra.ExactArgs(1),
+}
+
+func init() {
+	CmdTemplate.AddCommand(CmdTemplateApply)
+	CmdTemplateApply.Flags().StringP("parent", "p", "", "Link the top level tasks as children of another task")
+	_ = CmdTemplateApply.RegisterFlagCompletionFunc("parent", cl.CompleteTaskIDFlag)
+	CmdTemplateApply.Flags().StringArray("var", []string{}, "Set a template variable; ex. --var place=Lisbon. Can be repeated")
+}
+
+func templateApply(cmd *cobra.Command, args []string) error {
+	id := args[0]
+
+	cl.State.Fmt.Print("Applying Template")
+
+	parent, err := cmd.Flags().GetString("parent")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not apply template: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	vars, err := cmd.Flags().GetStringArray("var")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not apply template: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	variables := map[string]string{}
+	for _, v := range vars {
+		name, value, ok := strings.Cut(v, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			err := fmt.Errorf("variable %q should be in the form name=value", v)
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not apply template: %v", err))
+			cl.State.Fmt.Finish()
+			return err
+		}
+		variables[strings.TrimSpace(name)] = value
+	}
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.ApplyTemplate(context.Background(), &proto.ApplyTemplateRequest{
+		Id:        id,
+		Parent:    parent,
+		Variables: variables,
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not apply template: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	roots := []string{}
+	for _, id := range resp.RootIds {
+		roots = append(roots, color.MagentaString(id))
+	}
+
+	cl.State.Fmt.PrintSuccess(fmt.Sprintf("Created %d tasks from template: [%s]", len(resp.Ids), strings.Join(roots, ", ")))
+	cl.State.Fmt.Finish()
+	return nil
+}
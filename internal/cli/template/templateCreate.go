@@ -0,0 +1,95 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/tasktemplate"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var CmdTemplateCreate = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a new task template",
+	Long: `Create a new task template from an outline read from a file or, without --file, from stdin.
+
+See 'todo template --help' for how outlines are written.`,
+	Example: `$ todo template create "Monthly bills" --file bills.txt
+$ printf 'Trip to {{place}}\n  Book hotel\n  Pack' | todo template create trip`,
+	RunE: templateCreate,
+	Args: cobra.ExactArgs(1),
+}
+
+func init() {
+	CmdTemplate.AddCommand(CmdTemplateCreate)
+	CmdTemplateCreate.Flags().StringP("file", "f", "", "Read the outline from this file instead of stdin")
+	CmdTemplateCreate.Flags().StringP("description", "d", "", "Description about template")
+}
+
+func templateCreate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cl.State.Fmt.Print("Creating Template")
+
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not create template: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	description, err := cmd.Flags().GetString("description")
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not create template: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	var outline []byte
+	if file != "" {
+		outline, err = os.ReadFile(file)
+	} else {
+		outline, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not read template outline: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	tasks, err := tasktemplate.ParseOutline(string(outline))
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not read template outline: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.CreateTemplate(context.Background(), &proto.CreateTemplateRequest{
+		Name:        name,
+		Description: description,
+		Tasks:       tasks,
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not create template: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+	cl.State.Fmt.PrintSuccess(fmt.Sprintf("Created template: [%s] %s (%d tasks)", color.MagentaString(resp.Id),
+		"\""+color.BlueString(name)+"\"", tasktemplate.Count(tasks)))
+	cl.State.Fmt.Finish()
+	return nil
+}
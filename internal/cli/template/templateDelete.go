@@ -0,0 +1,51 @@
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/spf13/cobra"
+)
+
+var CmdTemplateDelete = &cobra.Command{
+	Use:   "delete <name|id>",
+	Short: "Delete a task template",
+	Long: `Delete a task template. Tasks already created from the template are left alone. Templates used by a scheduled
+task can't be deleted until the scheduled task is deleted or stops using it.`,
+	Example: `$ todo template delete "Monthly bills"`,
+	RunE:    templateDelete,
+	Args:    cobra.ExactArgs(1),
+}
+
+func init() {
+	CmdTemplate.AddCommand(CmdTemplateDelete)
+}
+
+func templateDelete(_ *cobra.Command, args []string) error {
+	id := args[0]
+
+	cl.State.Fmt.Print("Deleting Template")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.DeleteTemplate(context.Background(), &proto.DeleteTemplateRequest{
+		Id: id,
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not delete template: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+	cl.State.Fmt.PrintSuccess(fmt.Sprintf("Deleted template: %q", resp.Id))
+	cl.State.Fmt.Finish()
+	return nil
+}
@@ -0,0 +1,79 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/cli/format"
+	"github.com/clintjedwards/todo/internal/tasktemplate"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var CmdTemplateGet = &cobra.Command{
+	Use:   "get <name|id>",
+	Short: "Describe a task template",
+	Long: `Describe a task template, including its outline. The outline is printed in the same format
+'todo template create' reads so it can be saved to a file, edited and used to create a new template.`,
+	Example: `$ todo template get "Monthly bills"`,
+	RunE:    templateGet,
+	Args:    cobra.ExactArgs(1),
+}
+
+func init() {
+	CmdTemplate.AddCommand(CmdTemplateGet)
+	cl.AddOutputFlags(CmdTemplateGet)
+}
+
+func templateGet(_ *cobra.Command, args []string) error {
+	id := args[0]
+
+	cl.State.Fmt.Print("Getting Template Details")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.GetTemplate(context.Background(), &proto.GetTemplateRequest{
+		Id: id,
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not get template: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	if cl.State.WritesData() {
+		err = cl.State.WriteData(format.NewTemplate(resp.Template))
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not get template: %v", err))
+			return err
+		}
+		return nil
+	}
+
+	cl.State.Fmt.Println(formatTemplateInfo(resp.Template))
+	cl.State.Fmt.Finish()
+	return nil
+}
+
+func formatTemplateInfo(template *proto.Template) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Template [%s] :: %s\n\n", color.MagentaString(template.Id), color.BlueString(template.Name))
+	if template.Description != "" {
+		fmt.Fprintf(&b, "  %s\n\n", template.Description)
+	}
+	fmt.Fprintf(&b, "Created %s\n\n", format.UnixMilli(template.Created, "Unknown", cl.State.Config.Detail))
+	b.WriteString(tasktemplate.FormatOutline(template.Tasks))
+
+	return b.String()
+}
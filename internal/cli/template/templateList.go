@@ -0,0 +1,77 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/clintjedwards/todo/internal/cli/cl"
+	"github.com/clintjedwards/todo/internal/cli/format"
+	"github.com/clintjedwards/todo/internal/tasktemplate"
+	"github.com/clintjedwards/todo/proto"
+	"github.com/spf13/cobra"
+)
+
+var CmdTemplateList = &cobra.Command{
+	Use:     "list",
+	Short:   "List all task templates",
+	Example: `$ todo template list`,
+	RunE:    templateList,
+}
+
+func init() {
+	CmdTemplate.AddCommand(CmdTemplateList)
+	cl.AddOutputFlags(CmdTemplateList)
+}
+
+func templateList(_ *cobra.Command, _ []string) error {
+	cl.State.Fmt.Print("Collecting Templates")
+
+	conn, err := cl.State.Connect()
+	if err != nil {
+		cl.State.Fmt.PrintErr(err)
+		cl.State.Fmt.Finish()
+		return err
+	}
+
+	client := proto.NewTodoClient(conn)
+
+	resp, err := client.ListTemplates(context.Background(), &proto.ListTemplatesRequest{
+		Offset: 0,
+		Limit:  0,
+	})
+	if err != nil {
+		cl.State.Fmt.PrintErr(fmt.Sprintf("could not list templates: %v", err))
+		cl.State.Fmt.Finish()
+		return err
+	}
+	cl.State.Fmt.Finish()
+
+	if cl.State.WritesData() {
+		templates := []format.Template{}
+		for _, template := range resp.Templates {
+			templates = append(templates, format.NewTemplate(template))
+		}
+
+		err = cl.State.WriteData(templates)
+		if err != nil {
+			cl.State.Fmt.PrintErr(fmt.Sprintf("could not list templates: %v", err))
+			return err
+		}
+		return nil
+	}
+
+	data := [][]string{}
+	for _, template := range resp.Templates {
+		data = append(data, []string{
+			template.Id, template.Name, template.Description, strconv.Itoa(tasktemplate.Count(template.Tasks)),
+			format.UnixMilli(template.Created, "Unknown", false),
+		})
+	}
+
+	headers := []string{"ID", "Name", "Description", "Tasks", "Created"}
+	cl.State.Fmt.Println(format.Table(headers, data, !cl.State.Config.NoColor))
+	cl.State.Fmt.Finish()
+
+	return nil
+}
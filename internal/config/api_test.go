@@ -28,12 +28,21 @@ func TestInitAPIConfigAgainstSampleOverwriteWithEnvs(t *testing.T) {
 		LogLevel:    "info",
 		Development: &Development{},
 		Server: &Server{
-			Host:                "localhost:8080",
-			ShutdownTimeout:     time.Second * 15,
-			TLSCertPath:         "./test",
-			TLSKeyPath:          "./localhost.key",
-			StoragePath:         "/tmp/todo.db",
-			StorageResultsLimit: 200,
+			Host:                  "localhost:8080",
+			ShutdownTimeout:       time.Second * 15,
+			TLSCertPath:           "./test",
+			TLSKeyPath:            "./localhost.key",
+			StorageDriver:         "sqlite",
+			StoragePath:           "/tmp/todo.db",
+			StorageResultsLimit:   200,
+			BackupInterval:        time.Hour * 24,
+			BackupRetention:       7,
+			ArchivePolicy:         "none",
+			ArchiveAfter:          time.Hour * 720,
+			ArchiveInterval:       time.Hour,
+			AttachmentMaxSize:     10 << 20,
+			AttachmentMaxTaskSize: 50 << 20,
+			IDLength:              5,
 		},
 	}
 
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"os/user"
 	"sort"
 	"strings"
 
@@ -19,6 +20,12 @@ type CLI struct {
 	Format  string `koanf:"format"`
 	NoColor bool   `koanf:"no_color"`
 	Token   string `koanf:"token"`
+	// The name comments are left under. Defaults to the name of the user running the cli.
+	Author string `koanf:"author"`
+	// Keep a local copy of the server's tasks, read from and queued up for changes while the server can't be
+	// reached. Queued changes are sent the next time it can. Off by default so that a server that can't be reached is
+	// reported rather than quietly worked around.
+	Offline bool `koanf:"offline"`
 }
 
 // DefaultCLIConfig returns a pre-populated configuration struct that is used as the base for super imposing user configuration
@@ -27,9 +34,20 @@ func DefaultCLIConfig() *CLI {
 	return &CLI{
 		Host:   "localhost:8080",
 		Format: "pretty",
+		Author: defaultAuthor(),
 	}
 }
 
+// defaultAuthor returns the name of the user running the cli, or an empty string if it can't be found.
+func defaultAuthor() string {
+	current, err := user.Current()
+	if err != nil {
+		return os.Getenv("USER")
+	}
+
+	return current.Username
+}
+
 // Get configuration for command line.
 // This involves correctly finding and ordering different possible paths for the configuration file:
 //
@@ -0,0 +1,80 @@
+// Package metrics contains the prometheus collectors Todo exposes on its /metrics endpoint.
+//
+// Collectors are always updated, but are only served when metrics are enabled in the server configuration.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "todo"
+
+// Registry holds every collector Todo exposes. We use our own registry instead of the prometheus default so
+// that only metrics we intentionally export are served.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// RPCDuration tracks how long each GRPC method took to handle along with the status code it returned.
+	RPCDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "server_handling_seconds",
+		Help:      "Time taken to handle a GRPC request, labeled by method and returned status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	// DBQueryDuration tracks how long each storage operation took.
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Time taken to run a storage operation, labeled by operation.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"operation"})
+
+	// SchedulerFires counts how many times a scheduled task has come due and attempted to create a new task.
+	SchedulerFires = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "scheduler",
+		Name:      "fires_total",
+		Help:      "Total number of times a scheduled task came due.",
+	})
+
+	// SchedulerFailures counts how many times a scheduled task came due but failed to create a new task.
+	SchedulerFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "scheduler",
+		Name:      "failures_total",
+		Help:      "Total number of times a scheduled task came due but could not create its task.",
+	})
+
+	// SchedulerSkips counts how many times a scheduled task came due but didn't create a new task because the previous
+	// one was still unresolved.
+	SchedulerSkips = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "scheduler",
+		Name:      "skips_total",
+		Help:      "Total number of times a scheduled task came due but was skipped since its previous task was still open.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		RPCDuration,
+		DBQueryDuration,
+		SchedulerFires,
+		SchedulerFailures,
+		SchedulerSkips,
+	)
+}
+
+// ObserveQuery records the time since start as the duration of the storage operation given.
+// It is meant to be deferred at the top of a storage function: defer metrics.ObserveQuery("get_task", time.Now())
+func ObserveQuery(operation string, start time.Time) {
+	DBQueryDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
@@ -1,7 +1,9 @@
 package models
 
 import (
-	"math/rand"
+	"math/rand/v2"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/clintjedwards/todo/internal/storage"
@@ -16,44 +18,68 @@ const (
 	TaskStateCompleted  TaskState = "COMPLETED"
 )
 
+type OperationKind string
+
+const (
+	OperationKindUnknown  OperationKind = "OPERATION_KIND_UNKNOWN"
+	OperationKindComplete OperationKind = "COMPLETE"
+	OperationKindUpdate   OperationKind = "UPDATE"
+	OperationKindDelete   OperationKind = "DELETE"
+)
+
 type Task struct {
-	ID          string
-	Title       string
-	Description string
-	State       TaskState
-	Created     int64
-	Modified    int64
-	Parent      string
+	ID              string
+	Title           string
+	Description     string
+	State           TaskState
+	Created         int64
+	Modified        int64
+	Parent          string
+	ScheduledTaskID string
+	ScheduledFor    int64
+	Version         int64
+	CompletedAt     int64
+	CompletionNote  string
 }
 
 func (t *Task) ToProto() *proto.Task {
 	return &proto.Task{
-		Id:          t.ID,
-		Title:       t.Title,
-		Description: t.Description,
-		State:       proto.Task_TaskState(proto.Task_TaskState_value[string(t.State)]),
-		Created:     t.Created,
-		Modified:    t.Modified,
-		Parent:      t.Parent,
+		Id:              t.ID,
+		Title:           t.Title,
+		Description:     t.Description,
+		State:           proto.Task_TaskState(proto.Task_TaskState_value[string(t.State)]),
+		Created:         t.Created,
+		Modified:        t.Modified,
+		Parent:          t.Parent,
+		ScheduledTaskId: t.ScheduledTaskID,
+		ScheduledFor:    t.ScheduledFor,
+		Version:         t.Version,
+		CompletedAt:     t.CompletedAt,
+		CompletionNote:  t.CompletionNote,
 	}
 }
 
 // Returns a storage layer model from a domain-layer model.
 func (t *Task) ToStorage() *storage.Task {
 	return &storage.Task{
-		ID:          t.ID,
-		Title:       t.Title,
-		Description: t.Description,
-		State:       string(t.State),
-		Created:     t.Created,
-		Modified:    t.Modified,
-		Parent:      t.Parent,
+		ID:              t.ID,
+		Title:           t.Title,
+		Description:     t.Description,
+		State:           string(t.State),
+		Created:         t.Created,
+		Modified:        t.Modified,
+		Parent:          t.Parent,
+		ScheduledTaskID: t.ScheduledTaskID,
+		ScheduledFor:    t.ScheduledFor,
+		Version:         t.Version,
+		CompletedAt:     t.CompletedAt,
+		CompletionNote:  t.CompletionNote,
 	}
 }
 
-func NewTask(title, description, parent string) *Task {
+func NewTask(id, title, description, parent string) *Task {
 	return &Task{
-		ID:          string(generateRandString(3)),
+		ID:          id,
 		Title:       title,
 		Description: description,
 		State:       TaskStateUnresolved,
@@ -63,12 +89,27 @@ func NewTask(title, description, parent string) *Task {
 	}
 }
 
+type ScheduledTaskRecurrence string
+
+const (
+	ScheduledTaskRecurrenceUnknown         ScheduledTaskRecurrence = "RECURRENCE_UNKNOWN"
+	ScheduledTaskRecurrenceCalendar        ScheduledTaskRecurrence = "CALENDAR"
+	ScheduledTaskRecurrenceAfterCompletion ScheduledTaskRecurrence = "AFTER_COMPLETION"
+)
+
 type ScheduledTask struct {
-	ID          string
-	Title       string
-	Description string
-	Parent      string
-	Expression  string
+	ID             string
+	Title          string
+	Description    string
+	Parent         string
+	Expression     string
+	ExpressionType string
+	Paused         bool
+	Created        int64
+	Recurrence     ScheduledTaskRecurrence
+	SkipIfOpen     bool
+	Timezone       string
+	TemplateID     string
 }
 
 func (t *ScheduledTask) ToProto() *proto.ScheduledTask {
@@ -78,40 +119,258 @@ func (t *ScheduledTask) ToProto() *proto.ScheduledTask {
 		Description: t.Description,
 		Parent:      t.Parent,
 		Expression:  t.Expression,
+		ExpressionType: proto.ScheduledTask_ExpressionType(
+			proto.ScheduledTask_ExpressionType_value[t.ExpressionType]),
+		Paused:  t.Paused,
+		Created: t.Created,
+		Recurrence: proto.ScheduledTask_Recurrence(
+			proto.ScheduledTask_Recurrence_value[string(t.Recurrence)]),
+		SkipIfOpen: t.SkipIfOpen,
+		Timezone:   t.Timezone,
+		TemplateId: t.TemplateID,
 	}
 }
 
 // Returns a storage layer model from a domain-layer model.
 func (t *ScheduledTask) ToStorage() *storage.ScheduledTask {
 	return &storage.ScheduledTask{
+		ID:             t.ID,
+		Title:          t.Title,
+		Description:    t.Description,
+		Parent:         t.Parent,
+		Expression:     t.Expression,
+		ExpressionType: t.ExpressionType,
+		Paused:         t.Paused,
+		Created:        t.Created,
+		Recurrence:     string(t.Recurrence),
+		SkipIfOpen:     t.SkipIfOpen,
+		Timezone:       t.Timezone,
+		TemplateID:     t.TemplateID,
+	}
+}
+
+func NewScheduledTask(id, title, description, parent, expression, expressionType string) *ScheduledTask {
+	return &ScheduledTask{
+		ID:             id,
+		Title:          title,
+		Description:    description,
+		Parent:         parent,
+		Expression:     expression,
+		ExpressionType: expressionType,
+		Created:        time.Now().UnixMilli(),
+		Recurrence:     ScheduledTaskRecurrenceCalendar,
+	}
+}
+
+// Operation is an entry in the journal of changes made to tasks.
+type Operation struct {
+	ID          string
+	Kind        OperationKind
+	Description string
+	// Every task the operation touched as it was before the operation.
+	Before []storage.Task
+	// The attachments and comments on tasks the operation deleted.
+	Attachments []storage.Attachment
+	Comments    []storage.TaskComment
+	Created     int64
+	Undone      bool
+}
+
+// Returns a storage layer model from a domain-layer model.
+func (o *Operation) ToStorage() *storage.Operation {
+	return &storage.Operation{
+		ID:          o.ID,
+		Kind:        string(o.Kind),
+		Description: o.Description,
+		Tasks:       o.Before,
+		Attachments: o.Attachments,
+		Comments:    o.Comments,
+		Created:     o.Created,
+		Undone:      o.Undone,
+	}
+}
+
+func NewOperation(id string, kind OperationKind, description string, before []storage.Task, created int64) *Operation {
+	return &Operation{
+		ID:          id,
+		Kind:        kind,
+		Description: description,
+		Before:      before,
+		Created:     created,
+	}
+}
+
+// TaskComment is an entry in a task's running log of comments.
+type TaskComment struct {
+	ID      string
+	TaskID  string
+	Author  string
+	Text    string
+	Created int64
+}
+
+// Returns a storage layer model from a domain-layer model.
+func (c *TaskComment) ToStorage() *storage.TaskComment {
+	return &storage.TaskComment{
+		ID:      c.ID,
+		TaskID:  c.TaskID,
+		Author:  c.Author,
+		Text:    c.Text,
+		Created: c.Created,
+	}
+}
+
+func NewTaskComment(id, taskID, author, text string) *TaskComment {
+	return &TaskComment{
+		ID:      id,
+		TaskID:  taskID,
+		Author:  author,
+		Text:    text,
+		Created: time.Now().UnixMilli(),
+	}
+}
+
+// Attachment is a file attached to a task.
+type Attachment struct {
+	ID      string
+	TaskID  string
+	Name    string
+	Size    int64
+	Digest  string
+	Created int64
+}
+
+// Returns a storage layer model from a domain-layer model.
+func (a *Attachment) ToStorage() *storage.Attachment {
+	return &storage.Attachment{
+		ID:      a.ID,
+		TaskID:  a.TaskID,
+		Name:    a.Name,
+		Size:    a.Size,
+		Digest:  a.Digest,
+		Created: a.Created,
+	}
+}
+
+func NewAttachment(id, taskID, name string, size int64, digest string) *Attachment {
+	return &Attachment{
+		ID:      id,
+		TaskID:  taskID,
+		Name:    name,
+		Size:    size,
+		Digest:  digest,
+		Created: time.Now().UnixMilli(),
+	}
+}
+
+type Template struct {
+	ID          string
+	Name        string
+	Description string
+	Tasks       []TemplateTask
+	Created     int64
+}
+
+type TemplateTask struct {
+	Title       string
+	Description string
+	Children    []TemplateTask
+}
+
+func (t *Template) ToProto() *proto.Template {
+	return &proto.Template{
+		Id:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		Tasks:       templateTasksToProto(t.Tasks),
+		Created:     t.Created,
+	}
+}
+
+// Returns a storage layer model from a domain-layer model.
+func (t *Template) ToStorage() *storage.Template {
+	return &storage.Template{
 		ID:          t.ID,
-		Title:       t.Title,
+		Name:        t.Name,
 		Description: t.Description,
-		Parent:      t.Parent,
-		Expression:  t.Expression,
+		Tasks:       templateTasksToStorage(t.Tasks),
+		Created:     t.Created,
 	}
 }
 
-func NewScheduledTask(title, description, parent, expression string) *ScheduledTask {
-	return &ScheduledTask{
-		ID:          string(generateRandString(3)),
-		Title:       title,
+func NewTemplate(id, name, description string, tasks []*proto.TemplateTask) *Template {
+	return &Template{
+		ID:          id,
+		Name:        name,
 		Description: description,
-		Parent:      parent,
-		Expression:  expression,
+		Tasks:       NewTemplateTasks(tasks),
+		Created:     time.Now().UnixMilli(),
 	}
 }
 
-// generateRandString generates a variable length string; can be used for ids
-func generateRandString(length int) []byte {
-	const charset = "abcdefghijklmnopqrstuvwxyz" + "0123456789"
+// NewTemplateTasks returns a domain-layer model of the task trees given.
+func NewTemplateTasks(tasks []*proto.TemplateTask) []TemplateTask {
+	newTasks := []TemplateTask{}
+	for _, task := range tasks {
+		newTasks = append(newTasks, TemplateTask{
+			Title:       task.Title,
+			Description: task.Description,
+			Children:    NewTemplateTasks(task.Children),
+		})
+	}
+	return newTasks
+}
+
+func templateTasksToProto(tasks []TemplateTask) []*proto.TemplateTask {
+	protoTasks := []*proto.TemplateTask{}
+	for _, task := range tasks {
+		protoTasks = append(protoTasks, &proto.TemplateTask{
+			Title:       task.Title,
+			Description: task.Description,
+			Children:    templateTasksToProto(task.Children),
+		})
+	}
+	return protoTasks
+}
+
+func templateTasksToStorage(tasks []TemplateTask) storage.TemplateTasks {
+	storageTasks := storage.TemplateTasks{}
+	for _, task := range tasks {
+		storageTasks = append(storageTasks, storage.TemplateTask{
+			Title:       task.Title,
+			Description: task.Description,
+			Children:    templateTasksToStorage(task.Children),
+		})
+	}
+	return storageTasks
+}
 
-	seededRand := rand.New(
-		rand.NewSource(time.Now().UnixNano()))
+const idCharset = "0123456789abcdefghijklmnopqrstuvwxyz"
 
+// sortableIDTimeLength is the number of characters used to encode the creation time of a sortable ID. Nine base36
+// characters of unix milliseconds covers a few thousand years so the prefix never changes length.
+const sortableIDTimeLength = 9
+
+// NewID generates a random ID made up of length lowercase letters and digits. If sortable is set the ID is prefixed
+// with the current time so that IDs sort in the order they were created.
+func NewID(length int, sortable bool) string {
+	id := generateRandString(length)
+
+	if !sortable {
+		return string(id)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().UnixMilli(), len(idCharset))
+	padding := strings.Repeat("0", max(sortableIDTimeLength-len(timestamp), 0))
+
+	return padding + timestamp + string(id)
+}
+
+// generateRandString generates a variable length string; can be used for ids
+func generateRandString(length int) []byte {
 	b := make([]byte, length)
 	for i := range b {
-		b[i] = charset[seededRand.Intn(len(charset))]
+		b[i] = idCharset[rand.IntN(len(idCharset))]
 	}
 
 	return b
@@ -0,0 +1,200 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/clintjedwards/avail/v2"
+	"github.com/robfig/cron/v3"
+)
+
+// calendar is a Schedule described by the set of minutes, hours, days, months and years it fires in, which is how
+// both avail and cron expressions work. It fires once in every minute that matches.
+type calendar struct {
+	minutes []int // sorted
+	hours   []int // sorted
+	day     func(t time.Time) bool
+	month   func(month time.Month) bool
+	year    func(year int) bool
+
+	// The time zone the minutes, hours and days are in.
+	location *time.Location
+}
+
+func parseAvail(expression string, location *time.Location) (*calendar, error) {
+	timeframe, err := avail.New(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	expr := timeframe.ParsedExpression
+
+	return &calendar{
+		minutes: sortedValues(expr.Minutes.Values),
+		hours:   sortedValues(expr.Hours.Values),
+		day: func(t time.Time) bool {
+			_, dayOk := expr.Days.Values[t.Day()]
+			_, weekdayOk := expr.Weekdays.Values[int(t.Weekday())]
+			return dayOk && weekdayOk
+		},
+		month: func(month time.Month) bool {
+			_, ok := expr.Months.Values[int(month)]
+			return ok
+		},
+		year: func(year int) bool {
+			_, ok := expr.Years.Values[year]
+			return ok
+		},
+		location: location,
+	}, nil
+}
+
+// cronStarBit is set by the cron parser on fields that were given as "*" or "?".
+const cronStarBit = 1 << 63
+
+// parseCron parses a standard cron expression. Expressions can name their own time zone with a TZ= or CRON_TZ=
+// prefix; otherwise they're in the location given.
+func parseCron(expression string, location *time.Location) (*calendar, error) {
+	if strings.HasPrefix(strings.TrimSpace(expression), "@every") {
+		return nil, fmt.Errorf("@every is not supported; use a natural language expression like \"every 2 hours\" instead")
+	}
+
+	parsed, err := cron.ParseStandard(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, ok := parsed.(*cron.SpecSchedule)
+	if !ok {
+		return nil, fmt.Errorf("unsupported cron expression %q", expression)
+	}
+
+	upper := strings.ToUpper(strings.TrimSpace(expression))
+	if strings.HasPrefix(upper, "TZ=") || strings.HasPrefix(upper, "CRON_TZ=") {
+		location = spec.Location
+	}
+
+	return &calendar{
+		minutes: bitValues(spec.Minute, 0, 59),
+		hours:   bitValues(spec.Hour, 0, 23),
+		// Standard cron matches a day if either the day of month or weekday matches, unless one of them is a wildcard.
+		day: func(t time.Time) bool {
+			domMatch := spec.Dom&(1<<uint(t.Day())) > 0
+			dowMatch := spec.Dow&(1<<uint(t.Weekday())) > 0
+			if spec.Dom&cronStarBit > 0 || spec.Dow&cronStarBit > 0 {
+				return domMatch && dowMatch
+			}
+			return domMatch || dowMatch
+		},
+		month: func(month time.Month) bool {
+			return spec.Month&(1<<uint(month)) > 0
+		},
+		year: func(year int) bool {
+			return true
+		},
+		location: location,
+	}, nil
+}
+
+func (c *calendar) Next(after time.Time) (time.Time, bool) {
+	after = after.In(c.location).Truncate(time.Minute)
+	day := startOfDay(after)
+
+	for day.Year() <= maxYear {
+		if !c.year(day.Year()) {
+			day = time.Date(day.Year()+1, time.January, 1, 0, 0, 0, 0, day.Location())
+			continue
+		}
+
+		if !c.month(day.Month()) {
+			day = time.Date(day.Year(), day.Month()+1, 1, 0, 0, 0, 0, day.Location())
+			continue
+		}
+
+		if c.day(day) {
+			for _, hour := range c.hours {
+				for _, minute := range c.minutes {
+					candidate, ok := c.at(day, hour, minute)
+					if ok && candidate.After(after) {
+						return candidate, true
+					}
+				}
+			}
+		}
+
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return time.Time{}, false
+}
+
+func (c *calendar) Prev(before time.Time) (time.Time, bool) {
+	// Anything within the minute given counts as that minute so it can't be before it.
+	before = before.In(c.location)
+	if before.Truncate(time.Minute).Equal(before) {
+		before = before.Add(-time.Minute)
+	}
+	before = before.Truncate(time.Minute)
+	day := startOfDay(before)
+
+	for day.Year() >= minYear {
+		if !c.year(day.Year()) {
+			day = time.Date(day.Year(), time.January, 0, 0, 0, 0, 0, day.Location())
+			continue
+		}
+
+		if !c.month(day.Month()) {
+			day = time.Date(day.Year(), day.Month(), 0, 0, 0, 0, 0, day.Location())
+			continue
+		}
+
+		if c.day(day) {
+			for i := len(c.hours) - 1; i >= 0; i-- {
+				for j := len(c.minutes) - 1; j >= 0; j-- {
+					candidate, ok := c.at(day, c.hours[i], c.minutes[j])
+					if ok && !candidate.After(before) {
+						return candidate, true
+					}
+				}
+			}
+		}
+
+		day = day.AddDate(0, 0, -1)
+	}
+
+	return time.Time{}, false
+}
+
+// at returns the time at the hour and minute given on day. It returns false if the time falls on another day, which
+// can only happen when clocks skip forward around midnight.
+func (c *calendar) at(day time.Time, hour, minute int) (time.Time, bool) {
+	t := wallClock(day.Year(), day.Month(), day.Day(), hour, minute, day.Location())
+	return t, t.Day() == day.Day()
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func sortedValues(set map[int]struct{}) []int {
+	values := make([]int, 0, len(set))
+	for value := range set {
+		values = append(values, value)
+	}
+	sort.Ints(values)
+
+	return values
+}
+
+func bitValues(bits uint64, min, max int) []int {
+	values := []int{}
+	for i := min; i <= max; i++ {
+		if bits&(1<<uint(i)) > 0 {
+			values = append(values, i)
+		}
+	}
+
+	return values
+}
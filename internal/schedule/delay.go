@@ -0,0 +1,185 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedules that repeat relative to when a task is completed are described by a delay rather than a calendar. Delays
+// can be written as a Go duration (36h, 90m) or in plain English:
+//
+//	[every] [N|other|a|an] minute(s)|hour(s)|day(s)|week(s)|month(s)|year(s) [after completion]
+//	hourly|daily|weekly|monthly|yearly [after completion]
+//
+// Days and longer are calendar units so that "every day after completion" creates the next task at the same time of
+// day the previous one was completed even across daylight saving changes.
+
+// completionWords are the words allowed after "after" in a delay, covering phrasings like "after completion",
+// "after it's done" and "after I finish it".
+var completionWords = map[string]bool{
+	"completion": true, "completing": true, "completed": true, "complete": true,
+	"finishing": true, "finished": true, "finish": true, "done": true,
+	"it": true, "it's": true, "its": true, "is": true, "i": true, "the": true, "task": true,
+}
+
+var shortcutUnits = map[string]string{
+	"hourly":   "hour",
+	"daily":    "day",
+	"weekly":   "week",
+	"monthly":  "month",
+	"yearly":   "year",
+	"annually": "year",
+}
+
+// Delay is how long to wait after a task is completed before creating the next one.
+type Delay struct {
+	months   int
+	days     int
+	duration time.Duration
+}
+
+// After returns the time the delay ends if it begins at the time given.
+func (d Delay) After(t time.Time) time.Time {
+	return t.AddDate(0, d.months, d.days).Add(d.duration)
+}
+
+func (d Delay) String() string {
+	switch {
+	case d.months != 0 && d.months%12 == 0:
+		return pluralize(d.months/12, "year")
+	case d.months != 0:
+		return pluralize(d.months, "month")
+	case d.days != 0 && d.days%7 == 0:
+		return pluralize(d.days/7, "week")
+	case d.days != 0:
+		return pluralize(d.days, "day")
+	default:
+		return d.duration.String()
+	}
+}
+
+// IsAfterCompletion reports whether the expression describes a schedule relative to when a task is completed, like
+// "every 3 days after completion", rather than a calendar.
+func IsAfterCompletion(expression string) bool {
+	words := delayWords(expression)
+
+	for i, word := range words {
+		if word == "after" {
+			return isCompletionPhrase(words[i+1:])
+		}
+	}
+
+	return false
+}
+
+// ParseDelay parses how long to wait after a task is completed before creating the next one.
+func ParseDelay(expression string) (Delay, error) {
+	trimmed := strings.TrimSpace(expression)
+	if trimmed == "" {
+		return Delay{}, fmt.Errorf("expression is empty")
+	}
+
+	if duration, err := time.ParseDuration(trimmed); err == nil {
+		if duration < time.Minute {
+			return Delay{}, fmt.Errorf("delay %q must be at least a minute", expression)
+		}
+		return Delay{duration: duration}, nil
+	}
+
+	words := delayWords(expression)
+	for i, word := range words {
+		if word != "after" {
+			continue
+		}
+
+		if !isCompletionPhrase(words[i+1:]) {
+			return Delay{}, fmt.Errorf("could not understand %q: expected \"after completion\"", expression)
+		}
+
+		words = words[:i]
+		break
+	}
+
+	if len(words) > 0 && (words[0] == "every" || words[0] == "each") {
+		words = words[1:]
+	}
+
+	if len(words) == 1 {
+		if unit, ok := shortcutUnits[words[0]]; ok {
+			return newDelay(1, unit), nil
+		}
+	}
+
+	count := 1
+	if len(words) == 2 {
+		switch words[0] {
+		case "a", "an":
+		case "other":
+			count = 2
+		default:
+			n, err := strconv.Atoi(words[0])
+			if err != nil || n < 1 {
+				return Delay{}, fmt.Errorf("could not understand %q: expected a number before %q", expression, words[1])
+			}
+			count = n
+		}
+		words = words[1:]
+	}
+
+	if len(words) != 1 {
+		return Delay{}, fmt.Errorf("could not understand %q: expected something like \"3 days after completion\"",
+			expression)
+	}
+
+	unit := strings.TrimSuffix(words[0], "s")
+	if _, ok := units[unit]; !ok {
+		return Delay{}, fmt.Errorf("could not understand %q: expected minutes, hours, days, weeks, months or years",
+			expression)
+	}
+
+	return newDelay(count, unit), nil
+}
+
+func newDelay(count int, unit string) Delay {
+	switch unit {
+	case "minute":
+		return Delay{duration: time.Duration(count) * time.Minute}
+	case "hour":
+		return Delay{duration: time.Duration(count) * time.Hour}
+	case "day":
+		return Delay{days: count}
+	case "week":
+		return Delay{days: count * 7}
+	case "month":
+		return Delay{months: count}
+	default:
+		return Delay{months: count * 12}
+	}
+}
+
+func delayWords(expression string) []string {
+	return strings.Fields(strings.ToLower(strings.NewReplacer(",", " ", ".", " ").Replace(expression)))
+}
+
+func isCompletionPhrase(words []string) bool {
+	if len(words) == 0 {
+		return false
+	}
+
+	for _, word := range words {
+		if !completionWords[word] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func pluralize(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
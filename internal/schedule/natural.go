@@ -0,0 +1,473 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+// Natural language expressions are translated into recurrence rules. The grammar understood is deliberately small:
+//
+//	every [N|other] minute(s)|hour(s)|day(s)|week(s)|month(s)|year(s) [on ...] [at TIME]
+//	every [N|other] weekday|weekend|monday[, wednesday and friday] [at TIME]
+//	every 1st|2nd|3rd|4th|5th|last monday [of the month] [at TIME]
+//	every january 3rd [at TIME]
+//	hourly|daily|weekly|monthly|yearly [on ...] [at TIME]
+//
+// The "on" clause narrows weekly schedules to weekdays (on monday and thursday), monthly schedules to a day of the
+// month (on the 15th, on the last day, on the 2nd tuesday) and yearly schedules to a date (on march 3rd). TIME is
+// something like 9am, 9:30pm, 17:00, noon or midnight. Schedules repeating daily or less often fire at midnight
+// unless a time is given.
+
+var weekdays = map[string]rrule.Weekday{
+	"monday": rrule.MO, "mon": rrule.MO,
+	"tuesday": rrule.TU, "tue": rrule.TU, "tues": rrule.TU,
+	"wednesday": rrule.WE, "wed": rrule.WE,
+	"thursday": rrule.TH, "thu": rrule.TH, "thurs": rrule.TH,
+	"friday": rrule.FR, "fri": rrule.FR,
+	"saturday": rrule.SA, "sat": rrule.SA,
+	"sunday": rrule.SU, "sun": rrule.SU,
+}
+
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"may":  time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+}
+
+var units = map[string]rrule.Frequency{
+	"minute": rrule.MINUTELY,
+	"hour":   rrule.HOURLY,
+	"day":    rrule.DAILY,
+	"week":   rrule.WEEKLY,
+	"month":  rrule.MONTHLY,
+	"year":   rrule.YEARLY,
+}
+
+var shortcuts = map[string]rrule.Frequency{
+	"hourly":   rrule.HOURLY,
+	"daily":    rrule.DAILY,
+	"weekly":   rrule.WEEKLY,
+	"monthly":  rrule.MONTHLY,
+	"yearly":   rrule.YEARLY,
+	"annually": rrule.YEARLY,
+}
+
+var ordinalWords = map[string]int{
+	"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5, "last": -1,
+}
+
+// naturalParser consumes the words of a natural language expression from left to right.
+type naturalParser struct {
+	expression string
+	words      []string
+	pos        int
+}
+
+func parseNatural(expression string, anchor time.Time) (*recurrence, error) {
+	options, err := parseNaturalOptions(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	options.Dtstart = anchor
+
+	return newRecurrence(options)
+}
+
+func parseNaturalOptions(expression string) (rrule.ROption, error) {
+	normalized := strings.ToLower(strings.NewReplacer(",", " ", ".", " ").Replace(expression))
+	p := &naturalParser{
+		expression: expression,
+		words:      strings.Fields(normalized),
+	}
+
+	if len(p.words) == 0 {
+		return rrule.ROption{}, fmt.Errorf("expression is empty")
+	}
+
+	if p.index("after") != -1 {
+		return rrule.ROption{}, fmt.Errorf("could not understand %q: schedules relative to when a task is completed "+
+			"aren't calendar schedules and must use the after completion recurrence", expression)
+	}
+
+	hour, minute, hasTime, err := p.extractTime()
+	if err != nil {
+		return rrule.ROption{}, err
+	}
+
+	options, err := p.parseRecurrence()
+	if err != nil {
+		return rrule.ROption{}, err
+	}
+
+	if !p.done() {
+		return rrule.ROption{}, p.errorf("unexpected %q", strings.Join(p.words[p.pos:], " "))
+	}
+
+	switch options.Freq {
+	case rrule.MINUTELY, rrule.HOURLY:
+		if hasTime {
+			return rrule.ROption{}, p.errorf("a time of day can't be given for schedules that repeat every few " +
+				"minutes or hours")
+		}
+
+		if options.Freq == rrule.HOURLY {
+			options.Byminute = []int{0}
+		}
+	default:
+		options.Byhour = []int{hour}
+		options.Byminute = []int{minute}
+	}
+
+	return options, nil
+}
+
+// parseRecurrence parses everything but the time of day.
+func (p *naturalParser) parseRecurrence() (rrule.ROption, error) {
+	word := p.next()
+
+	if freq, ok := shortcuts[word]; ok {
+		options := rrule.ROption{Freq: freq, Interval: 1}
+		return options, p.parseOn(&options)
+	}
+
+	if word != "every" && word != "each" {
+		return rrule.ROption{}, p.errorf("expected the expression to start with \"every\"")
+	}
+
+	word = p.next()
+
+	// every other week / every 3 days
+	interval := 1
+	if word == "other" {
+		interval = 2
+		word = p.next()
+	} else if n, err := strconv.Atoi(word); err == nil {
+		if n < 1 {
+			return rrule.ROption{}, p.errorf("the interval must be at least 1")
+		}
+		interval = n
+		word = p.next()
+	}
+
+	if freq, ok := units[strings.TrimSuffix(word, "s")]; ok {
+		options := rrule.ROption{Freq: freq, Interval: interval}
+		return options, p.parseOn(&options)
+	}
+
+	switch strings.TrimSuffix(word, "s") {
+	case "weekday":
+		return rrule.ROption{
+			Freq:      rrule.WEEKLY,
+			Interval:  interval,
+			Byweekday: []rrule.Weekday{rrule.MO, rrule.TU, rrule.WE, rrule.TH, rrule.FR},
+		}, nil
+	case "weekend":
+		p.accept("day", "days")
+		return rrule.ROption{
+			Freq:      rrule.WEEKLY,
+			Interval:  interval,
+			Byweekday: []rrule.Weekday{rrule.SA, rrule.SU},
+		}, nil
+	}
+
+	// every monday and thursday / every other friday
+	if _, ok := parseWeekday(word); ok {
+		p.pos--
+		days, err := p.parseWeekdays()
+		if err != nil {
+			return rrule.ROption{}, err
+		}
+		return rrule.ROption{Freq: rrule.WEEKLY, Interval: interval, Byweekday: days}, nil
+	}
+
+	if interval != 1 {
+		return rrule.ROption{}, p.errorf("expected minutes, hours, days, weeks, months, years or a weekday after %q",
+			p.words[p.pos-2])
+	}
+
+	// every 2nd tuesday of the month
+	if n, ok := parseOrdinal(word); ok && n >= -1 && n <= 5 {
+		day, ok := parseWeekday(p.next())
+		if !ok {
+			return rrule.ROption{}, p.errorf("expected a weekday after %q", word)
+		}
+
+		if p.accept("of") {
+			p.accept("the", "every", "each")
+			if !p.accept("month") {
+				return rrule.ROption{}, p.errorf("expected \"of the month\"")
+			}
+		}
+
+		return rrule.ROption{Freq: rrule.MONTHLY, Byweekday: []rrule.Weekday{day.Nth(n)}}, nil
+	}
+
+	// every march 3rd
+	if month, ok := months[word]; ok {
+		day, ok := parseOrdinal(p.next())
+		if !ok || day < 1 || day > 31 {
+			return rrule.ROption{}, p.errorf("expected a day of the month after %q", word)
+		}
+
+		return rrule.ROption{Freq: rrule.YEARLY, Bymonth: []int{int(month)}, Bymonthday: []int{day}}, nil
+	}
+
+	return rrule.ROption{}, p.errorf("did not recognize %q", word)
+}
+
+// parseOn parses an optional clause narrowing down when within each period the schedule fires.
+func (p *naturalParser) parseOn(options *rrule.ROption) error {
+	if !p.accept("on") {
+		return nil
+	}
+
+	switch options.Freq {
+	case rrule.DAILY, rrule.WEEKLY:
+		// every week on monday and friday
+		days, err := p.parseWeekdays()
+		if err != nil {
+			return err
+		}
+		options.Freq = rrule.WEEKLY
+		options.Byweekday = days
+		return nil
+
+	case rrule.MONTHLY:
+		p.accept("the")
+
+		n, ok := parseOrdinal(p.next())
+		if !ok {
+			return p.errorf("expected a day of the month after \"on\"")
+		}
+
+		// every month on the 2nd tuesday
+		if day, ok := parseWeekday(p.peek()); ok {
+			p.next()
+			if n < -1 || n > 5 || n == 0 {
+				return p.errorf("a month only has up to five of each weekday")
+			}
+			options.Byweekday = []rrule.Weekday{day.Nth(n)}
+			return nil
+		}
+
+		// every month on the 15th / on the last day
+		p.accept("day")
+		if n < -1 || n > 31 || n == 0 {
+			return p.errorf("a month only has up to 31 days")
+		}
+		options.Bymonthday = []int{n}
+		return nil
+
+	case rrule.YEARLY:
+		// every year on march 3rd
+		month, ok := months[p.next()]
+		if !ok {
+			return p.errorf("expected a month after \"on\"")
+		}
+
+		day, ok := parseOrdinal(p.next())
+		if !ok || day < 1 || day > 31 {
+			return p.errorf("expected a day of the month after the month")
+		}
+
+		options.Bymonth = []int{int(month)}
+		options.Bymonthday = []int{day}
+		return nil
+
+	default:
+		return p.errorf("\"on\" can't be used for schedules that repeat every few minutes or hours")
+	}
+}
+
+// parseWeekdays parses a list of weekdays like "monday, wednesday and friday".
+func (p *naturalParser) parseWeekdays() ([]rrule.Weekday, error) {
+	days := []rrule.Weekday{}
+
+	for {
+		day, ok := parseWeekday(p.next())
+		if !ok {
+			return nil, p.errorf("expected a weekday")
+		}
+		days = append(days, day)
+
+		if _, ok := parseWeekday(p.peek()); ok {
+			continue
+		}
+
+		if p.peek() == "and" {
+			p.next()
+			continue
+		}
+
+		return days, nil
+	}
+}
+
+// extractTime finds and removes the time of day from the expression, wherever it is. Times are introduced by "at",
+// with the exception of noon and midnight.
+func (p *naturalParser) extractTime() (hour, minute int, found bool, err error) {
+	for i, word := range p.words {
+		if word != "at" && word != "noon" && word != "midnight" {
+			continue
+		}
+
+		start, end := i, i+1
+		if word == "at" {
+			if end >= len(p.words) {
+				return 0, 0, false, p.errorf("expected a time after \"at\"")
+			}
+			word = p.words[end]
+			end++
+		}
+
+		// Allow "9 am" as well as "9am".
+		if end < len(p.words) && (p.words[end] == "am" || p.words[end] == "pm") {
+			word += p.words[end]
+			end++
+		}
+
+		hour, minute, err := parseTimeOfDay(word)
+		if err != nil {
+			return 0, 0, false, p.errorf("%v", err)
+		}
+
+		p.words = append(p.words[:start:start], p.words[end:]...)
+		return hour, minute, true, nil
+	}
+
+	return 0, 0, false, nil
+}
+
+// parseTimeOfDay parses times like 9am, 9:30pm, 17:00, noon and midnight.
+func parseTimeOfDay(word string) (hour, minute int, err error) {
+	switch word {
+	case "noon":
+		return 12, 0, nil
+	case "midnight":
+		return 0, 0, nil
+	}
+
+	suffix := ""
+	if strings.HasSuffix(word, "am") || strings.HasSuffix(word, "pm") {
+		suffix = word[len(word)-2:]
+		word = word[:len(word)-2]
+	}
+
+	hourStr, minuteStr, hasMinute := strings.Cut(word, ":")
+
+	hour, err = strconv.Atoi(hourStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%q is not a time of day", word+suffix)
+	}
+
+	if hasMinute {
+		minute, err = strconv.Atoi(minuteStr)
+		if err != nil || len(minuteStr) != 2 || minute > 59 {
+			return 0, 0, fmt.Errorf("%q is not a time of day", word+suffix)
+		}
+	} else if suffix == "" {
+		// A bare number is too easy to mistake for something else, like the day of the month.
+		return 0, 0, fmt.Errorf("%q is not a time of day; try %sam or %spm", word, word, word)
+	}
+
+	switch suffix {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, fmt.Errorf("%q is not a time of day", word+suffix)
+		}
+		hour %= 12
+		if suffix == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return 0, 0, fmt.Errorf("%q is not a time of day", word)
+		}
+	}
+
+	return hour, minute, nil
+}
+
+func parseWeekday(word string) (rrule.Weekday, bool) {
+	day, ok := weekdays[strings.TrimSuffix(word, "s")]
+	if !ok {
+		day, ok = weekdays[word]
+	}
+	return day, ok
+}
+
+// parseOrdinal parses ordinals like 1st, second, 15th and last, as well as bare numbers. Last is returned as -1.
+func parseOrdinal(word string) (int, bool) {
+	if n, ok := ordinalWords[word]; ok {
+		return n, true
+	}
+
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		word = strings.TrimSuffix(word, suffix)
+	}
+
+	n, err := strconv.Atoi(word)
+	if err != nil {
+		return 0, false
+	}
+
+	return n, true
+}
+
+func (p *naturalParser) next() string {
+	if p.done() {
+		return ""
+	}
+	word := p.words[p.pos]
+	p.pos++
+	return word
+}
+
+func (p *naturalParser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.words[p.pos]
+}
+
+// accept consumes the next word if it is one of the words given.
+func (p *naturalParser) accept(words ...string) bool {
+	for _, word := range words {
+		if p.peek() == word {
+			p.pos++
+			return true
+		}
+	}
+	return false
+}
+
+func (p *naturalParser) done() bool {
+	return p.pos >= len(p.words)
+}
+
+func (p *naturalParser) index(word string) int {
+	for i, w := range p.words {
+		if w == word {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *naturalParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("could not understand %q: %s", p.expression, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,93 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+// recurrence is a Schedule backed by an iCalendar recurrence rule.
+//
+// The rule is evaluated in floating time, wall clock times with no zone, and only then placed in its location. Left to
+// itself the rrule library places times skipped by daylight saving wherever time.Date happens to put them, which can be
+// before the previous fire time.
+type recurrence struct {
+	rule     *rrule.RRule
+	location *time.Location
+}
+
+func parseRRule(expression string, anchor time.Time) (*recurrence, error) {
+	options, err := rrule.StrToROption(expression)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse rrule %q: %w", expression, err)
+	}
+
+	// Without a start the rule would be counted from whenever it happened to be parsed, which moves every time the
+	// service restarts.
+	if options.Dtstart.IsZero() {
+		options.Dtstart = anchor
+	}
+
+	return newRecurrence(*options)
+}
+
+// newRecurrence builds a recurrence from the options given. The rule is evaluated in the location of Dtstart.
+func newRecurrence(options rrule.ROption) (*recurrence, error) {
+	location := options.Dtstart.Location()
+	options.Dtstart = floating(options.Dtstart)
+	if !options.Until.IsZero() {
+		options.Until = floating(options.Until.In(location))
+	}
+
+	if options.Freq == rrule.SECONDLY {
+		return nil, fmt.Errorf("schedules can't fire more than once a minute")
+	}
+
+	if len(options.Bysecond) == 0 {
+		options.Bysecond = []int{0}
+	}
+
+	if len(options.Bysecond) > 1 {
+		return nil, fmt.Errorf("schedules can't fire more than once a minute")
+	}
+
+	rule, err := rrule.NewRRule(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recurrence{rule: rule, location: location}, nil
+}
+
+func (r *recurrence) Next(after time.Time) (time.Time, bool) {
+	// A floating time can land at or before the time given once placed in its location, when clocks go back or a
+	// skipped time is moved forward, so we keep going until we pass it.
+	for next := r.rule.After(floating(after.In(r.location)), false); !next.IsZero(); next = r.rule.After(next, false) {
+		if t := r.local(next); t.After(after) {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (r *recurrence) Prev(before time.Time) (time.Time, bool) {
+	for prev := r.rule.Before(floating(before.In(r.location)), false); !prev.IsZero(); prev = r.rule.Before(prev, false) {
+		if t := r.local(prev); t.Before(before) {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// local places a floating time in the recurrence's location.
+func (r *recurrence) local(t time.Time) time.Time {
+	return wallClock(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), r.location)
+}
+
+// floating returns the wall clock time of t as if it were in UTC.
+func floating(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
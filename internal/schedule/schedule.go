@@ -0,0 +1,155 @@
+// Package schedule computes when scheduled tasks are due to fire.
+//
+// Scheduled tasks can be described by a handful of expression kinds. Every kind is parsed into a Schedule which
+// answers the only questions the scheduler and its users need answered: when does it fire next and when did it last
+// fire.
+package schedule
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// The range of years we're willing to search for fire times in. This keeps expressions that can never match,
+	// like the 31st of February, from searching forever.
+	minYear = 1970
+	maxYear = 2100
+)
+
+// Kind is the type of expression a schedule was described with.
+type Kind string
+
+const (
+	// KindUnknown means the kind should be detected from the expression itself.
+	KindUnknown Kind = "EXPRESSION_TYPE_UNKNOWN"
+
+	// KindAvail is the six field cron subset understood by avail: minute hour day month weekday year.
+	// https://github.com/clintjedwards/avail
+	KindAvail Kind = "AVAIL"
+
+	// KindCron is the standard five field cron syntax: minute hour day month weekday. Month and weekday names, steps
+	// and descriptors like @daily are supported.
+	KindCron Kind = "CRON"
+
+	// KindRRule is an iCalendar (RFC 5545) recurrence rule. Ex: FREQ=MONTHLY;BYDAY=+2TU;BYHOUR=9;BYMINUTE=0
+	KindRRule Kind = "RRULE"
+
+	// KindNatural is a plain English description. Ex: every 2nd tuesday at 9am
+	KindNatural Kind = "NATURAL"
+)
+
+// Kinds returns every kind of expression that can be parsed.
+func Kinds() []Kind {
+	return []Kind{KindAvail, KindCron, KindRRule, KindNatural}
+}
+
+// Schedule describes the times at which a scheduled task fires. Schedules have minute granularity.
+type Schedule interface {
+	// Next returns the first time strictly after the time given that the schedule fires. It returns false if the
+	// schedule never fires again.
+	Next(after time.Time) (time.Time, bool)
+
+	// Prev returns the last time strictly before the time given that the schedule fired. It returns false if the
+	// schedule has never fired.
+	Prev(before time.Time) (time.Time, bool)
+}
+
+// Parse parses an expression of the kind given into a Schedule. If kind is KindUnknown it is detected from the
+// expression. The anchor is when the schedule was created; schedules that repeat on an interval, like "every 3 weeks",
+// count their intervals from it. Fire times are worked out in the anchor's time zone.
+func Parse(kind Kind, expression string, anchor time.Time) (Schedule, error) {
+	if kind == KindUnknown || kind == "" {
+		kind = Detect(expression)
+	}
+
+	anchor = anchor.Truncate(time.Minute)
+
+	switch kind {
+	case KindAvail:
+		return parseAvail(expression, anchor.Location())
+	case KindCron:
+		return parseCron(expression, anchor.Location())
+	case KindRRule:
+		return parseRRule(expression, anchor)
+	case KindNatural:
+		return parseNatural(expression, anchor)
+	default:
+		return nil, fmt.Errorf("expression type %q not recognized", kind)
+	}
+}
+
+var cronFieldRegex = regexp.MustCompile(`^([0-9*?]+|[a-zA-Z]{3})([,/-]([0-9*?]+|[a-zA-Z]{3}))*$`)
+
+// Detect guesses the kind of an expression from its shape. Anything that doesn't look like one of the more formal
+// kinds is assumed to be natural language.
+func Detect(expression string) Kind {
+	expression = strings.TrimSpace(expression)
+	upper := strings.ToUpper(expression)
+
+	switch {
+	case strings.HasPrefix(upper, "RRULE:"), strings.HasPrefix(upper, "FREQ="), strings.HasPrefix(upper, "DTSTART"):
+		return KindRRule
+	case strings.HasPrefix(expression, "@"), strings.HasPrefix(upper, "TZ="), strings.HasPrefix(upper, "CRON_TZ="):
+		return KindCron
+	}
+
+	fields := strings.Fields(expression)
+	for _, field := range fields {
+		if !cronFieldRegex.MatchString(field) {
+			return KindNatural
+		}
+	}
+
+	switch len(fields) {
+	case 6:
+		return KindAvail
+	case 5:
+		return KindCron
+	default:
+		return KindNatural
+	}
+}
+
+// wallClock returns the time at the wall clock time given in location.
+//
+// Daylight saving transitions are handled so that a schedule neither misses nor repeats a day: wall clock times
+// skipped when clocks go forward happen after the jump instead, and times repeated when clocks go back only happen the
+// first time around. Schedules only accept fire times strictly after the last, which keeps a skipped time that lands on
+// another fire time from firing twice.
+func wallClock(year int, month time.Month, day, hour, minute int, location *time.Location) time.Time {
+	t := time.Date(year, month, day, hour, minute, 0, 0, location)
+
+	// time.Date doesn't promise which side of a transition skipped or repeated times land on so we pin them down.
+	switch {
+	case t.Hour() != hour || t.Minute() != minute:
+		// The wall clock time doesn't exist. Reading it with the offset from before the clocks jumped moves it
+		// forward by however much they jumped.
+		_, offset := time.Date(year, month, day, hour, minute, 0, 0, location).Add(-24 * time.Hour).Zone()
+		t = time.Date(year, month, day, hour, minute, 0, 0, time.FixedZone("", offset)).In(location)
+	case t.Add(-time.Hour).Hour() == hour && t.Add(-time.Hour).Minute() == minute:
+		// The wall clock time happens twice and time.Date gave us the second.
+		t = t.Add(-time.Hour)
+	}
+
+	return t
+}
+
+// Upcoming returns up to n times after the time given that the schedule fires, in order.
+func Upcoming(schedule Schedule, after time.Time, n int) []time.Time {
+	times := []time.Time{}
+
+	for len(times) < n {
+		next, ok := schedule.Next(after)
+		if !ok {
+			break
+		}
+
+		times = append(times, next)
+		after = next
+	}
+
+	return times
+}
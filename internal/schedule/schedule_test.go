@@ -0,0 +1,380 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// anchor is the time schedules in tests were created at; a Friday.
+var anchor = time.Date(2024, time.March, 1, 8, 17, 0, 0, time.UTC)
+
+func date(month time.Month, day, hour, minute int) time.Time {
+	return time.Date(2024, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestDetect(t *testing.T) {
+	tests := map[string]Kind{
+		"* * * * * *":                 KindAvail,
+		"0 0 1 * * 2030":              KindAvail,
+		"*/15 9 * * mon-fri":          KindCron,
+		"0 0 1 * *":                   KindCron,
+		"@daily":                      KindCron,
+		"FREQ=DAILY;BYHOUR=9":         KindRRule,
+		"RRULE:FREQ=DAILY":            KindRRule,
+		"every 2nd tuesday at 9am":    KindNatural,
+		"every day":                   KindNatural,
+		"daily":                       KindNatural,
+		"mon tue wed thu fri sat sun": KindNatural,
+	}
+
+	for expression, want := range tests {
+		if got := Detect(expression); got != want {
+			t.Errorf("unexpected kind detected for %q; got %s; want %s", expression, got, want)
+		}
+	}
+}
+
+func TestUpcoming(t *testing.T) {
+	tests := map[string]struct {
+		kind       Kind
+		expression string
+		after      time.Time
+		want       []time.Time
+	}{
+		"every minute": {
+			kind:       KindAvail,
+			expression: "* * * * * *",
+			after:      time.Date(2024, time.March, 1, 10, 0, 30, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, time.March, 1, 10, 1, 0, 0, time.UTC),
+				time.Date(2024, time.March, 1, 10, 2, 0, 0, time.UTC),
+				time.Date(2024, time.March, 1, 10, 3, 0, 0, time.UTC),
+			},
+		},
+		"first of the month": {
+			kind:       KindAvail,
+			expression: "0 0 1 * * *",
+			after:      time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		"weekday mornings": {
+			kind:       KindAvail,
+			expression: "30 9 * * 1-5 *",
+			after:      time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC), // A Friday
+			want: []time.Time{
+				time.Date(2024, time.March, 4, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, time.March, 5, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, time.March, 6, 9, 30, 0, 0, time.UTC),
+			},
+		},
+		"leap days only": {
+			kind:       KindAvail,
+			expression: "0 12 29 2 * *",
+			after:      time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2028, time.February, 29, 12, 0, 0, 0, time.UTC),
+				time.Date(2032, time.February, 29, 12, 0, 0, 0, time.UTC),
+				time.Date(2036, time.February, 29, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		"ends": {
+			kind:       KindAvail,
+			expression: "0 0 1 1 * 2030",
+			after:      time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		"cron steps and names": {
+			kind:       KindCron,
+			expression: "*/15 9 * * mon-fri",
+			after:      date(time.March, 1, 12, 0),
+			want:       []time.Time{date(time.March, 4, 9, 0), date(time.March, 4, 9, 15), date(time.March, 4, 9, 30)},
+		},
+		"cron day of month or weekday": {
+			kind:       KindCron,
+			expression: "0 0 13 * fri",
+			after:      date(time.March, 2, 0, 0),
+			want:       []time.Time{date(time.March, 8, 0, 0), date(time.March, 13, 0, 0), date(time.March, 15, 0, 0)},
+		},
+		"cron descriptor": {
+			kind:       KindCron,
+			expression: "@daily",
+			after:      date(time.March, 1, 10, 0),
+			want:       []time.Time{date(time.March, 2, 0, 0), date(time.March, 3, 0, 0), date(time.March, 4, 0, 0)},
+		},
+		"rrule": {
+			kind:       KindRRule,
+			expression: "FREQ=MONTHLY;BYDAY=+2TU;BYHOUR=9;BYMINUTE=0",
+			after:      anchor,
+			want:       []time.Time{date(time.March, 12, 9, 0), date(time.April, 9, 9, 0), date(time.May, 14, 9, 0)},
+		},
+		"rrule with start": {
+			kind:       KindRRule,
+			expression: "DTSTART:20240101T070000Z\nRRULE:FREQ=WEEKLY;INTERVAL=2",
+			after:      anchor,
+			want:       []time.Time{date(time.March, 11, 7, 0), date(time.March, 25, 7, 0), date(time.April, 8, 7, 0)},
+		},
+		"natural nth weekday": {
+			kind:       KindNatural,
+			expression: "every 2nd Tuesday at 9am",
+			after:      anchor,
+			want:       []time.Time{date(time.March, 12, 9, 0), date(time.April, 9, 9, 0), date(time.May, 14, 9, 0)},
+		},
+		"natural interval counts from creation": {
+			kind:       KindNatural,
+			expression: "every 3 weeks",
+			after:      anchor,
+			want:       []time.Time{date(time.March, 22, 0, 0), date(time.April, 12, 0, 0), date(time.May, 3, 0, 0)},
+		},
+		"natural weekdays": {
+			kind:       KindNatural,
+			expression: "every weekday at 7:30pm",
+			after:      anchor,
+			want:       []time.Time{date(time.March, 1, 19, 30), date(time.March, 4, 19, 30), date(time.March, 5, 19, 30)},
+		},
+		"natural every other": {
+			kind:       KindNatural,
+			expression: "every other monday at noon",
+			after:      anchor,
+			want:       []time.Time{date(time.March, 11, 12, 0), date(time.March, 25, 12, 0), date(time.April, 8, 12, 0)},
+		},
+		"natural weekday list": {
+			kind:       KindNatural,
+			expression: "every tuesday, thursday and saturday at 17:00",
+			after:      anchor,
+			want:       []time.Time{date(time.March, 2, 17, 0), date(time.March, 5, 17, 0), date(time.March, 7, 17, 0)},
+		},
+		"natural last day of month": {
+			kind:       KindNatural,
+			expression: "every month on the last day at 6pm",
+			after:      anchor,
+			want:       []time.Time{date(time.March, 31, 18, 0), date(time.April, 30, 18, 0), date(time.May, 31, 18, 0)},
+		},
+		"natural hours": {
+			kind:       KindNatural,
+			expression: "every 2 hours",
+			after:      anchor,
+			want:       []time.Time{date(time.March, 1, 10, 0), date(time.March, 1, 12, 0), date(time.March, 1, 14, 0)},
+		},
+		"natural shortcut": {
+			kind:       KindNatural,
+			expression: "daily at 9 am",
+			after:      anchor,
+			want:       []time.Time{date(time.March, 1, 9, 0), date(time.March, 2, 9, 0), date(time.March, 3, 9, 0)},
+		},
+		"natural yearly": {
+			kind:       KindNatural,
+			expression: "every year on dec 25th at 8am",
+			after:      anchor,
+			want: []time.Time{
+				date(time.December, 25, 8, 0),
+				time.Date(2025, time.December, 25, 8, 0, 0, 0, time.UTC),
+				time.Date(2026, time.December, 25, 8, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := Parse(test.kind, test.expression, anchor)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := Upcoming(schedule, test.after, 3)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("unexpected fire times (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrev(t *testing.T) {
+	tests := map[string]struct {
+		kind       Kind
+		expression string
+		before     time.Time
+		want       time.Time
+		ok         bool
+	}{
+		"same minute counts": {
+			kind:       KindAvail,
+			expression: "* * * * * *",
+			before:     time.Date(2024, time.March, 1, 10, 0, 30, 0, time.UTC),
+			want:       time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC),
+			ok:         true,
+		},
+		"exact minute is excluded": {
+			kind:       KindAvail,
+			expression: "* * * * * *",
+			before:     time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC),
+			want:       time.Date(2024, time.March, 1, 9, 59, 0, 0, time.UTC),
+			ok:         true,
+		},
+		"previous month": {
+			kind:       KindAvail,
+			expression: "0 0 1 * * *",
+			before:     time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC),
+			want:       time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			ok:         true,
+		},
+		"previous year": {
+			kind:       KindAvail,
+			expression: "0 8 25 12 * *",
+			before:     time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC),
+			want:       time.Date(2023, time.December, 25, 8, 0, 0, 0, time.UTC),
+			ok:         true,
+		},
+		"never fired": {
+			kind:       KindAvail,
+			expression: "0 0 1 1 * 2030",
+			before:     time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			ok:         false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := Parse(test.kind, test.expression, anchor)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, ok := schedule.Prev(test.before)
+			if ok != test.ok {
+				t.Fatalf("unexpected result from Prev; got ok=%v; want ok=%v", ok, test.ok)
+			}
+
+			if !got.Equal(test.want) {
+				t.Errorf("unexpected previous fire time; got %v; want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"sometimes",
+		"every blursday",
+		"every day at 25:00",
+		"every day at 9",
+		"every 2 hours at 9am",
+		"every 0 days",
+		"every 3 weeks after completion",
+		"FREQ=SECONDLY",
+		"@every 5m",
+	}
+
+	for _, expression := range tests {
+		_, err := Parse(KindUnknown, expression, anchor)
+		if err == nil {
+			t.Errorf("expected an error parsing %q", expression)
+		}
+	}
+}
+
+func TestParseDelay(t *testing.T) {
+	completed := date(time.March, 9, 18, 30)
+
+	tests := map[string]time.Time{
+		"36h":                              date(time.March, 11, 6, 30),
+		"3 days after completion":          date(time.March, 12, 18, 30),
+		"every other week after it's done": date(time.March, 23, 18, 30),
+		"a month":                          date(time.April, 9, 18, 30),
+		"monthly after completion":         date(time.April, 9, 18, 30),
+		"every 90 minutes":                 date(time.March, 9, 20, 0),
+	}
+
+	for expression, want := range tests {
+		t.Run(expression, func(t *testing.T) {
+			delay, err := ParseDelay(expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := delay.After(completed); !got.Equal(want) {
+				t.Errorf("unexpected end of delay; got %v; want %v", got, want)
+			}
+		})
+	}
+
+	for _, expression := range []string{"", "10s", "every blursday", "3 days after lunch", "every 0 days"} {
+		if _, err := ParseDelay(expression); err == nil {
+			t.Errorf("expected an error parsing %q", expression)
+		}
+	}
+
+	if !IsAfterCompletion("every 3 days after completion") || IsAfterCompletion("every day at 9am") {
+		t.Errorf("after completion expressions not detected correctly")
+	}
+}
+
+func TestDaylightSaving(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	at := func(month time.Month, day, hour, minute int, offset time.Duration) time.Time {
+		return time.Date(2024, month, day, hour, minute, 0, 0, time.FixedZone("", int(offset.Seconds())))
+	}
+
+	springForward := time.Date(2024, time.March, 9, 12, 0, 0, 0, newYork)
+	fallBack := time.Date(2024, time.November, 2, 12, 0, 0, 0, newYork)
+
+	tests := []struct {
+		expression string
+		after      time.Time
+		want       []time.Time
+	}{
+		{
+			// 2:30am doesn't exist on the 10th so it fires an hour later rather than being missed.
+			expression: "30 2 * * *",
+			after:      springForward,
+			want:       []time.Time{at(time.March, 10, 3, 30, -4*time.Hour), at(time.March, 11, 2, 30, -4*time.Hour)},
+		},
+		{
+			expression: "every day at 2:30am",
+			after:      springForward,
+			want:       []time.Time{at(time.March, 10, 3, 30, -4*time.Hour), at(time.March, 11, 2, 30, -4*time.Hour)},
+		},
+		{
+			// 1:30am happens twice on the 3rd but only fires the first time.
+			expression: "30 1 * * *",
+			after:      fallBack,
+			want:       []time.Time{at(time.November, 3, 1, 30, -4*time.Hour), at(time.November, 4, 1, 30, -5*time.Hour)},
+		},
+		{
+			expression: "FREQ=DAILY;BYHOUR=1;BYMINUTE=30",
+			after:      fallBack,
+			want:       []time.Time{at(time.November, 3, 1, 30, -4*time.Hour), at(time.November, 4, 1, 30, -5*time.Hour)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			schedule, err := Parse(KindUnknown, test.expression, test.after)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := Upcoming(schedule, test.after, len(test.want))
+			if len(got) != len(test.want) {
+				t.Fatalf("unexpected number of fire times; got %v; want %v", got, test.want)
+			}
+
+			for i := range got {
+				if !got[i].Equal(test.want[i]) {
+					t.Errorf("unexpected fire time %d; got %v; want %v", i, got[i], test.want[i])
+				}
+			}
+		})
+	}
+}
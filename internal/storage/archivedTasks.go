@@ -0,0 +1,184 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	qb "github.com/Masterminds/squirrel"
+	"github.com/clintjedwards/todo/internal/metrics"
+	"github.com/clintjedwards/todo/proto"
+)
+
+// ArchivedTask is a task that has been moved out of the tasks table to keep it small. Archived tasks keep every field
+// they had so that they can be moved back exactly as they were.
+type ArchivedTask struct {
+	Task
+	// When the task was archived in unix milliseconds; 0 for tasks that aren't archived.
+	Archived int64 `db:"archived"`
+}
+
+var archivedTaskColumns = append(taskColumns[:len(taskColumns):len(taskColumns)], "archived")
+
+func (t *ArchivedTask) ToProto() *proto.Task {
+	task := t.Task.ToProto()
+	task.Archived = t.Archived
+	return task
+}
+
+// ListArchivedTasks returns archived tasks, most recently archived first.
+func (db *DB) ListArchivedTasks(conn Queryable, offset, limit int) ([]ArchivedTask, error) {
+	defer metrics.ObserveQuery("list_archived_tasks", time.Now())
+
+	if limit == 0 || limit > db.maxResultsLimit {
+		limit = db.maxResultsLimit
+	}
+
+	query, args := db.builder.Select(archivedTaskColumns...).
+		From("archived_tasks").
+		OrderBy("archived DESC", "id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).MustSql()
+
+	tasks := []ArchivedTask{}
+	err := conn.Select(&tasks, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return tasks, nil
+}
+
+// ListTasksIncludingArchived works like ListTasks but also returns archived tasks, after all of those that aren't.
+func (db *DB) ListTasksIncludingArchived(conn Queryable, offset, limit int, excludeCompleted bool,
+) ([]ArchivedTask, error) {
+	defer metrics.ObserveQuery("list_tasks_including_archived", time.Now())
+
+	if limit == 0 || limit > db.maxResultsLimit {
+		limit = db.maxResultsLimit
+	}
+
+	columns := strings.Join(taskColumns, ", ")
+
+	statement := db.builder.Select(archivedTaskColumns...).
+		From(fmt.Sprintf(`(SELECT %s, 0 AS archived FROM tasks UNION ALL SELECT %s, archived FROM archived_tasks)
+		AS all_tasks`, columns, columns)).
+		OrderBy("archived", "id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	if excludeCompleted {
+		statement = statement.Where(qb.NotEq{"state": "COMPLETED"})
+	}
+
+	query, args := statement.MustSql()
+
+	tasks := []ArchivedTask{}
+	err := conn.Select(&tasks, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return tasks, nil
+}
+
+func (db *DB) GetArchivedTask(conn Queryable, id string) (ArchivedTask, error) {
+	defer metrics.ObserveQuery("get_archived_task", time.Now())
+
+	query, args := db.builder.Select(archivedTaskColumns...).
+		From("archived_tasks").
+		Where(qb.Eq{"id": id}).MustSql()
+
+	task := ArchivedTask{}
+	err := conn.Get(&task, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ArchivedTask{}, ErrEntityNotFound
+		}
+
+		return ArchivedTask{}, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return task, nil
+}
+
+// FindArchivedTaskIDs returns up to limit archived task IDs that start with the prefix given, sorted so that an exact
+// match comes first.
+func (db *DB) FindArchivedTaskIDs(conn Queryable, prefix string, limit int) ([]string, error) {
+	defer metrics.ObserveQuery("find_archived_task_ids", time.Now())
+
+	return db.findIDs(conn, "archived_tasks", prefix, limit)
+}
+
+func (db *DB) GetArchivedTaskChildren(conn Queryable, parentID string) ([]ArchivedTask, error) {
+	defer metrics.ObserveQuery("get_archived_task_children", time.Now())
+
+	query, args := db.builder.Select(archivedTaskColumns...).
+		From("archived_tasks").
+		Where(qb.Eq{"parent": parentID}).MustSql()
+
+	tasks := []ArchivedTask{}
+	err := conn.Select(&tasks, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return tasks, nil
+}
+
+// ArchiveTask moves a task from the tasks table into the archive, marking it as archived at the time given.
+func (db *DB) ArchiveTask(conn Queryable, id string, archived int64) error {
+	defer metrics.ObserveQuery("archive_task", time.Now())
+
+	return db.moveTask(conn, id, "tasks", "archived_tasks", &archived)
+}
+
+// RestoreArchivedTask moves a task from the archive back into the tasks table. It returns ErrEntityExists if a task
+// with the same ID has been created since.
+func (db *DB) RestoreArchivedTask(conn Queryable, id string) error {
+	defer metrics.ObserveQuery("restore_archived_task", time.Now())
+
+	return db.moveTask(conn, id, "archived_tasks", "tasks", nil)
+}
+
+// moveTask copies a task from one tasks table to another and removes it from the first. If archived is given it is
+// written to the archived column of the destination.
+func (db *DB) moveTask(conn Queryable, id, from, to string, archived *int64) error {
+	insertColumns := strings.Join(taskColumns, ", ")
+
+	statement := db.builder.Select(taskColumns...).From(from).Where(qb.Eq{"id": id})
+	if archived != nil {
+		insertColumns += ", archived"
+		statement = statement.Column(qb.Expr("CAST(? AS BIGINT)", *archived))
+	}
+
+	selectQuery, args := statement.MustSql()
+
+	result, err := conn.Exec(fmt.Sprintf("INSERT INTO %s (%s) %s", to, insertColumns, selectQuery), args...)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrEntityExists
+		}
+
+		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	moved, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	if moved == 0 {
+		return ErrEntityNotFound
+	}
+
+	deleteQuery, args := db.builder.Delete(from).Where(qb.Eq{"id": id}).MustSql()
+	_, err = conn.Exec(deleteQuery, args...)
+	if err != nil {
+		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	qb "github.com/Masterminds/squirrel"
+	"github.com/clintjedwards/todo/internal/metrics"
+	"github.com/clintjedwards/todo/proto"
+)
+
+// Attachment is a file attached to a task. Only its details are kept in the database; the contents live in a blob
+// directory under their digest so that attachments with the same contents share a single copy.
+type Attachment struct {
+	ID     string `db:"id"`
+	TaskID string `db:"task_id"`
+	Name   string `db:"name"`
+	// The size of the contents in bytes.
+	Size int64 `db:"size"`
+	// The hex encoded SHA-256 digest of the contents.
+	Digest  string `db:"digest"`
+	Created int64  `db:"created"`
+}
+
+var attachmentColumns = []string{"id", "task_id", "name", "size", "digest", "created"}
+
+func (a *Attachment) ToProto() *proto.Attachment {
+	return &proto.Attachment{
+		Id:      a.ID,
+		TaskId:  a.TaskID,
+		Name:    a.Name,
+		Size:    a.Size,
+		Digest:  a.Digest,
+		Created: a.Created,
+	}
+}
+
+// ListAttachments returns the attachments on a task, oldest first.
+func (db *DB) ListAttachments(conn Queryable, taskID string) ([]Attachment, error) {
+	defer metrics.ObserveQuery("list_attachments", time.Now())
+
+	query, args := db.builder.Select(attachmentColumns...).
+		From("attachments").
+		Where(qb.Eq{"task_id": taskID}).
+		OrderBy("created", "id").MustSql()
+
+	attachments := []Attachment{}
+	err := conn.Select(&attachments, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return attachments, nil
+}
+
+func (db *DB) GetAttachment(conn Queryable, id string) (Attachment, error) {
+	defer metrics.ObserveQuery("get_attachment", time.Now())
+
+	query, args := db.builder.Select(attachmentColumns...).
+		From("attachments").
+		Where(qb.Eq{"id": id}).MustSql()
+
+	attachment := Attachment{}
+	err := conn.Get(&attachment, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Attachment{}, ErrEntityNotFound
+		}
+
+		return Attachment{}, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return attachment, nil
+}
+
+// FindAttachmentIDs returns up to limit attachment IDs that start with the prefix given, sorted so that an exact
+// match comes first.
+func (db *DB) FindAttachmentIDs(conn Queryable, prefix string, limit int) ([]string, error) {
+	defer metrics.ObserveQuery("find_attachment_ids", time.Now())
+
+	return db.findIDs(conn, "attachments", prefix, limit)
+}
+
+// CountAttachmentsWithDigest returns the number of attachments whose contents have the digest given.
+func (db *DB) CountAttachmentsWithDigest(conn Queryable, digest string) (int64, error) {
+	defer metrics.ObserveQuery("count_attachments_with_digest", time.Now())
+
+	query, args := db.builder.Select("count(*)").
+		From("attachments").
+		Where(qb.Eq{"digest": digest}).MustSql()
+
+	var count int64
+	err := conn.Get(&count, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return count, nil
+}
+
+func (db *DB) InsertAttachment(conn Queryable, attachment *Attachment) error {
+	defer metrics.ObserveQuery("insert_attachment", time.Now())
+
+	_, err := conn.NamedExec(`INSERT INTO attachments (id, task_id, name, size, digest, created) VALUES
+	(:id, :task_id, :name, :size, :digest, :created)`, attachment)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrEntityExists
+		}
+
+		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return nil
+}
+
+// DeleteTaskAttachments removes every attachment on a task. The contents are left for the caller to clean up.
+func (db *DB) DeleteTaskAttachments(conn Queryable, taskID string) error {
+	defer metrics.ObserveQuery("delete_task_attachments", time.Now())
+
+	query, args := db.builder.Delete("attachments").Where(qb.Eq{"task_id": taskID}).MustSql()
+	_, err := conn.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return nil
+}
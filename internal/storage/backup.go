@@ -0,0 +1,172 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Backup writes a consistent snapshot of the live database to the path given using SQLite's "VACUUM INTO".
+// It is safe to call while the database is being read from and written to. The destination must not already exist.
+//
+// Backups are only supported for the sqlite driver; postgres databases should be backed up with pg_dump.
+// Attachment contents are kept outside the database and aren't part of the backup.
+func (db *DB) Backup(path string) error {
+	if db.driver != DriverSQLite {
+		return fmt.Errorf("backups are only supported for the sqlite driver; %w", ErrUnsupported)
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("backup destination %q already exists; %w", path, ErrEntityExists)
+	}
+
+	_, err := db.Exec("VACUUM INTO ?", path)
+	if err != nil {
+		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return nil
+}
+
+// ValidateBackup checks that the sqlite database file at the path given is a healthy Todo database and that every
+// migration recorded in it is one this version of Todo knows about. Databases that are older than the current
+// schema are considered valid since they will simply be migrated forward on the next start.
+func ValidateBackup(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("could not open backup %q: %w", path, err)
+	}
+
+	db, err := sqlx.Connect("sqlite3", fmt.Sprintf("file:%s?mode=ro", path))
+	if err != nil {
+		return fmt.Errorf("could not open backup %q: %w", path, err)
+	}
+	defer db.Close()
+
+	var integrity string
+	err = db.Get(&integrity, "PRAGMA integrity_check")
+	if err != nil {
+		return fmt.Errorf("could not check integrity of backup: %w", err)
+	}
+
+	if integrity != "ok" {
+		return fmt.Errorf("backup failed integrity check: %s; %w", integrity, ErrPreconditionFailure)
+	}
+
+	migrator := migrate{
+		Migrations: knownMigrations(DriverSQLite),
+	}
+
+	hasMigrations, err := migrator.hasMigrationTable(db)
+	if err != nil || !hasMigrations {
+		return fmt.Errorf("could not find migrations in backup; is this a todo database?; %w", ErrPreconditionFailure)
+	}
+
+	err = migrator.check(db)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Restore validates the backup at src and then swaps it in place of the database at dest. The database being
+// replaced (along with its WAL files) is kept alongside it with the suffix ".pre-restore-" and the time of the restore
+// in case the restore needs to be reverted by hand. It returns the path it was kept at, or an empty path if there was
+// no database at dest. If the swap fails part way through the database being replaced is put back.
+//
+// The Todo service must not be running against dest while a restore is taking place.
+func Restore(src, dest string) (string, error) {
+	err := ValidateBackup(src)
+	if err != nil {
+		return "", err
+	}
+
+	kept := fmt.Sprintf("%s.pre-restore-%s", dest, time.Now().UTC().Format("20060102T150405.000"))
+	for _, suffix := range walSuffixes {
+		_, err := os.Lstat(kept + suffix)
+		if err == nil {
+			return "", fmt.Errorf("%s already exists; %w", kept+suffix, ErrEntityExists)
+		}
+	}
+
+	tmpPath := dest + ".restore-tmp"
+	err = copyFile(src, tmpPath)
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("could not stage backup: %w", err)
+	}
+
+	moved := []string{}
+	for _, suffix := range walSuffixes {
+		err := os.Rename(dest+suffix, kept+suffix)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			_ = os.Remove(tmpPath)
+			return "", fmt.Errorf("could not move current database out of the way: %w%s", err,
+				putBack(dest, kept, moved))
+		}
+		moved = append(moved, suffix)
+	}
+
+	err = os.Rename(tmpPath, dest)
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("could not swap in backup: %w%s", err, putBack(dest, kept, moved))
+	}
+
+	if len(moved) == 0 {
+		return "", nil
+	}
+
+	return kept, nil
+}
+
+// walSuffixes are the suffixes of the files that make up a sqlite database in WAL mode.
+var walSuffixes = []string{"", "-wal", "-shm"}
+
+// putBack moves the files of a database that were moved out of the way for a restore back to where they were. It
+// returns a description of anything it couldn't move back, to add to the restore's error.
+func putBack(dest, kept string, moved []string) string {
+	failed := ""
+	for _, suffix := range moved {
+		err := os.Rename(kept+suffix, dest+suffix)
+		if err != nil {
+			failed += fmt.Sprintf("; could not move %s back to %s: %v", kept+suffix, dest+suffix, err)
+		}
+	}
+
+	return failed
+}
+
+func copyFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, in)
+	if err != nil {
+		_ = out.Close()
+		return err
+	}
+
+	err = out.Sync()
+	if err != nil {
+		_ = out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	qb "github.com/Masterminds/squirrel"
+	"github.com/clintjedwards/todo/internal/metrics"
+	"github.com/clintjedwards/todo/proto"
+)
+
+// TaskComment is an entry in a task's running log of comments. Comments are only ever added, never changed.
+type TaskComment struct {
+	ID      string `db:"id"`
+	TaskID  string `db:"task_id"`
+	Author  string `db:"author"`
+	Text    string `db:"text"`
+	Created int64  `db:"created"`
+}
+
+var taskCommentColumns = []string{"id", "task_id", "author", "text", "created"}
+
+func (c *TaskComment) ToProto() *proto.TaskComment {
+	return &proto.TaskComment{
+		Id:      c.ID,
+		TaskId:  c.TaskID,
+		Author:  c.Author,
+		Text:    c.Text,
+		Created: c.Created,
+	}
+}
+
+// ListTaskComments returns the comments left on a task, oldest first.
+func (db *DB) ListTaskComments(conn Queryable, taskID string, offset, limit int) ([]TaskComment, error) {
+	defer metrics.ObserveQuery("list_task_comments", time.Now())
+
+	if limit == 0 || limit > db.maxResultsLimit {
+		limit = db.maxResultsLimit
+	}
+
+	query, args := db.builder.Select(taskCommentColumns...).
+		From("task_comments").
+		Where(qb.Eq{"task_id": taskID}).
+		OrderBy("created", "id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).MustSql()
+
+	comments := []TaskComment{}
+	err := conn.Select(&comments, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return comments, nil
+}
+
+func (db *DB) GetTaskComment(conn Queryable, id string) (TaskComment, error) {
+	defer metrics.ObserveQuery("get_task_comment", time.Now())
+
+	query, args := db.builder.Select(taskCommentColumns...).
+		From("task_comments").
+		Where(qb.Eq{"id": id}).MustSql()
+
+	comment := TaskComment{}
+	err := conn.Get(&comment, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return TaskComment{}, ErrEntityNotFound
+		}
+
+		return TaskComment{}, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return comment, nil
+}
+
+func (db *DB) InsertTaskComment(conn Queryable, comment *TaskComment) error {
+	defer metrics.ObserveQuery("insert_task_comment", time.Now())
+
+	_, err := conn.NamedExec(`INSERT INTO task_comments (id, task_id, author, text, created) VALUES
+	(:id, :task_id, :author, :text, :created)`, comment)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrEntityExists
+		}
+
+		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return nil
+}
+
+// DeleteTaskComments removes every comment left on a task.
+func (db *DB) DeleteTaskComments(conn Queryable, taskID string) error {
+	defer metrics.ObserveQuery("delete_task_comments", time.Now())
+
+	query, args := db.builder.Delete("task_comments").Where(qb.Eq{"task_id": taskID}).MustSql()
+	_, err := conn.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return nil
+}
@@ -3,6 +3,11 @@ package storage
 import (
 	"database/sql"
 	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
@@ -13,12 +18,41 @@ type migrate struct {
 	Migrations []migration
 }
 
+// MigrationStatus describes a single migration and whether it has been applied to the database.
+type MigrationStatus struct {
+	ID      string
+	Applied bool
+
+	// Known is false when the migration was found in the database but this version of Todo does not know about it.
+	// This happens when the database was last migrated by a newer version of Todo.
+	Known bool
+
+	// Reversible is true when the migration has a down step and can be rolled back.
+	Reversible bool
+}
+
 // migrate will run the migrations using the provided db connection.
-func (s *migrate) migrate(db *sqlx.DB, dialect string) error {
+func (s *migrate) migrate(db *sqlx.DB) error {
+	return s.up(db, "")
+}
+
+// up applies all pending migrations in order. If to is not empty migrations stop after the migration with
+// that ID has been applied.
+func (s *migrate) up(db *sqlx.DB, to string) error {
 	err := s.createMigrationTable(db)
 	if err != nil {
 		return err
 	}
+
+	if to != "" && s.find(to) == -1 {
+		return fmt.Errorf("migration %q not found; %w", to, ErrEntityNotFound)
+	}
+
+	err = s.check(db)
+	if err != nil {
+		return err
+	}
+
 	for _, m := range s.Migrations {
 		var found string
 		err := db.Get(&found, "SELECT id FROM migrations WHERE id=$1", m.ID)
@@ -26,6 +60,9 @@ func (s *migrate) migrate(db *sqlx.DB, dialect string) error {
 		case sql.ErrNoRows:
 			log.Info().Msgf("running migration ID: %v", m.ID)
 		case nil:
+			if m.ID == to {
+				return nil
+			}
 			continue
 		default:
 			return fmt.Errorf("looking up migration by id: %w", err)
@@ -34,10 +71,185 @@ func (s *migrate) migrate(db *sqlx.DB, dialect string) error {
 		if err != nil {
 			return err
 		}
+
+		if m.ID == to {
+			return nil
+		}
+	}
+	return nil
+}
+
+// down rolls back applied migrations in reverse order. If to is empty only the latest migration is rolled back,
+// otherwise every migration after the migration with that ID is rolled back.
+func (s *migrate) down(db *sqlx.DB, to string) error {
+	err := s.createMigrationTable(db)
+	if err != nil {
+		return err
+	}
+
+	err = s.check(db)
+	if err != nil {
+		return err
+	}
+
+	applied, err := s.applied(db)
+	if err != nil {
+		return err
+	}
+
+	stop := -1
+	if to != "" {
+		stop = s.find(to)
+		if stop == -1 {
+			return fmt.Errorf("migration %q not found; %w", to, ErrEntityNotFound)
+		}
+	}
+
+	rolledBack := 0
+	for i := len(s.Migrations) - 1; i > stop; i-- {
+		m := s.Migrations[i]
+		if _, ok := applied[m.ID]; !ok {
+			continue
+		}
+
+		if to == "" && rolledBack == 1 {
+			return nil
+		}
+
+		log.Info().Msgf("rolling back migration ID: %v", m.ID)
+		err := s.rollbackMigration(db, m)
+		if err != nil {
+			return err
+		}
+		rolledBack++
 	}
+
 	return nil
 }
 
+// status returns the state of every known migration followed by any unknown migrations found in the database. It
+// only reads from the database; a database without a migrations table is reported as having nothing applied.
+func (s *migrate) status(db *sqlx.DB) ([]MigrationStatus, error) {
+	applied, err := s.applied(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []MigrationStatus{}
+	for _, m := range s.Migrations {
+		_, ok := applied[m.ID]
+		statuses = append(statuses, MigrationStatus{
+			ID:         m.ID,
+			Applied:    ok,
+			Known:      true,
+			Reversible: m.Rollback != nil,
+		})
+		delete(applied, m.ID)
+	}
+
+	unknown := []string{}
+	for id := range applied {
+		unknown = append(unknown, id)
+	}
+	sortMigrationIDs(unknown)
+
+	for _, id := range unknown {
+		statuses = append(statuses, MigrationStatus{
+			ID:      id,
+			Applied: true,
+		})
+	}
+
+	return statuses, nil
+}
+
+// version returns the ID of the latest migration applied to the database or an empty string if none have been.
+func (s *migrate) version(db *sqlx.DB) (string, error) {
+	statuses, err := s.status(db)
+	if err != nil {
+		return "", err
+	}
+
+	version := ""
+	for _, status := range statuses {
+		if status.Applied {
+			version = status.ID
+		}
+	}
+
+	return version, nil
+}
+
+// check returns an error if the database contains migrations that this version of Todo does not know about.
+// Running against such a database could silently corrupt data so we refuse instead.
+func (s *migrate) check(db *sqlx.DB) error {
+	statuses, err := s.status(db)
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		if !status.Known {
+			return fmt.Errorf("database contains migration %q which is newer than this version of todo; "+
+				"please upgrade todo; %w", status.ID, ErrPreconditionFailure)
+		}
+	}
+
+	return nil
+}
+
+// applied returns the IDs of every migration recorded in the database, which is none if the migrations table hasn't
+// been created yet.
+func (s *migrate) applied(db *sqlx.DB) (map[string]struct{}, error) {
+	exists, err := s.hasMigrationTable(db)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return map[string]struct{}{}, nil
+	}
+
+	ids := []string{}
+	err = db.Select(&ids, "SELECT id FROM migrations")
+	if err != nil {
+		return nil, fmt.Errorf("looking up applied migrations: %w", err)
+	}
+
+	applied := map[string]struct{}{}
+	for _, id := range ids {
+		applied[id] = struct{}{}
+	}
+
+	return applied, nil
+}
+
+func (s *migrate) find(id string) int {
+	for i, m := range s.Migrations {
+		if m.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// hasMigrationTable reports whether the migrations table exists without creating it.
+func (s *migrate) hasMigrationTable(db *sqlx.DB) (bool, error) {
+	query := "SELECT to_regclass('migrations') IS NOT NULL"
+	if db.DriverName() == "sqlite3" {
+		query = "SELECT count(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = 'migrations'"
+	}
+
+	var exists bool
+	err := db.Get(&exists, query)
+	if err != nil {
+		return false, fmt.Errorf("looking up migrations table: %w", err)
+	}
+
+	return exists, nil
+}
+
 func (s *migrate) createMigrationTable(db *sqlx.DB) error {
 	_, err := db.Exec("CREATE TABLE IF NOT EXISTS migrations (id TEXT PRIMARY KEY )")
 	if err != nil {
@@ -70,17 +282,46 @@ func (s *migrate) runMigration(db *sqlx.DB, m migration) error {
 	return nil
 }
 
+func (s *migrate) rollbackMigration(db *sqlx.DB, m migration) error {
+	errorf := func(err error) error { return fmt.Errorf("rolling back migration %q: %w", m.ID, err) }
+
+	if m.Rollback == nil {
+		return errorf(fmt.Errorf("migration has no down step; %w", ErrUnsupported))
+	}
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return errorf(err)
+	}
+	_, err = tx.Exec("DELETE FROM migrations WHERE id = $1", m.ID)
+	if err != nil {
+		_ = tx.Rollback()
+		return errorf(err)
+	}
+	err = m.Rollback(tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return errorf(err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return errorf(err)
+	}
+	return nil
+}
+
 // migration is a unique ID plus a function that uses a sqlx transaction
-// to perform a database migration step.
+// to perform a database migration step. Rollback is optional and undoes the step.
 type migration struct {
-	ID      string
-	Migrate func(tx *sqlx.Tx) error
+	ID       string
+	Migrate  func(tx *sqlx.Tx) error
+	Rollback func(tx *sqlx.Tx) error
 }
 
 // migrationQuery will create a SqlxMigration using the provided id and
 // query string. It is a helper function designed to simplify the process of
 // creating migrations that only depending on a SQL query string.
-func migrationQuery(id, query string) migration {
+func migrationQuery(id, query, downQuery string) migration {
 	queryFn := func(query string) func(tx *sqlx.Tx) error {
 		if query == "" {
 			return nil
@@ -92,8 +333,83 @@ func migrationQuery(id, query string) migration {
 	}
 
 	m := migration{
-		ID:      id,
-		Migrate: queryFn(query),
+		ID:       id,
+		Migrate:  queryFn(query),
+		Rollback: queryFn(downQuery),
 	}
 	return m
 }
+
+// loadMigrations reads every migration in the directory given. Migrations are sql files named
+// "<id>_<description>.sql" with an optional down step named "<id>_<description>.down.sql".
+// IDs are numeric and migrations are returned in ascending order.
+func loadMigrations(fsys fs.FS, dir string) ([]migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ups := map[string]string{}
+	downs := map[string]string{}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".sql") {
+			continue
+		}
+
+		id, _, ok := strings.Cut(name, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %q is not named in the format <id>_<description>.sql", name)
+		}
+
+		if _, err := strconv.Atoi(id); err != nil {
+			return nil, fmt.Errorf("migration %q does not have a numeric id", name)
+		}
+
+		contents, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.HasSuffix(name, ".down.sql") {
+			downs[id] = string(contents)
+			continue
+		}
+
+		if _, exists := ups[id]; exists {
+			return nil, fmt.Errorf("duplicate migration id %q", id)
+		}
+		ups[id] = string(contents)
+	}
+
+	ids := []string{}
+	for id := range ups {
+		ids = append(ids, id)
+	}
+	sortMigrationIDs(ids)
+
+	migrations := []migration{}
+	for _, id := range ids {
+		migrations = append(migrations, migrationQuery(id, ups[id], downs[id]))
+		delete(downs, id)
+	}
+
+	for id := range downs {
+		return nil, fmt.Errorf("down migration %q has no matching up migration", id)
+	}
+
+	return migrations, nil
+}
+
+// sortMigrationIDs sorts migration ids numerically, falling back to lexical order for ids that are not numbers.
+func sortMigrationIDs(ids []string) {
+	sort.Slice(ids, func(i, j int) bool {
+		a, aErr := strconv.Atoi(ids[i])
+		b, bErr := strconv.Atoi(ids[j])
+		if aErr != nil || bErr != nil {
+			return ids[i] < ids[j]
+		}
+		return a < b
+	})
+}
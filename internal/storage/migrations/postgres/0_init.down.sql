@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS scheduled_tasks;
+DROP TABLE IF EXISTS tasks;
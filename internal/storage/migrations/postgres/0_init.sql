@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS tasks (
+    id                 TEXT    NOT NULL,
+    title              TEXT    NOT NULL,
+    description        TEXT    NOT NULL,
+    state              TEXT    NOT NULL,
+    created            BIGINT  NOT NULL,
+    modified           BIGINT  NOT NULL,
+    parent             TEXT,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS scheduled_tasks (
+    id                 TEXT  NOT NULL,
+    title              TEXT  NOT NULL,
+    description        TEXT  NOT NULL,
+    expression         TEXT  NOT NULL,
+    parent             TEXT,
+    PRIMARY KEY (id)
+);
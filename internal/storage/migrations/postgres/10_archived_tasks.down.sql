@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS archived_tasks_archived;
+DROP INDEX IF EXISTS archived_tasks_parent;
+DROP TABLE IF EXISTS archived_tasks;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS archived_tasks (
+    id                 TEXT    NOT NULL,
+    title              TEXT    NOT NULL,
+    description        TEXT    NOT NULL,
+    state              TEXT    NOT NULL,
+    created            BIGINT  NOT NULL,
+    modified           BIGINT  NOT NULL,
+    parent             TEXT,
+    scheduled_task_id  TEXT    NOT NULL DEFAULT '',
+    scheduled_for      BIGINT  NOT NULL DEFAULT 0,
+    version            BIGINT  NOT NULL DEFAULT 0,
+    completed_at       BIGINT  NOT NULL DEFAULT 0,
+    completion_note    TEXT    NOT NULL DEFAULT '',
+    archived           BIGINT  NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS archived_tasks_parent ON archived_tasks (parent);
+CREATE INDEX IF NOT EXISTS archived_tasks_archived ON archived_tasks (archived);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS attachments (
+    id                 TEXT    NOT NULL,
+    task_id            TEXT    NOT NULL,
+    name               TEXT    NOT NULL,
+    size               BIGINT  NOT NULL,
+    digest             TEXT    NOT NULL,
+    created            BIGINT  NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS attachments_task_id ON attachments (task_id);
+CREATE INDEX IF NOT EXISTS attachments_digest ON attachments (digest);
@@ -0,0 +1 @@
+ALTER TABLE operations ADD COLUMN attachments TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1 @@
+ALTER TABLE scheduled_tasks ADD COLUMN paused BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+ALTER TABLE scheduled_tasks ADD COLUMN expression_type TEXT NOT NULL DEFAULT 'AVAIL';
+ALTER TABLE scheduled_tasks ADD COLUMN created BIGINT NOT NULL DEFAULT 0;
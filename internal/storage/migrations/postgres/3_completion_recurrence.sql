@@ -0,0 +1,4 @@
+ALTER TABLE tasks ADD COLUMN scheduled_task_id TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS tasks_scheduled_task_id ON tasks (scheduled_task_id);
+ALTER TABLE scheduled_tasks ADD COLUMN recurrence TEXT NOT NULL DEFAULT 'CALENDAR';
+ALTER TABLE scheduled_tasks ADD COLUMN skip_if_open BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN scheduled_for BIGINT NOT NULL DEFAULT 0;
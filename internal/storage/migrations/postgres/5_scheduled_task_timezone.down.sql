@@ -0,0 +1 @@
+ALTER TABLE scheduled_tasks DROP COLUMN timezone;
@@ -0,0 +1 @@
+ALTER TABLE scheduled_tasks ADD COLUMN timezone TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS templates (
+    id                 TEXT    NOT NULL,
+    name               TEXT    NOT NULL,
+    description        TEXT    NOT NULL,
+    tasks              TEXT    NOT NULL,
+    created            BIGINT  NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (name)
+);
+
+ALTER TABLE scheduled_tasks ADD COLUMN template_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS operations (
+    id                 TEXT    NOT NULL,
+    kind               TEXT    NOT NULL,
+    description        TEXT    NOT NULL,
+    tasks              TEXT    NOT NULL,
+    created            BIGINT  NOT NULL,
+    undone             BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS operations_created ON operations (created);
@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN version BIGINT NOT NULL DEFAULT 0;
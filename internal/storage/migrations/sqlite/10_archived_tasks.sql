@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS archived_tasks (
+    id                 TEXT    NOT NULL,
+    title              TEXT    NOT NULL,
+    description        TEXT    NOT NULL,
+    state              TEXT    NOT NULL,
+    created            INTEGER NOT NULL,
+    modified           INTEGER NOT NULL,
+    parent             TEXT,
+    scheduled_task_id  TEXT    NOT NULL DEFAULT '',
+    scheduled_for      INTEGER NOT NULL DEFAULT 0,
+    version            INTEGER NOT NULL DEFAULT 0,
+    completed_at       INTEGER NOT NULL DEFAULT 0,
+    completion_note    TEXT    NOT NULL DEFAULT '',
+    archived           INTEGER NOT NULL,
+    PRIMARY KEY (id)
+) STRICT;
+
+CREATE INDEX IF NOT EXISTS archived_tasks_parent ON archived_tasks (parent);
+CREATE INDEX IF NOT EXISTS archived_tasks_archived ON archived_tasks (archived);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS task_comments (
+    id                 TEXT    NOT NULL,
+    task_id            TEXT    NOT NULL,
+    author             TEXT    NOT NULL,
+    text               TEXT    NOT NULL,
+    created            INTEGER NOT NULL,
+    PRIMARY KEY (id)
+) STRICT;
+
+CREATE INDEX IF NOT EXISTS task_comments_task_id ON task_comments (task_id, created);
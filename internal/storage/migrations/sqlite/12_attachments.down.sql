@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS attachments_digest;
+DROP INDEX IF EXISTS attachments_task_id;
+DROP TABLE IF EXISTS attachments;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS task_create_requests (
+    request_id         TEXT    NOT NULL,
+    task_id            TEXT    NOT NULL,
+    created            INTEGER NOT NULL,
+    PRIMARY KEY (request_id)
+) STRICT;
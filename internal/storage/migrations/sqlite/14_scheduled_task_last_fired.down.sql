@@ -0,0 +1,2 @@
+ALTER TABLE scheduled_tasks DROP COLUMN last_completed;
+ALTER TABLE scheduled_tasks DROP COLUMN last_fired;
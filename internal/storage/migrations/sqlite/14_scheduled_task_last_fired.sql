@@ -0,0 +1,8 @@
+ALTER TABLE scheduled_tasks ADD COLUMN last_fired INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE scheduled_tasks ADD COLUMN last_completed INTEGER NOT NULL DEFAULT 0;
+
+UPDATE scheduled_tasks SET
+    last_fired = COALESCE((SELECT created FROM tasks WHERE tasks.scheduled_task_id = scheduled_tasks.id
+        ORDER BY created DESC, id DESC LIMIT 1), 0),
+    last_completed = COALESCE((SELECT completed_at FROM tasks WHERE tasks.scheduled_task_id = scheduled_tasks.id
+        ORDER BY created DESC, id DESC LIMIT 1), 0);
@@ -0,0 +1 @@
+ALTER TABLE operations ADD COLUMN comments TEXT NOT NULL DEFAULT '[]';
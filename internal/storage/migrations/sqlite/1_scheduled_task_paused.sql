@@ -0,0 +1 @@
+ALTER TABLE scheduled_tasks ADD COLUMN paused INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE scheduled_tasks DROP COLUMN created;
+ALTER TABLE scheduled_tasks DROP COLUMN expression_type;
@@ -0,0 +1,4 @@
+ALTER TABLE scheduled_tasks DROP COLUMN skip_if_open;
+ALTER TABLE scheduled_tasks DROP COLUMN recurrence;
+DROP INDEX IF EXISTS tasks_scheduled_task_id;
+ALTER TABLE tasks DROP COLUMN scheduled_task_id;
@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN scheduled_for INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE scheduled_tasks DROP COLUMN template_id;
+
+DROP TABLE IF EXISTS templates;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS operations (
+    id                 TEXT    NOT NULL,
+    kind               TEXT    NOT NULL,
+    description        TEXT    NOT NULL,
+    tasks              TEXT    NOT NULL,
+    created            INTEGER NOT NULL,
+    undone             INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (id)
+) STRICT;
+
+CREATE INDEX IF NOT EXISTS operations_created ON operations (created);
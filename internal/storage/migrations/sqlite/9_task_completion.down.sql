@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP COLUMN completion_note;
+ALTER TABLE tasks DROP COLUMN completed_at;
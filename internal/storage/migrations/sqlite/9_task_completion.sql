@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN completed_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN completion_note TEXT NOT NULL DEFAULT '';
+UPDATE tasks SET completed_at = modified WHERE state = 'COMPLETED';
@@ -0,0 +1,276 @@
+package storage
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	qb "github.com/Masterminds/squirrel"
+	"github.com/clintjedwards/todo/internal/metrics"
+	"github.com/clintjedwards/todo/proto"
+)
+
+// Operation is an entry in the journal of changes made to tasks. It holds every task the change touched as it was
+// before the change so that the change can be undone.
+type Operation struct {
+	ID          string     `db:"id"`
+	Kind        string     `db:"kind"`
+	Description string     `db:"description"`
+	Tasks       TaskImages `db:"tasks"`
+	// The attachments on tasks the change deleted. Their contents are kept for as long as the operation is.
+	Attachments AttachmentImages `db:"attachments"`
+	// The comments on tasks the change deleted.
+	Comments CommentImages `db:"comments"`
+	Created  int64         `db:"created"`
+	Undone   bool          `db:"undone"`
+}
+
+var operationColumns = []string{"id", "kind", "description", "tasks", "attachments", "comments", "created", "undone"}
+
+func (o *Operation) ToProto() *proto.Operation {
+	taskIDs := []string{}
+	for _, task := range o.Tasks {
+		taskIDs = append(taskIDs, task.ID)
+	}
+
+	return &proto.Operation{
+		Id:          o.ID,
+		Kind:        proto.Operation_Kind(proto.Operation_Kind_value[o.Kind]),
+		Description: o.Description,
+		TaskIds:     taskIDs,
+		Created:     o.Created,
+		Undone:      o.Undone,
+	}
+}
+
+type UpdatableOperationFields struct {
+	Undone *bool
+}
+
+// TaskImages are copies of tasks stored as a single JSON column since they are always read and written as a whole.
+type TaskImages []Task
+
+// Value implements driver.Valuer so the tasks can be written to the database.
+func (t TaskImages) Value() (driver.Value, error) {
+	if t == nil {
+		t = TaskImages{}
+	}
+
+	return imagesValue(t)
+}
+
+// Scan implements sql.Scanner so the tasks can be read from the database.
+func (t *TaskImages) Scan(value any) error {
+	return scanImages(value, t, "task")
+}
+
+// AttachmentImages are copies of attachments stored as a single JSON column, like TaskImages.
+type AttachmentImages []Attachment
+
+// Value implements driver.Valuer so the attachments can be written to the database.
+func (a AttachmentImages) Value() (driver.Value, error) {
+	if a == nil {
+		a = AttachmentImages{}
+	}
+
+	return imagesValue(a)
+}
+
+// Scan implements sql.Scanner so the attachments can be read from the database.
+func (a *AttachmentImages) Scan(value any) error {
+	return scanImages(value, a, "attachment")
+}
+
+// CommentImages are copies of comments stored as a single JSON column, like TaskImages.
+type CommentImages []TaskComment
+
+// Value implements driver.Valuer so the comments can be written to the database.
+func (c CommentImages) Value() (driver.Value, error) {
+	if c == nil {
+		c = CommentImages{}
+	}
+
+	return imagesValue(c)
+}
+
+// Scan implements sql.Scanner so the comments can be read from the database.
+func (c *CommentImages) Scan(value any) error {
+	return scanImages(value, c, "comment")
+}
+
+func imagesValue(images any) (driver.Value, error) {
+	raw, err := json.Marshal(images)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(raw), nil
+}
+
+func scanImages(value, images any, kind string) error {
+	switch v := value.(type) {
+	case string:
+		return json.Unmarshal([]byte(v), images)
+	case []byte:
+		return json.Unmarshal(v, images)
+	default:
+		return fmt.Errorf("could not scan %T into %s images", value, kind)
+	}
+}
+
+// ListOperations returns operations newest first.
+func (db *DB) ListOperations(conn Queryable, offset, limit int) ([]Operation, error) {
+	defer metrics.ObserveQuery("list_operations", time.Now())
+
+	if limit == 0 || limit > db.maxResultsLimit {
+		limit = db.maxResultsLimit
+	}
+
+	query, args := db.builder.Select(operationColumns...).
+		From("operations").
+		OrderBy("created DESC", "id DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).MustSql()
+
+	operations := []Operation{}
+	err := conn.Select(&operations, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return operations, nil
+}
+
+func (db *DB) GetOperation(conn Queryable, id string) (Operation, error) {
+	defer metrics.ObserveQuery("get_operation", time.Now())
+
+	query, args := db.builder.Select(operationColumns...).
+		From("operations").
+		Where(qb.Eq{"id": id}).MustSql()
+
+	return db.getOperation(conn, query, args)
+}
+
+// GetLatestOperation returns the most recent operation that hasn't been undone.
+func (db *DB) GetLatestOperation(conn Queryable) (Operation, error) {
+	defer metrics.ObserveQuery("get_latest_operation", time.Now())
+
+	query, args := db.builder.Select(operationColumns...).
+		From("operations").
+		Where(qb.Eq{"undone": false}).
+		OrderBy("created DESC", "id DESC").
+		Limit(1).MustSql()
+
+	return db.getOperation(conn, query, args)
+}
+
+func (db *DB) getOperation(conn Queryable, query string, args []any) (Operation, error) {
+	operation := Operation{}
+	err := conn.Get(&operation, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Operation{}, ErrEntityNotFound
+		}
+
+		return Operation{}, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return operation, nil
+}
+
+// FindOperationIDs returns up to limit IDs that start with the prefix given, sorted so that an exact match comes first.
+func (db *DB) FindOperationIDs(conn Queryable, prefix string, limit int) ([]string, error) {
+	defer metrics.ObserveQuery("find_operation_ids", time.Now())
+
+	return db.findIDs(conn, "operations", prefix, limit)
+}
+
+func (db *DB) InsertOperation(conn Queryable, operation *Operation) error {
+	defer metrics.ObserveQuery("insert_operation", time.Now())
+
+	_, err := conn.NamedExec(`INSERT INTO operations (id, kind, description, tasks, attachments, comments, created,
+	undone) VALUES (:id, :kind, :description, :tasks, :attachments, :comments, :created, :undone)`, operation)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrEntityExists
+		}
+
+		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return nil
+}
+
+func (db *DB) UpdateOperation(conn Queryable, id string, fields UpdatableOperationFields) error {
+	defer metrics.ObserveQuery("update_operation", time.Now())
+
+	statement := db.builder.Update("operations")
+
+	if fields.Undone != nil {
+		statement = statement.Set("undone", fields.Undone)
+	}
+
+	query, args := statement.Where(qb.Eq{"id": id}).MustSql()
+
+	_, err := conn.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return nil
+}
+
+// MarkOperationUndone marks the operation given as undone, returning ErrConflict if it already has been. The check and
+// the change are made at once so that an operation can't be undone twice by undos racing each other.
+func (db *DB) MarkOperationUndone(conn Queryable, id string) error {
+	defer metrics.ObserveQuery("mark_operation_undone", time.Now())
+
+	query, args := db.builder.Update("operations").
+		Set("undone", true).
+		Where(qb.Eq{"id": id, "undone": false}).MustSql()
+
+	result, err := conn.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	if updated == 0 {
+		_, err := db.GetOperation(conn, id)
+		if err != nil {
+			return err
+		}
+		return ErrConflict
+	}
+
+	return nil
+}
+
+// PruneOperations deletes all but the newest keep operations.
+func (db *DB) PruneOperations(conn Queryable, keep int) error {
+	defer metrics.ObserveQuery("prune_operations", time.Now())
+
+	// The subquery is built with the default placeholders so that they're only converted once, along with the
+	// query it's part of.
+	newestQuery, newestArgs := qb.Select("id").
+		From("operations").
+		OrderBy("created DESC", "id DESC").
+		Limit(uint64(keep)).MustSql()
+
+	query, args := db.builder.Delete("operations").
+		Where(qb.Expr("id NOT IN (SELECT id FROM ("+newestQuery+") AS newest)", newestArgs...)).MustSql()
+
+	_, err := conn.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return nil
+}
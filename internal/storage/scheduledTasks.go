@@ -4,18 +4,38 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"strings"
+	"time"
 
 	qb "github.com/Masterminds/squirrel"
+	"github.com/clintjedwards/todo/internal/metrics"
 	"github.com/clintjedwards/todo/proto"
 )
 
 type ScheduledTask struct {
-	ID          string `db:"id"`
-	Title       string `db:"title"`
-	Description string `db:"description"`
-	Expression  string `db:"expression"`
-	Parent      string `db:"parent"`
+	ID             string `db:"id"`
+	Title          string `db:"title"`
+	Description    string `db:"description"`
+	Expression     string `db:"expression"`
+	ExpressionType string `db:"expression_type"`
+	Parent         string `db:"parent"`
+	Paused         bool   `db:"paused"`
+	Created        int64  `db:"created"`
+	Recurrence     string `db:"recurrence"`
+	SkipIfOpen     bool   `db:"skip_if_open"`
+	// The IANA time zone the expression is evaluated in. Empty means the server's default time zone.
+	Timezone string `db:"timezone"`
+	// The template to create when the scheduled task fires instead of a single task. Empty means no template.
+	TemplateID string `db:"template_id"`
+	// When the scheduled task last created tasks in unix milliseconds; 0 if it never has.
+	LastFired int64 `db:"last_fired"`
+	// When the task it last created was seen to be completed in unix milliseconds; 0 if it hasn't been yet. Kept so
+	// that after completion scheduled tasks still know when they're next due if that task is deleted.
+	LastCompleted int64 `db:"last_completed"`
+}
+
+var scheduledTaskColumns = []string{
+	"id", "title", "description", "expression", "expression_type", "parent", "paused", "created", "recurrence",
+	"skip_if_open", "timezone", "template_id", "last_fired", "last_completed",
 }
 
 func (t *ScheduledTask) ToProto() *proto.ScheduledTask {
@@ -24,23 +44,42 @@ func (t *ScheduledTask) ToProto() *proto.ScheduledTask {
 		Title:       t.Title,
 		Description: t.Description,
 		Expression:  t.Expression,
-		Parent:      t.Parent,
+		ExpressionType: proto.ScheduledTask_ExpressionType(
+			proto.ScheduledTask_ExpressionType_value[t.ExpressionType]),
+		Parent:  t.Parent,
+		Paused:  t.Paused,
+		Created: t.Created,
+		Recurrence: proto.ScheduledTask_Recurrence(
+			proto.ScheduledTask_Recurrence_value[t.Recurrence]),
+		SkipIfOpen: t.SkipIfOpen,
+		Timezone:   t.Timezone,
+		TemplateId: t.TemplateID,
 	}
 }
 
 type UpdatableScheduledTaskFields struct {
-	Title       *string
-	Description *string
-	Expression  *string
-	Parent      *string
+	Title          *string
+	Description    *string
+	Expression     *string
+	ExpressionType *string
+	Parent         *string
+	Paused         *bool
+	Recurrence     *string
+	SkipIfOpen     *bool
+	Timezone       *string
+	TemplateID     *string
+	LastFired      *int64
+	LastCompleted  *int64
 }
 
 func (db *DB) ListScheduledTasks(conn Queryable, offset, limit int) ([]ScheduledTask, error) {
+	defer metrics.ObserveQuery("list_scheduled_tasks", time.Now())
+
 	if limit == 0 || limit > db.maxResultsLimit {
 		limit = db.maxResultsLimit
 	}
 
-	statement := qb.Select("id", "title", "description", "expression", "parent").
+	statement := db.builder.Select(scheduledTaskColumns...).
 		From("scheduled_tasks").
 		Limit(uint64(limit)).
 		Offset(uint64(offset))
@@ -57,7 +96,9 @@ func (db *DB) ListScheduledTasks(conn Queryable, offset, limit int) ([]Scheduled
 }
 
 func (db *DB) GetScheduledTask(conn Queryable, id string) (ScheduledTask, error) {
-	query, args := qb.Select("id", "title", "description", "expression", "parent").
+	defer metrics.ObserveQuery("get_scheduled_task", time.Now())
+
+	query, args := db.builder.Select(scheduledTaskColumns...).
 		From("scheduled_tasks").
 		Where(qb.Eq{"id": id}).MustSql()
 
@@ -74,11 +115,22 @@ func (db *DB) GetScheduledTask(conn Queryable, id string) (ScheduledTask, error)
 	return task, nil
 }
 
+// FindScheduledTaskIDs returns up to limit IDs that start with the prefix given, sorted so that an exact match comes first.
+func (db *DB) FindScheduledTaskIDs(conn Queryable, prefix string, limit int) ([]string, error) {
+	defer metrics.ObserveQuery("find_scheduled_task_ids", time.Now())
+
+	return db.findIDs(conn, "scheduled_tasks", prefix, limit)
+}
+
 func (db *DB) InsertScheduledTask(conn Queryable, task *ScheduledTask) error {
-	_, err := conn.NamedExec(`INSERT INTO scheduled_tasks (id, title, description, expression, parent) VALUES
-	(:id, :title, :description, :expression, :parent)`, task)
+	defer metrics.ObserveQuery("insert_scheduled_task", time.Now())
+
+	_, err := conn.NamedExec(`INSERT INTO scheduled_tasks (id, title, description, expression, expression_type, parent,
+	paused, created, recurrence, skip_if_open, timezone, template_id, last_fired, last_completed) VALUES (:id, :title,
+	:description, :expression, :expression_type, :parent, :paused, :created, :recurrence, :skip_if_open, :timezone,
+	:template_id, :last_fired, :last_completed)`, task)
 	if err != nil {
-		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		if isUniqueViolation(err) {
 			return ErrEntityExists
 		}
 
@@ -89,7 +141,9 @@ func (db *DB) InsertScheduledTask(conn Queryable, task *ScheduledTask) error {
 }
 
 func (db *DB) UpdateScheduledTask(conn Queryable, id string, fields UpdatableScheduledTaskFields) error {
-	statement := qb.Update("scheduled_tasks")
+	defer metrics.ObserveQuery("update_scheduled_task", time.Now())
+
+	statement := db.builder.Update("scheduled_tasks")
 
 	if fields.Title != nil {
 		statement = statement.Set("title", fields.Title)
@@ -107,6 +161,38 @@ func (db *DB) UpdateScheduledTask(conn Queryable, id string, fields UpdatableSch
 		statement = statement.Set("expression", fields.Expression)
 	}
 
+	if fields.ExpressionType != nil {
+		statement = statement.Set("expression_type", fields.ExpressionType)
+	}
+
+	if fields.Paused != nil {
+		statement = statement.Set("paused", fields.Paused)
+	}
+
+	if fields.Recurrence != nil {
+		statement = statement.Set("recurrence", fields.Recurrence)
+	}
+
+	if fields.SkipIfOpen != nil {
+		statement = statement.Set("skip_if_open", fields.SkipIfOpen)
+	}
+
+	if fields.Timezone != nil {
+		statement = statement.Set("timezone", fields.Timezone)
+	}
+
+	if fields.TemplateID != nil {
+		statement = statement.Set("template_id", fields.TemplateID)
+	}
+
+	if fields.LastFired != nil {
+		statement = statement.Set("last_fired", fields.LastFired)
+	}
+
+	if fields.LastCompleted != nil {
+		statement = statement.Set("last_completed", fields.LastCompleted)
+	}
+
 	query, args := statement.Where(qb.Eq{"id": id}).MustSql()
 
 	_, err := conn.Exec(query, args...)
@@ -122,7 +208,9 @@ func (db *DB) UpdateScheduledTask(conn Queryable, id string, fields UpdatableSch
 }
 
 func (db *DB) DeleteScheduledTask(conn Queryable, id string) error {
-	query, args := qb.Delete("scheduled_tasks").Where(qb.Eq{"id": id}).MustSql()
+	defer metrics.ObserveQuery("delete_scheduled_task", time.Now())
+
+	query, args := db.builder.Delete("scheduled_tasks").Where(qb.Eq{"id": id}).MustSql()
 	_, err := conn.Exec(query, args...)
 	if err != nil {
 		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
@@ -130,3 +218,18 @@ func (db *DB) DeleteScheduledTask(conn Queryable, id string) error {
 
 	return nil
 }
+
+// CountScheduledTasks returns the total number of scheduled tasks.
+func (db *DB) CountScheduledTasks(conn Queryable) (int64, error) {
+	defer metrics.ObserveQuery("count_scheduled_tasks", time.Now())
+
+	query, args := db.builder.Select("count(*)").From("scheduled_tasks").MustSql()
+
+	var count int64
+	err := conn.Get(&count, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return count, nil
+}
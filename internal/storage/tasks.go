@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	qb "github.com/Masterminds/squirrel"
+	"github.com/clintjedwards/todo/internal/metrics"
 	"github.com/clintjedwards/todo/proto"
 )
 
@@ -18,35 +20,74 @@ type Task struct {
 	Created     int64  `db:"created"`
 	Modified    int64  `db:"modified"`
 	Parent      string `db:"parent"`
+	// The scheduled task that created this task, if any.
+	ScheduledTaskID string `db:"scheduled_task_id"`
+	// When the scheduled task that created this task came due, in unix milliseconds.
+	ScheduledFor int64 `db:"scheduled_for"`
+	// Incremented every time the task is updated so that clients can detect changes made since they read it.
+	Version int64 `db:"version"`
+	// When the task was completed in unix milliseconds; 0 while it is unresolved.
+	CompletedAt int64 `db:"completed_at"`
+	// Optionally, why or how the task was completed.
+	CompletionNote string `db:"completion_note"`
+}
+
+var taskColumns = []string{
+	"id", "title", "description", "state", "created", "modified", "parent", "scheduled_task_id", "scheduled_for",
+	"version", "completed_at", "completion_note",
 }
 
 func (t *Task) ToProto() *proto.Task {
 	return &proto.Task{
-		Id:          t.ID,
-		Title:       t.Title,
-		Description: t.Description,
-		State:       proto.Task_TaskState(proto.Task_TaskState_value[string(t.State)]),
-		Created:     t.Created,
-		Modified:    t.Modified,
-		Parent:      t.Parent,
+		Id:              t.ID,
+		Title:           t.Title,
+		Description:     t.Description,
+		State:           proto.Task_TaskState(proto.Task_TaskState_value[string(t.State)]),
+		Created:         t.Created,
+		Modified:        t.Modified,
+		Parent:          t.Parent,
+		ScheduledTaskId: t.ScheduledTaskID,
+		ScheduledFor:    t.ScheduledFor,
+		Version:         t.Version,
+		CompletedAt:     t.CompletedAt,
+		CompletionNote:  t.CompletionNote,
 	}
 }
 
 type UpdatableTaskFields struct {
-	Title       *string
-	Description *string
-	State       *string
-	Modified    *int64
-	Parent      *string
+	Title          *string
+	Description    *string
+	State          *string
+	Modified       *int64
+	Parent         *string
+	CompletedAt    *int64
+	CompletionNote *string
+}
+
+// TaskFilter narrows down which tasks a query matches. Tasks must match every field that is set.
+type TaskFilter struct {
+	State string
+	// Only match tasks below this task: its children, their children and so on.
+	Subtree string
+	// Only match tasks created before this time in unix milliseconds.
+	CreatedBefore int64
+	// Only match tasks completed before this time in unix milliseconds.
+	CompletedBefore int64
+	// Only match tasks without a parent.
+	TopLevel bool
 }
 
+// ListTasks returns tasks oldest first, in a stable order so that they can be paged through.
 func (db *DB) ListTasks(conn Queryable, offset, limit int, excludeCompleted bool) ([]Task, error) {
+	defer metrics.ObserveQuery("list_tasks", time.Now())
+
 	if limit == 0 || limit > db.maxResultsLimit {
 		limit = db.maxResultsLimit
 	}
 
-	statement := qb.Select("id", "title", "description", "state", "created", "modified", "parent").
+	statement := db.builder.Select(taskColumns...).
 		From("tasks").
+		OrderBy("created", "id").
 		Limit(uint64(limit)).
 		Offset(uint64(offset))
 
@@ -66,7 +107,9 @@ func (db *DB) ListTasks(conn Queryable, offset, limit int, excludeCompleted bool
 }
 
 func (db *DB) GetTask(conn Queryable, id string) (Task, error) {
-	query, args := qb.Select("id", "title", "description", "state", "created", "modified", "parent").
+	defer metrics.ObserveQuery("get_task", time.Now())
+
+	query, args := db.builder.Select(taskColumns...).
 		From("tasks").
 		Where(qb.Eq{"id": id}).MustSql()
 
@@ -83,8 +126,63 @@ func (db *DB) GetTask(conn Queryable, id string) (Task, error) {
 	return task, nil
 }
 
+// FindTaskIDs returns up to limit IDs that start with the prefix given, sorted so that an exact match comes first.
+func (db *DB) FindTaskIDs(conn Queryable, prefix string, limit int) ([]string, error) {
+	defer metrics.ObserveQuery("find_task_ids", time.Now())
+
+	return db.findIDs(conn, "tasks", prefix, limit)
+}
+
+// FindTaskIDsMatching returns the IDs of every task that matches the filter given, oldest first.
+func (db *DB) FindTaskIDsMatching(conn Queryable, filter TaskFilter) ([]string, error) {
+	defer metrics.ObserveQuery("find_task_ids_matching", time.Now())
+
+	statement := db.builder.Select("id").
+		From("tasks").
+		OrderBy("created", "id")
+
+	if filter.State != "" {
+		statement = statement.Where(qb.Eq{"state": filter.State})
+	}
+
+	if filter.CreatedBefore != 0 {
+		statement = statement.Where(qb.Lt{"created": filter.CreatedBefore})
+	}
+
+	if filter.CompletedBefore != 0 {
+		statement = statement.Where(qb.And{qb.Gt{"completed_at": 0}, qb.Lt{"completed_at": filter.CompletedBefore}})
+	}
+
+	if filter.TopLevel {
+		statement = statement.Where(qb.Or{qb.Eq{"parent": ""}, qb.Eq{"parent": nil}})
+	}
+
+	if filter.Subtree != "" {
+		statement = statement.Where(qb.Expr(`id IN (
+			WITH RECURSIVE subtree(id) AS (
+				SELECT id FROM tasks WHERE parent = ?
+				UNION
+				SELECT tasks.id FROM tasks JOIN subtree ON tasks.parent = subtree.id
+			)
+			SELECT id FROM subtree
+		)`, filter.Subtree))
+	}
+
+	query, args := statement.MustSql()
+
+	ids := []string{}
+	err := conn.Select(&ids, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return ids, nil
+}
+
 func (db *DB) GetTaskChildren(conn Queryable, parentID string) ([]Task, error) {
-	statement := qb.Select("id", "title", "description", "state", "created", "modified", "parent").
+	defer metrics.ObserveQuery("get_task_children", time.Now())
+
+	statement := db.builder.Select(taskColumns...).
 		From("tasks").
 		Where(qb.Eq{"parent": parentID})
 
@@ -99,11 +197,109 @@ func (db *DB) GetTaskChildren(conn Queryable, parentID string) ([]Task, error) {
 	return tasks, nil
 }
 
+// GetLatestScheduledTaskInstance returns the most recently created task created by the scheduled task given.
+func (db *DB) GetLatestScheduledTaskInstance(conn Queryable, scheduledTaskID string) (Task, error) {
+	defer metrics.ObserveQuery("get_latest_scheduled_task_instance", time.Now())
+
+	query, args := db.builder.Select(taskColumns...).
+		From("tasks").
+		Where(qb.Eq{"scheduled_task_id": scheduledTaskID}).
+		OrderBy("created DESC", "id DESC").
+		Limit(1).MustSql()
+
+	task := Task{}
+	err := conn.Get(&task, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Task{}, ErrEntityNotFound
+		}
+
+		return Task{}, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return task, nil
+}
+
+// ListScheduledTaskInstances returns the tasks created by the scheduled task given, newest first. Archived tasks are
+// included since they're still part of the scheduled task's history.
+func (db *DB) ListScheduledTaskInstances(conn Queryable, scheduledTaskID string, offset, limit int) ([]Task, error) {
+	defer metrics.ObserveQuery("list_scheduled_task_instances", time.Now())
+
+	if limit == 0 || limit > db.maxResultsLimit {
+		limit = db.maxResultsLimit
+	}
+
+	columns := strings.Join(taskColumns, ", ")
+
+	query, args := db.builder.Select(taskColumns...).
+		From(fmt.Sprintf(`(SELECT %s FROM tasks UNION ALL SELECT %s FROM archived_tasks) AS all_tasks`,
+			columns, columns)).
+		Where(qb.Eq{"scheduled_task_id": scheduledTaskID}).
+		OrderBy("created DESC", "id DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).MustSql()
+
+	tasks := []Task{}
+	err := conn.Select(&tasks, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return tasks, nil
+}
+
 func (db *DB) InsertTask(conn Queryable, task *Task) error {
-	_, err := conn.NamedExec(`INSERT INTO tasks (id, title, description, state, created, modified, parent) VALUES
-	(:id, :title, :description, :state, :created, :modified, :parent)`, task)
+	defer metrics.ObserveQuery("insert_task", time.Now())
+
+	_, err := conn.NamedExec(`INSERT INTO tasks (id, title, description, state, created, modified, parent,
+	scheduled_task_id, scheduled_for, version, completed_at, completion_note) VALUES (:id, :title, :description,
+	:state, :created, :modified, :parent, :scheduled_task_id, :scheduled_for, :version, :completed_at,
+	:completion_note)`, task)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrEntityExists
+		}
+
+		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return nil
+}
+
+// GetTaskCreateRequest returns the ID of the task created by the create request with the request ID given.
+func (db *DB) GetTaskCreateRequest(conn Queryable, requestID string) (string, error) {
+	defer metrics.ObserveQuery("get_task_create_request", time.Now())
+
+	query, args := db.builder.Select("task_id").
+		From("task_create_requests").
+		Where(qb.Eq{"request_id": requestID}).MustSql()
+
+	var taskID string
+	err := conn.Get(&taskID, query, args...)
 	if err != nil {
-		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrEntityNotFound
+		}
+
+		return "", fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return taskID, nil
+}
+
+// InsertTaskCreateRequest records that the create request with the request ID given created the task given, so that
+// the request can be retried without creating the task twice.
+func (db *DB) InsertTaskCreateRequest(conn Queryable, requestID, taskID string, created int64) error {
+	defer metrics.ObserveQuery("insert_task_create_request", time.Now())
+
+	_, err := conn.NamedExec(`INSERT INTO task_create_requests (request_id, task_id, created) VALUES
+	(:request_id, :task_id, :created)`, map[string]any{
+		"request_id": requestID,
+		"task_id":    taskID,
+		"created":    created,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
 			return ErrEntityExists
 		}
 
@@ -113,8 +309,38 @@ func (db *DB) InsertTask(conn Queryable, task *Task) error {
 	return nil
 }
 
+// UpdateTask changes the fields given and increments the task's version.
 func (db *DB) UpdateTask(conn Queryable, id string, fields UpdatableTaskFields) error {
-	statement := qb.Update("tasks")
+	defer metrics.ObserveQuery("update_task", time.Now())
+
+	_, err := db.updateTask(conn, qb.Eq{"id": id}, fields)
+	return err
+}
+
+// UpdateTaskAtVersion changes the fields given only if the task is still at the version given, returning ErrConflict
+// if it has been changed since.
+func (db *DB) UpdateTaskAtVersion(conn Queryable, id string, version int64, fields UpdatableTaskFields) error {
+	defer metrics.ObserveQuery("update_task_at_version", time.Now())
+
+	updated, err := db.updateTask(conn, qb.Eq{"id": id, "version": version}, fields)
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		_, err := db.GetTask(conn, id)
+		if err != nil {
+			return err
+		}
+		return ErrConflict
+	}
+
+	return nil
+}
+
+// updateTask changes the fields given on every task matching where, returning the number of tasks changed.
+func (db *DB) updateTask(conn Queryable, where qb.Eq, fields UpdatableTaskFields) (int64, error) {
+	statement := db.builder.Update("tasks").Set("version", qb.Expr("version + 1"))
 
 	if fields.Title != nil {
 		statement = statement.Set("title", fields.Title)
@@ -136,22 +362,37 @@ func (db *DB) UpdateTask(conn Queryable, id string, fields UpdatableTaskFields)
 		statement = statement.Set("parent", fields.Parent)
 	}
 
-	query, args := statement.Where(qb.Eq{"id": id}).MustSql()
+	if fields.CompletedAt != nil {
+		statement = statement.Set("completed_at", fields.CompletedAt)
+	}
 
-	_, err := conn.Exec(query, args...)
+	if fields.CompletionNote != nil {
+		statement = statement.Set("completion_note", fields.CompletionNote)
+	}
+
+	query, args := statement.Where(where).MustSql()
+
+	result, err := conn.Exec(query, args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return ErrEntityNotFound
+			return 0, ErrEntityNotFound
 		}
 
-		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+		return 0, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
 	}
 
-	return nil
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return updated, nil
 }
 
 func (db *DB) DeleteTask(conn Queryable, id string) error {
-	query, args := qb.Delete("tasks").Where(qb.Eq{"id": id}).MustSql()
+	defer metrics.ObserveQuery("delete_task", time.Now())
+
+	query, args := db.builder.Delete("tasks").Where(qb.Eq{"id": id}).MustSql()
 	_, err := conn.Exec(query, args...)
 	if err != nil {
 		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
@@ -159,3 +400,29 @@ func (db *DB) DeleteTask(conn Queryable, id string) error {
 
 	return nil
 }
+
+// CountTasks returns the number of tasks in each state.
+func (db *DB) CountTasks(conn Queryable) (map[string]int64, error) {
+	defer metrics.ObserveQuery("count_tasks", time.Now())
+
+	query, args := db.builder.Select("state", "count(*) AS count").
+		From("tasks").
+		GroupBy("state").MustSql()
+
+	rows := []struct {
+		State string `db:"state"`
+		Count int64  `db:"count"`
+	}{}
+
+	err := conn.Select(&rows, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	counts := map[string]int64{}
+	for _, row := range rows {
+		counts[row.State] = row.Count
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	qb "github.com/Masterminds/squirrel"
+	"github.com/clintjedwards/todo/internal/metrics"
+	"github.com/clintjedwards/todo/proto"
+)
+
+type Template struct {
+	ID          string        `db:"id"`
+	Name        string        `db:"name"`
+	Description string        `db:"description"`
+	Tasks       TemplateTasks `db:"tasks"`
+	Created     int64         `db:"created"`
+}
+
+var templateColumns = []string{"id", "name", "description", "tasks", "created"}
+
+func (t *Template) ToProto() *proto.Template {
+	return &proto.Template{
+		Id:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		Tasks:       t.Tasks.ToProto(),
+		Created:     t.Created,
+	}
+}
+
+type TemplateTask struct {
+	Title       string        `json:"title"`
+	Description string        `json:"description,omitempty"`
+	Children    TemplateTasks `json:"children,omitempty"`
+}
+
+// TemplateTasks is a tree of tasks stored as a single JSON column since it is always read and written as a whole.
+type TemplateTasks []TemplateTask
+
+func (t TemplateTasks) ToProto() []*proto.TemplateTask {
+	tasks := []*proto.TemplateTask{}
+	for _, task := range t {
+		tasks = append(tasks, &proto.TemplateTask{
+			Title:       task.Title,
+			Description: task.Description,
+			Children:    task.Children.ToProto(),
+		})
+	}
+	return tasks
+}
+
+// Value implements driver.Valuer so the tree can be written to the database.
+func (t TemplateTasks) Value() (driver.Value, error) {
+	if t == nil {
+		t = TemplateTasks{}
+	}
+
+	raw, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(raw), nil
+}
+
+// Scan implements sql.Scanner so the tree can be read from the database.
+func (t *TemplateTasks) Scan(value any) error {
+	switch v := value.(type) {
+	case string:
+		return json.Unmarshal([]byte(v), t)
+	case []byte:
+		return json.Unmarshal(v, t)
+	default:
+		return fmt.Errorf("could not scan %T into template tasks", value)
+	}
+}
+
+func (db *DB) ListTemplates(conn Queryable, offset, limit int) ([]Template, error) {
+	defer metrics.ObserveQuery("list_templates", time.Now())
+
+	if limit == 0 || limit > db.maxResultsLimit {
+		limit = db.maxResultsLimit
+	}
+
+	query, args := db.builder.Select(templateColumns...).
+		From("templates").
+		OrderBy("name").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).MustSql()
+
+	templates := []Template{}
+	err := conn.Select(&templates, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return templates, nil
+}
+
+func (db *DB) GetTemplate(conn Queryable, id string) (Template, error) {
+	defer metrics.ObserveQuery("get_template", time.Now())
+
+	return db.getTemplate(conn, qb.Eq{"id": id})
+}
+
+func (db *DB) GetTemplateByName(conn Queryable, name string) (Template, error) {
+	defer metrics.ObserveQuery("get_template_by_name", time.Now())
+
+	return db.getTemplate(conn, qb.Eq{"name": name})
+}
+
+func (db *DB) getTemplate(conn Queryable, where qb.Eq) (Template, error) {
+	query, args := db.builder.Select(templateColumns...).
+		From("templates").
+		Where(where).MustSql()
+
+	template := Template{}
+	err := conn.Get(&template, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Template{}, ErrEntityNotFound
+		}
+
+		return Template{}, fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return template, nil
+}
+
+// FindTemplateIDs returns up to limit IDs that start with the prefix given, sorted so that an exact match comes first.
+func (db *DB) FindTemplateIDs(conn Queryable, prefix string, limit int) ([]string, error) {
+	defer metrics.ObserveQuery("find_template_ids", time.Now())
+
+	return db.findIDs(conn, "templates", prefix, limit)
+}
+
+func (db *DB) InsertTemplate(conn Queryable, template *Template) error {
+	defer metrics.ObserveQuery("insert_template", time.Now())
+
+	_, err := conn.NamedExec(`INSERT INTO templates (id, name, description, tasks, created) VALUES
+	(:id, :name, :description, :tasks, :created)`, template)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrEntityExists
+		}
+
+		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return nil
+}
+
+func (db *DB) DeleteTemplate(conn Queryable, id string) error {
+	defer metrics.ObserveQuery("delete_template", time.Now())
+
+	query, args := db.builder.Delete("templates").Where(qb.Eq{"id": id}).MustSql()
+	_, err := conn.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("database error occurred: %v; %w", err, ErrInternal)
+	}
+
+	return nil
+}
@@ -0,0 +1,236 @@
+// Package tasktemplate reads, writes and fills in task templates.
+//
+// Templates are written as an indented outline where every line is a task and indentation makes a task the child of
+// the one above it. A description can follow the title after a "|". List markers are optional:
+//
+//	Pay bills for {{month}} {{year}}
+//	  - Electricity | Account 1234
+//	  - Water
+//	  - Internet
+//
+// Titles and descriptions can contain variables like {{month}} that are filled in when the template is applied. See
+// Variables for the ones that are always available.
+package tasktemplate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/clintjedwards/todo/proto"
+)
+
+// descriptionSeparator separates a task's title from its description in an outline.
+const descriptionSeparator = "|"
+
+// ParseOutline reads the tasks described by an outline.
+func ParseOutline(outline string) ([]*proto.TemplateTask, error) {
+	type level struct {
+		indent int
+		task   *proto.TemplateTask
+	}
+
+	roots := []*proto.TemplateTask{}
+	rootIndent := 0
+
+	// The chain of tasks from the root down to the most recently read task.
+	stack := []level{}
+
+	for i, line := range strings.Split(outline, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		indent := indentation(line)
+		title, description, _ := strings.Cut(trimListMarker(trimmed), descriptionSeparator)
+		task := &proto.TemplateTask{
+			Title:       strings.TrimSpace(title),
+			Description: strings.TrimSpace(description),
+		}
+
+		if task.Title == "" {
+			return nil, fmt.Errorf("line %d: task has no title", i+1)
+		}
+
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		if len(stack) == 0 {
+			if len(roots) == 0 {
+				rootIndent = indent
+			} else if indent != rootIndent {
+				return nil, fmt.Errorf("line %d: indentation doesn't match any task above it", i+1)
+			}
+			roots = append(roots, task)
+		} else {
+			parent := stack[len(stack)-1].task
+			parent.Children = append(parent.Children, task)
+		}
+
+		stack = append(stack, level{indent: indent, task: task})
+	}
+
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("template has no tasks")
+	}
+
+	return roots, nil
+}
+
+// FormatOutline writes tasks out as an outline that ParseOutline can read back.
+func FormatOutline(tasks []*proto.TemplateTask) string {
+	var b strings.Builder
+	writeOutline(&b, tasks, 0)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func writeOutline(b *strings.Builder, tasks []*proto.TemplateTask, depth int) {
+	for _, task := range tasks {
+		b.WriteString(strings.Repeat("  ", depth))
+		if depth > 0 {
+			b.WriteString("- ")
+		}
+		b.WriteString(task.Title)
+		if task.Description != "" {
+			fmt.Fprintf(b, " %s %s", descriptionSeparator, task.Description)
+		}
+		b.WriteString("\n")
+
+		writeOutline(b, task.Children, depth+1)
+	}
+}
+
+// Count returns the number of tasks in the trees given.
+func Count(tasks []*proto.TemplateTask) int {
+	count := 0
+	for _, task := range tasks {
+		count += 1 + Count(task.Children)
+	}
+	return count
+}
+
+// indentation returns the width of the whitespace at the start of a line, counting tabs as four spaces.
+func indentation(line string) int {
+	width := 0
+	for _, char := range line {
+		switch char {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+func trimListMarker(line string) string {
+	for _, marker := range []string{"- ", "* ", "+ "} {
+		if strings.HasPrefix(line, marker) {
+			return strings.TrimSpace(strings.TrimPrefix(line, marker))
+		}
+	}
+	return line
+}
+
+var variableRegex = regexp.MustCompile(`{{\s*([a-zA-Z0-9_]+)\s*}}`)
+
+// Variables returns the variables always available to templates, describing the time given:
+//
+//	{{date}}          2024-03-01
+//	{{day}}           1
+//	{{weekday}}       Friday
+//	{{week}}          9, the ISO week number
+//	{{month}}         March
+//	{{month_number}}  3
+//	{{year}}          2024
+//	{{time}}          15:04
+func Variables(t time.Time) map[string]string {
+	_, week := t.ISOWeek()
+
+	return map[string]string{
+		"date":         t.Format("2006-01-02"),
+		"day":          strconv.Itoa(t.Day()),
+		"weekday":      t.Weekday().String(),
+		"week":         strconv.Itoa(week),
+		"month":        t.Month().String(),
+		"month_number": strconv.Itoa(int(t.Month())),
+		"year":         strconv.Itoa(t.Year()),
+		"time":         t.Format("15:04"),
+	}
+}
+
+// Render fills in the variables used in the text given. Using a variable that isn't set is an error rather than
+// silently leaving a hole in a task's title.
+func Render(text string, variables map[string]string) (string, error) {
+	var missing []string
+
+	rendered := variableRegex.ReplaceAllStringFunc(text, func(match string) string {
+		name := variableRegex.FindStringSubmatch(match)[1]
+		value, ok := variables[name]
+		if !ok {
+			missing = append(missing, name)
+			return match
+		}
+		return value
+	})
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("variable %q is not set", missing[0])
+	}
+
+	return rendered, nil
+}
+
+// RenderTasks returns a copy of the task trees given with their variables filled in.
+func RenderTasks(tasks []*proto.TemplateTask, variables map[string]string) ([]*proto.TemplateTask, error) {
+	rendered := []*proto.TemplateTask{}
+
+	for _, task := range tasks {
+		title, err := Render(task.Title, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		description, err := Render(task.Description, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		children, err := RenderTasks(task.Children, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		rendered = append(rendered, &proto.TemplateTask{Title: title, Description: description, Children: children})
+	}
+
+	return rendered, nil
+}
+
+// Validate checks that the task trees given could be saved as a template.
+func Validate(tasks []*proto.TemplateTask) error {
+	if len(tasks) == 0 {
+		return fmt.Errorf("template has no tasks")
+	}
+
+	for _, task := range tasks {
+		if strings.TrimSpace(task.Title) == "" {
+			return fmt.Errorf("every task in a template needs a title")
+		}
+
+		if len(task.Children) > 0 {
+			err := Validate(task.Children)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
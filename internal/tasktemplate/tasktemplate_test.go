@@ -0,0 +1,88 @@
+package tasktemplate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clintjedwards/todo/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestParseOutline(t *testing.T) {
+	outline := `
+# Comments and blank lines are ignored.
+Pay bills for {{month}} {{year}}
+  - Electricity | Account 1234
+  - Water
+	* Check meter
+Weekly review
+`
+
+	want := []*proto.TemplateTask{
+		{Title: "Pay bills for {{month}} {{year}}", Children: []*proto.TemplateTask{
+			{Title: "Electricity", Description: "Account 1234"},
+			{Title: "Water", Children: []*proto.TemplateTask{
+				{Title: "Check meter"},
+			}},
+		}},
+		{Title: "Weekly review"},
+	}
+
+	got, err := ParseOutline(outline)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected tasks (-want +got):\n%s", diff)
+	}
+
+	if count := Count(got); count != 5 {
+		t.Errorf("incorrect number of tasks counted; got %d; want %d", count, 5)
+	}
+
+	// Formatting and parsing again should give back the same tasks.
+	roundTrip, err := ParseOutline(FormatOutline(got))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(want, roundTrip, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected tasks after formatting (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseOutlineErrors(t *testing.T) {
+	tests := map[string]string{
+		"empty":             "\n# just a comment\n",
+		"missing title":     "Trip\n  - | no title",
+		"mismatched indent": "  Trip\n    Pack\n Unpack",
+	}
+
+	for name, outline := range tests {
+		if _, err := ParseOutline(outline); err == nil {
+			t.Errorf("%s: expected an error parsing %q", name, outline)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	variables := Variables(time.Date(2024, time.March, 1, 15, 4, 0, 0, time.UTC))
+	variables["place"] = "Lisbon"
+
+	got, err := Render("{{weekday}} {{ date }}: trip to {{place}} in {{month}} (week {{week}}) at {{time}}", variables)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "Friday 2024-03-01: trip to Lisbon in March (week 9) at 15:04"
+	if got != want {
+		t.Errorf("unexpected render; got %q; want %q", got, want)
+	}
+
+	_, err = Render("Trip to {{destination}}", variables)
+	if err == nil {
+		t.Errorf("expected an error rendering an unset variable")
+	}
+}
@@ -25,7 +25,7 @@ var File_todo_proto protoreflect.FileDescriptor
 const file_todo_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"todo.proto\x12\x05proto\x1a\x14todo_transport.proto2\xdf\x06\n" +
+	"todo.proto\x12\x05proto\x1a\x14todo_transport.proto2\xbe\x14\n" +
 	"\x04Todo\x12J\n" +
 	"\rGetSystemInfo\x12\x1b.proto.GetSystemInfoRequest\x1a\x1c.proto.GetSystemInfoResponse\x12>\n" +
 	"\tListTasks\x12\x17.proto.ListTasksRequest\x1a\x18.proto.ListTasksResponse\x12A\n" +
@@ -35,36 +35,99 @@ const file_todo_proto_rawDesc = "" +
 	"\n" +
 	"UpdateTask\x12\x18.proto.UpdateTaskRequest\x1a\x19.proto.UpdateTaskResponse\x12A\n" +
 	"\n" +
-	"DeleteTask\x12\x18.proto.DeleteTaskRequest\x1a\x19.proto.DeleteTaskResponse\x12Y\n" +
+	"DeleteTask\x12\x18.proto.DeleteTaskRequest\x1a\x19.proto.DeleteTaskResponse\x12S\n" +
+	"\x10BatchUpdateTasks\x12\x1e.proto.BatchUpdateTasksRequest\x1a\x1f.proto.BatchUpdateTasksResponse\x12S\n" +
+	"\x10BatchDeleteTasks\x12\x1e.proto.BatchDeleteTasksRequest\x1a\x1f.proto.BatchDeleteTasksResponse\x12M\n" +
+	"\x0eListOperations\x12\x1c.proto.ListOperationsRequest\x1a\x1d.proto.ListOperationsResponse\x12J\n" +
+	"\rUndoOperation\x12\x1b.proto.UndoOperationRequest\x1a\x1c.proto.UndoOperationResponse\x12G\n" +
+	"\fGetTaskStats\x12\x1a.proto.GetTaskStatsRequest\x1a\x1b.proto.GetTaskStatsResponse\x12V\n" +
+	"\x11ListArchivedTasks\x12\x1f.proto.ListArchivedTasksRequest\x1a .proto.ListArchivedTasksResponse\x12\\\n" +
+	"\x13RestoreArchivedTask\x12!.proto.RestoreArchivedTaskRequest\x1a\".proto.RestoreArchivedTaskResponse\x12M\n" +
+	"\x0eAddTaskComment\x12\x1c.proto.AddTaskCommentRequest\x1a\x1d.proto.AddTaskCommentResponse\x12S\n" +
+	"\x10ListTaskComments\x12\x1e.proto.ListTaskCommentsRequest\x1a\x1f.proto.ListTaskCommentsResponse\x12U\n" +
+	"\x10UploadAttachment\x12\x1e.proto.UploadAttachmentRequest\x1a\x1f.proto.UploadAttachmentResponse(\x01\x12P\n" +
+	"\x0fListAttachments\x12\x1d.proto.ListAttachmentsRequest\x1a\x1e.proto.ListAttachmentsResponse\x12[\n" +
+	"\x12DownloadAttachment\x12 .proto.DownloadAttachmentRequest\x1a!.proto.DownloadAttachmentResponse0\x01\x12Y\n" +
 	"\x12ListScheduledTasks\x12 .proto.ListScheduledTasksRequest\x1a!.proto.ListScheduledTasksResponse\x12\\\n" +
 	"\x13CreateScheduledTask\x12!.proto.CreateScheduledTaskRequest\x1a\".proto.CreateScheduledTaskResponse\x12S\n" +
 	"\x10GetScheduledTask\x12\x1e.proto.GetScheduledTaskRequest\x1a\x1f.proto.GetScheduledTaskResponse\x12\\\n" +
 	"\x13UpdateScheduledTask\x12!.proto.UpdateScheduledTaskRequest\x1a\".proto.UpdateScheduledTaskResponse\x12\\\n" +
-	"\x13DeleteScheduledTask\x12!.proto.DeleteScheduledTaskRequest\x1a\".proto.DeleteScheduledTaskResponseB%Z#github.com/clintjedwards/todo/protob\x06proto3"
+	"\x13DeleteScheduledTask\x12!.proto.DeleteScheduledTaskRequest\x1a\".proto.DeleteScheduledTaskResponse\x12Y\n" +
+	"\x12PauseScheduledTask\x12 .proto.PauseScheduledTaskRequest\x1a!.proto.PauseScheduledTaskResponse\x12\\\n" +
+	"\x13ResumeScheduledTask\x12!.proto.ResumeScheduledTaskRequest\x1a\".proto.ResumeScheduledTaskResponse\x12h\n" +
+	"\x17GetScheduledTaskHistory\x12%.proto.GetScheduledTaskHistoryRequest\x1a&.proto.GetScheduledTaskHistoryResponse\x12P\n" +
+	"\x0fPreviewSchedule\x12\x1d.proto.PreviewScheduleRequest\x1a\x1e.proto.PreviewScheduleResponse\x12J\n" +
+	"\rListTemplates\x12\x1b.proto.ListTemplatesRequest\x1a\x1c.proto.ListTemplatesResponse\x12D\n" +
+	"\vGetTemplate\x12\x19.proto.GetTemplateRequest\x1a\x1a.proto.GetTemplateResponse\x12M\n" +
+	"\x0eCreateTemplate\x12\x1c.proto.CreateTemplateRequest\x1a\x1d.proto.CreateTemplateResponse\x12M\n" +
+	"\x0eDeleteTemplate\x12\x1c.proto.DeleteTemplateRequest\x1a\x1d.proto.DeleteTemplateResponse\x12J\n" +
+	"\rApplyTemplate\x12\x1b.proto.ApplyTemplateRequest\x1a\x1c.proto.ApplyTemplateResponseB%Z#github.com/clintjedwards/todo/protob\x06proto3"
 
 var file_todo_proto_goTypes = []any{
-	(*GetSystemInfoRequest)(nil),        // 0: proto.GetSystemInfoRequest
-	(*ListTasksRequest)(nil),            // 1: proto.ListTasksRequest
-	(*CreateTaskRequest)(nil),           // 2: proto.CreateTaskRequest
-	(*GetTaskRequest)(nil),              // 3: proto.GetTaskRequest
-	(*UpdateTaskRequest)(nil),           // 4: proto.UpdateTaskRequest
-	(*DeleteTaskRequest)(nil),           // 5: proto.DeleteTaskRequest
-	(*ListScheduledTasksRequest)(nil),   // 6: proto.ListScheduledTasksRequest
-	(*CreateScheduledTaskRequest)(nil),  // 7: proto.CreateScheduledTaskRequest
-	(*GetScheduledTaskRequest)(nil),     // 8: proto.GetScheduledTaskRequest
-	(*UpdateScheduledTaskRequest)(nil),  // 9: proto.UpdateScheduledTaskRequest
-	(*DeleteScheduledTaskRequest)(nil),  // 10: proto.DeleteScheduledTaskRequest
-	(*GetSystemInfoResponse)(nil),       // 11: proto.GetSystemInfoResponse
-	(*ListTasksResponse)(nil),           // 12: proto.ListTasksResponse
-	(*CreateTaskResponse)(nil),          // 13: proto.CreateTaskResponse
-	(*GetTaskResponse)(nil),             // 14: proto.GetTaskResponse
-	(*UpdateTaskResponse)(nil),          // 15: proto.UpdateTaskResponse
-	(*DeleteTaskResponse)(nil),          // 16: proto.DeleteTaskResponse
-	(*ListScheduledTasksResponse)(nil),  // 17: proto.ListScheduledTasksResponse
-	(*CreateScheduledTaskResponse)(nil), // 18: proto.CreateScheduledTaskResponse
-	(*GetScheduledTaskResponse)(nil),    // 19: proto.GetScheduledTaskResponse
-	(*UpdateScheduledTaskResponse)(nil), // 20: proto.UpdateScheduledTaskResponse
-	(*DeleteScheduledTaskResponse)(nil), // 21: proto.DeleteScheduledTaskResponse
+	(*GetSystemInfoRequest)(nil),            // 0: proto.GetSystemInfoRequest
+	(*ListTasksRequest)(nil),                // 1: proto.ListTasksRequest
+	(*CreateTaskRequest)(nil),               // 2: proto.CreateTaskRequest
+	(*GetTaskRequest)(nil),                  // 3: proto.GetTaskRequest
+	(*UpdateTaskRequest)(nil),               // 4: proto.UpdateTaskRequest
+	(*DeleteTaskRequest)(nil),               // 5: proto.DeleteTaskRequest
+	(*BatchUpdateTasksRequest)(nil),         // 6: proto.BatchUpdateTasksRequest
+	(*BatchDeleteTasksRequest)(nil),         // 7: proto.BatchDeleteTasksRequest
+	(*ListOperationsRequest)(nil),           // 8: proto.ListOperationsRequest
+	(*UndoOperationRequest)(nil),            // 9: proto.UndoOperationRequest
+	(*GetTaskStatsRequest)(nil),             // 10: proto.GetTaskStatsRequest
+	(*ListArchivedTasksRequest)(nil),        // 11: proto.ListArchivedTasksRequest
+	(*RestoreArchivedTaskRequest)(nil),      // 12: proto.RestoreArchivedTaskRequest
+	(*AddTaskCommentRequest)(nil),           // 13: proto.AddTaskCommentRequest
+	(*ListTaskCommentsRequest)(nil),         // 14: proto.ListTaskCommentsRequest
+	(*UploadAttachmentRequest)(nil),         // 15: proto.UploadAttachmentRequest
+	(*ListAttachmentsRequest)(nil),          // 16: proto.ListAttachmentsRequest
+	(*DownloadAttachmentRequest)(nil),       // 17: proto.DownloadAttachmentRequest
+	(*ListScheduledTasksRequest)(nil),       // 18: proto.ListScheduledTasksRequest
+	(*CreateScheduledTaskRequest)(nil),      // 19: proto.CreateScheduledTaskRequest
+	(*GetScheduledTaskRequest)(nil),         // 20: proto.GetScheduledTaskRequest
+	(*UpdateScheduledTaskRequest)(nil),      // 21: proto.UpdateScheduledTaskRequest
+	(*DeleteScheduledTaskRequest)(nil),      // 22: proto.DeleteScheduledTaskRequest
+	(*PauseScheduledTaskRequest)(nil),       // 23: proto.PauseScheduledTaskRequest
+	(*ResumeScheduledTaskRequest)(nil),      // 24: proto.ResumeScheduledTaskRequest
+	(*GetScheduledTaskHistoryRequest)(nil),  // 25: proto.GetScheduledTaskHistoryRequest
+	(*PreviewScheduleRequest)(nil),          // 26: proto.PreviewScheduleRequest
+	(*ListTemplatesRequest)(nil),            // 27: proto.ListTemplatesRequest
+	(*GetTemplateRequest)(nil),              // 28: proto.GetTemplateRequest
+	(*CreateTemplateRequest)(nil),           // 29: proto.CreateTemplateRequest
+	(*DeleteTemplateRequest)(nil),           // 30: proto.DeleteTemplateRequest
+	(*ApplyTemplateRequest)(nil),            // 31: proto.ApplyTemplateRequest
+	(*GetSystemInfoResponse)(nil),           // 32: proto.GetSystemInfoResponse
+	(*ListTasksResponse)(nil),               // 33: proto.ListTasksResponse
+	(*CreateTaskResponse)(nil),              // 34: proto.CreateTaskResponse
+	(*GetTaskResponse)(nil),                 // 35: proto.GetTaskResponse
+	(*UpdateTaskResponse)(nil),              // 36: proto.UpdateTaskResponse
+	(*DeleteTaskResponse)(nil),              // 37: proto.DeleteTaskResponse
+	(*BatchUpdateTasksResponse)(nil),        // 38: proto.BatchUpdateTasksResponse
+	(*BatchDeleteTasksResponse)(nil),        // 39: proto.BatchDeleteTasksResponse
+	(*ListOperationsResponse)(nil),          // 40: proto.ListOperationsResponse
+	(*UndoOperationResponse)(nil),           // 41: proto.UndoOperationResponse
+	(*GetTaskStatsResponse)(nil),            // 42: proto.GetTaskStatsResponse
+	(*ListArchivedTasksResponse)(nil),       // 43: proto.ListArchivedTasksResponse
+	(*RestoreArchivedTaskResponse)(nil),     // 44: proto.RestoreArchivedTaskResponse
+	(*AddTaskCommentResponse)(nil),          // 45: proto.AddTaskCommentResponse
+	(*ListTaskCommentsResponse)(nil),        // 46: proto.ListTaskCommentsResponse
+	(*UploadAttachmentResponse)(nil),        // 47: proto.UploadAttachmentResponse
+	(*ListAttachmentsResponse)(nil),         // 48: proto.ListAttachmentsResponse
+	(*DownloadAttachmentResponse)(nil),      // 49: proto.DownloadAttachmentResponse
+	(*ListScheduledTasksResponse)(nil),      // 50: proto.ListScheduledTasksResponse
+	(*CreateScheduledTaskResponse)(nil),     // 51: proto.CreateScheduledTaskResponse
+	(*GetScheduledTaskResponse)(nil),        // 52: proto.GetScheduledTaskResponse
+	(*UpdateScheduledTaskResponse)(nil),     // 53: proto.UpdateScheduledTaskResponse
+	(*DeleteScheduledTaskResponse)(nil),     // 54: proto.DeleteScheduledTaskResponse
+	(*PauseScheduledTaskResponse)(nil),      // 55: proto.PauseScheduledTaskResponse
+	(*ResumeScheduledTaskResponse)(nil),     // 56: proto.ResumeScheduledTaskResponse
+	(*GetScheduledTaskHistoryResponse)(nil), // 57: proto.GetScheduledTaskHistoryResponse
+	(*PreviewScheduleResponse)(nil),         // 58: proto.PreviewScheduleResponse
+	(*ListTemplatesResponse)(nil),           // 59: proto.ListTemplatesResponse
+	(*GetTemplateResponse)(nil),             // 60: proto.GetTemplateResponse
+	(*CreateTemplateResponse)(nil),          // 61: proto.CreateTemplateResponse
+	(*DeleteTemplateResponse)(nil),          // 62: proto.DeleteTemplateResponse
+	(*ApplyTemplateResponse)(nil),           // 63: proto.ApplyTemplateResponse
 }
 var file_todo_proto_depIdxs = []int32{
 	0,  // 0: proto.Todo.GetSystemInfo:input_type -> proto.GetSystemInfoRequest
@@ -73,24 +136,66 @@ var file_todo_proto_depIdxs = []int32{
 	3,  // 3: proto.Todo.GetTask:input_type -> proto.GetTaskRequest
 	4,  // 4: proto.Todo.UpdateTask:input_type -> proto.UpdateTaskRequest
 	5,  // 5: proto.Todo.DeleteTask:input_type -> proto.DeleteTaskRequest
-	6,  // 6: proto.Todo.ListScheduledTasks:input_type -> proto.ListScheduledTasksRequest
-	7,  // 7: proto.Todo.CreateScheduledTask:input_type -> proto.CreateScheduledTaskRequest
-	8,  // 8: proto.Todo.GetScheduledTask:input_type -> proto.GetScheduledTaskRequest
-	9,  // 9: proto.Todo.UpdateScheduledTask:input_type -> proto.UpdateScheduledTaskRequest
-	10, // 10: proto.Todo.DeleteScheduledTask:input_type -> proto.DeleteScheduledTaskRequest
-	11, // 11: proto.Todo.GetSystemInfo:output_type -> proto.GetSystemInfoResponse
-	12, // 12: proto.Todo.ListTasks:output_type -> proto.ListTasksResponse
-	13, // 13: proto.Todo.CreateTask:output_type -> proto.CreateTaskResponse
-	14, // 14: proto.Todo.GetTask:output_type -> proto.GetTaskResponse
-	15, // 15: proto.Todo.UpdateTask:output_type -> proto.UpdateTaskResponse
-	16, // 16: proto.Todo.DeleteTask:output_type -> proto.DeleteTaskResponse
-	17, // 17: proto.Todo.ListScheduledTasks:output_type -> proto.ListScheduledTasksResponse
-	18, // 18: proto.Todo.CreateScheduledTask:output_type -> proto.CreateScheduledTaskResponse
-	19, // 19: proto.Todo.GetScheduledTask:output_type -> proto.GetScheduledTaskResponse
-	20, // 20: proto.Todo.UpdateScheduledTask:output_type -> proto.UpdateScheduledTaskResponse
-	21, // 21: proto.Todo.DeleteScheduledTask:output_type -> proto.DeleteScheduledTaskResponse
-	11, // [11:22] is the sub-list for method output_type
-	0,  // [0:11] is the sub-list for method input_type
+	6,  // 6: proto.Todo.BatchUpdateTasks:input_type -> proto.BatchUpdateTasksRequest
+	7,  // 7: proto.Todo.BatchDeleteTasks:input_type -> proto.BatchDeleteTasksRequest
+	8,  // 8: proto.Todo.ListOperations:input_type -> proto.ListOperationsRequest
+	9,  // 9: proto.Todo.UndoOperation:input_type -> proto.UndoOperationRequest
+	10, // 10: proto.Todo.GetTaskStats:input_type -> proto.GetTaskStatsRequest
+	11, // 11: proto.Todo.ListArchivedTasks:input_type -> proto.ListArchivedTasksRequest
+	12, // 12: proto.Todo.RestoreArchivedTask:input_type -> proto.RestoreArchivedTaskRequest
+	13, // 13: proto.Todo.AddTaskComment:input_type -> proto.AddTaskCommentRequest
+	14, // 14: proto.Todo.ListTaskComments:input_type -> proto.ListTaskCommentsRequest
+	15, // 15: proto.Todo.UploadAttachment:input_type -> proto.UploadAttachmentRequest
+	16, // 16: proto.Todo.ListAttachments:input_type -> proto.ListAttachmentsRequest
+	17, // 17: proto.Todo.DownloadAttachment:input_type -> proto.DownloadAttachmentRequest
+	18, // 18: proto.Todo.ListScheduledTasks:input_type -> proto.ListScheduledTasksRequest
+	19, // 19: proto.Todo.CreateScheduledTask:input_type -> proto.CreateScheduledTaskRequest
+	20, // 20: proto.Todo.GetScheduledTask:input_type -> proto.GetScheduledTaskRequest
+	21, // 21: proto.Todo.UpdateScheduledTask:input_type -> proto.UpdateScheduledTaskRequest
+	22, // 22: proto.Todo.DeleteScheduledTask:input_type -> proto.DeleteScheduledTaskRequest
+	23, // 23: proto.Todo.PauseScheduledTask:input_type -> proto.PauseScheduledTaskRequest
+	24, // 24: proto.Todo.ResumeScheduledTask:input_type -> proto.ResumeScheduledTaskRequest
+	25, // 25: proto.Todo.GetScheduledTaskHistory:input_type -> proto.GetScheduledTaskHistoryRequest
+	26, // 26: proto.Todo.PreviewSchedule:input_type -> proto.PreviewScheduleRequest
+	27, // 27: proto.Todo.ListTemplates:input_type -> proto.ListTemplatesRequest
+	28, // 28: proto.Todo.GetTemplate:input_type -> proto.GetTemplateRequest
+	29, // 29: proto.Todo.CreateTemplate:input_type -> proto.CreateTemplateRequest
+	30, // 30: proto.Todo.DeleteTemplate:input_type -> proto.DeleteTemplateRequest
+	31, // 31: proto.Todo.ApplyTemplate:input_type -> proto.ApplyTemplateRequest
+	32, // 32: proto.Todo.GetSystemInfo:output_type -> proto.GetSystemInfoResponse
+	33, // 33: proto.Todo.ListTasks:output_type -> proto.ListTasksResponse
+	34, // 34: proto.Todo.CreateTask:output_type -> proto.CreateTaskResponse
+	35, // 35: proto.Todo.GetTask:output_type -> proto.GetTaskResponse
+	36, // 36: proto.Todo.UpdateTask:output_type -> proto.UpdateTaskResponse
+	37, // 37: proto.Todo.DeleteTask:output_type -> proto.DeleteTaskResponse
+	38, // 38: proto.Todo.BatchUpdateTasks:output_type -> proto.BatchUpdateTasksResponse
+	39, // 39: proto.Todo.BatchDeleteTasks:output_type -> proto.BatchDeleteTasksResponse
+	40, // 40: proto.Todo.ListOperations:output_type -> proto.ListOperationsResponse
+	41, // 41: proto.Todo.UndoOperation:output_type -> proto.UndoOperationResponse
+	42, // 42: proto.Todo.GetTaskStats:output_type -> proto.GetTaskStatsResponse
+	43, // 43: proto.Todo.ListArchivedTasks:output_type -> proto.ListArchivedTasksResponse
+	44, // 44: proto.Todo.RestoreArchivedTask:output_type -> proto.RestoreArchivedTaskResponse
+	45, // 45: proto.Todo.AddTaskComment:output_type -> proto.AddTaskCommentResponse
+	46, // 46: proto.Todo.ListTaskComments:output_type -> proto.ListTaskCommentsResponse
+	47, // 47: proto.Todo.UploadAttachment:output_type -> proto.UploadAttachmentResponse
+	48, // 48: proto.Todo.ListAttachments:output_type -> proto.ListAttachmentsResponse
+	49, // 49: proto.Todo.DownloadAttachment:output_type -> proto.DownloadAttachmentResponse
+	50, // 50: proto.Todo.ListScheduledTasks:output_type -> proto.ListScheduledTasksResponse
+	51, // 51: proto.Todo.CreateScheduledTask:output_type -> proto.CreateScheduledTaskResponse
+	52, // 52: proto.Todo.GetScheduledTask:output_type -> proto.GetScheduledTaskResponse
+	53, // 53: proto.Todo.UpdateScheduledTask:output_type -> proto.UpdateScheduledTaskResponse
+	54, // 54: proto.Todo.DeleteScheduledTask:output_type -> proto.DeleteScheduledTaskResponse
+	55, // 55: proto.Todo.PauseScheduledTask:output_type -> proto.PauseScheduledTaskResponse
+	56, // 56: proto.Todo.ResumeScheduledTask:output_type -> proto.ResumeScheduledTaskResponse
+	57, // 57: proto.Todo.GetScheduledTaskHistory:output_type -> proto.GetScheduledTaskHistoryResponse
+	58, // 58: proto.Todo.PreviewSchedule:output_type -> proto.PreviewScheduleResponse
+	59, // 59: proto.Todo.ListTemplates:output_type -> proto.ListTemplatesResponse
+	60, // 60: proto.Todo.GetTemplate:output_type -> proto.GetTemplateResponse
+	61, // 61: proto.Todo.CreateTemplate:output_type -> proto.CreateTemplateResponse
+	62, // 62: proto.Todo.DeleteTemplate:output_type -> proto.DeleteTemplateResponse
+	63, // 63: proto.Todo.ApplyTemplate:output_type -> proto.ApplyTemplateResponse
+	32, // [32:64] is the sub-list for method output_type
+	0,  // [0:32] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
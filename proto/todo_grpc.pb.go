@@ -19,17 +19,38 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Todo_GetSystemInfo_FullMethodName       = "/proto.Todo/GetSystemInfo"
-	Todo_ListTasks_FullMethodName           = "/proto.Todo/ListTasks"
-	Todo_CreateTask_FullMethodName          = "/proto.Todo/CreateTask"
-	Todo_GetTask_FullMethodName             = "/proto.Todo/GetTask"
-	Todo_UpdateTask_FullMethodName          = "/proto.Todo/UpdateTask"
-	Todo_DeleteTask_FullMethodName          = "/proto.Todo/DeleteTask"
-	Todo_ListScheduledTasks_FullMethodName  = "/proto.Todo/ListScheduledTasks"
-	Todo_CreateScheduledTask_FullMethodName = "/proto.Todo/CreateScheduledTask"
-	Todo_GetScheduledTask_FullMethodName    = "/proto.Todo/GetScheduledTask"
-	Todo_UpdateScheduledTask_FullMethodName = "/proto.Todo/UpdateScheduledTask"
-	Todo_DeleteScheduledTask_FullMethodName = "/proto.Todo/DeleteScheduledTask"
+	Todo_GetSystemInfo_FullMethodName           = "/proto.Todo/GetSystemInfo"
+	Todo_ListTasks_FullMethodName               = "/proto.Todo/ListTasks"
+	Todo_CreateTask_FullMethodName              = "/proto.Todo/CreateTask"
+	Todo_GetTask_FullMethodName                 = "/proto.Todo/GetTask"
+	Todo_UpdateTask_FullMethodName              = "/proto.Todo/UpdateTask"
+	Todo_DeleteTask_FullMethodName              = "/proto.Todo/DeleteTask"
+	Todo_BatchUpdateTasks_FullMethodName        = "/proto.Todo/BatchUpdateTasks"
+	Todo_BatchDeleteTasks_FullMethodName        = "/proto.Todo/BatchDeleteTasks"
+	Todo_ListOperations_FullMethodName          = "/proto.Todo/ListOperations"
+	Todo_UndoOperation_FullMethodName           = "/proto.Todo/UndoOperation"
+	Todo_GetTaskStats_FullMethodName            = "/proto.Todo/GetTaskStats"
+	Todo_ListArchivedTasks_FullMethodName       = "/proto.Todo/ListArchivedTasks"
+	Todo_RestoreArchivedTask_FullMethodName     = "/proto.Todo/RestoreArchivedTask"
+	Todo_AddTaskComment_FullMethodName          = "/proto.Todo/AddTaskComment"
+	Todo_ListTaskComments_FullMethodName        = "/proto.Todo/ListTaskComments"
+	Todo_UploadAttachment_FullMethodName        = "/proto.Todo/UploadAttachment"
+	Todo_ListAttachments_FullMethodName         = "/proto.Todo/ListAttachments"
+	Todo_DownloadAttachment_FullMethodName      = "/proto.Todo/DownloadAttachment"
+	Todo_ListScheduledTasks_FullMethodName      = "/proto.Todo/ListScheduledTasks"
+	Todo_CreateScheduledTask_FullMethodName     = "/proto.Todo/CreateScheduledTask"
+	Todo_GetScheduledTask_FullMethodName        = "/proto.Todo/GetScheduledTask"
+	Todo_UpdateScheduledTask_FullMethodName     = "/proto.Todo/UpdateScheduledTask"
+	Todo_DeleteScheduledTask_FullMethodName     = "/proto.Todo/DeleteScheduledTask"
+	Todo_PauseScheduledTask_FullMethodName      = "/proto.Todo/PauseScheduledTask"
+	Todo_ResumeScheduledTask_FullMethodName     = "/proto.Todo/ResumeScheduledTask"
+	Todo_GetScheduledTaskHistory_FullMethodName = "/proto.Todo/GetScheduledTaskHistory"
+	Todo_PreviewSchedule_FullMethodName         = "/proto.Todo/PreviewSchedule"
+	Todo_ListTemplates_FullMethodName           = "/proto.Todo/ListTemplates"
+	Todo_GetTemplate_FullMethodName             = "/proto.Todo/GetTemplate"
+	Todo_CreateTemplate_FullMethodName          = "/proto.Todo/CreateTemplate"
+	Todo_DeleteTemplate_FullMethodName          = "/proto.Todo/DeleteTemplate"
+	Todo_ApplyTemplate_FullMethodName           = "/proto.Todo/ApplyTemplate"
 )
 
 // TodoClient is the client API for Todo service.
@@ -53,6 +74,36 @@ type TodoClient interface {
 	UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*UpdateTaskResponse, error)
 	// DeleteTask removes a task by id.
 	DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*DeleteTaskResponse, error)
+	// BatchUpdateTasks updates many tasks at once, chosen by id or by a filter, inside a single transaction. Tasks
+	// that can't be updated are reported in their result and don't stop the others.
+	BatchUpdateTasks(ctx context.Context, in *BatchUpdateTasksRequest, opts ...grpc.CallOption) (*BatchUpdateTasksResponse, error)
+	// BatchDeleteTasks deletes many tasks at once, chosen by id or by a filter, inside a single transaction.
+	BatchDeleteTasks(ctx context.Context, in *BatchDeleteTasksRequest, opts ...grpc.CallOption) (*BatchDeleteTasksResponse, error)
+	// ListOperations returns the journal of recent changes made to tasks, newest first.
+	ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error)
+	// UndoOperation puts every task an operation touched back the way it was before the operation.
+	UndoOperation(ctx context.Context, in *UndoOperationRequest, opts ...grpc.CallOption) (*UndoOperationResponse, error)
+	// GetTaskStats summarizes how tasks are being completed: completions over time, how long tasks take to complete,
+	// how old open tasks are and how far along each tree of tasks is.
+	GetTaskStats(ctx context.Context, in *GetTaskStatsRequest, opts ...grpc.CallOption) (*GetTaskStatsResponse, error)
+	// ListArchivedTasks returns the tasks the server's cleanup policy has moved out of the task list, most recently
+	// archived first.
+	ListArchivedTasks(ctx context.Context, in *ListArchivedTasksRequest, opts ...grpc.CallOption) (*ListArchivedTasksResponse, error)
+	// RestoreArchivedTask moves an archived tree of tasks back into the task list. Naming any task in the tree restores
+	// all of it.
+	RestoreArchivedTask(ctx context.Context, in *RestoreArchivedTaskRequest, opts ...grpc.CallOption) (*RestoreArchivedTaskResponse, error)
+	// AddTaskComment appends a comment to a task's running log. Comments can't be changed once added.
+	AddTaskComment(ctx context.Context, in *AddTaskCommentRequest, opts ...grpc.CallOption) (*AddTaskCommentResponse, error)
+	// ListTaskComments returns the comments left on a task, oldest first.
+	ListTaskComments(ctx context.Context, in *ListTaskCommentsRequest, opts ...grpc.CallOption) (*ListTaskCommentsResponse, error)
+	// UploadAttachment attaches a file to a task. The first message names the task and file; the rest carry the file's
+	// contents in order.
+	UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentRequest, UploadAttachmentResponse], error)
+	// ListAttachments returns the files attached to a task, oldest first.
+	ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error)
+	// DownloadAttachment returns an attached file. The first message describes the file; the rest carry its contents
+	// in order.
+	DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadAttachmentResponse], error)
 	// ListScheduledTasks returns all registered scheduled tasks.
 	ListScheduledTasks(ctx context.Context, in *ListScheduledTasksRequest, opts ...grpc.CallOption) (*ListScheduledTasksResponse, error)
 	// CreateScheduledTask creates a scheduled new task.
@@ -63,6 +114,25 @@ type TodoClient interface {
 	UpdateScheduledTask(ctx context.Context, in *UpdateScheduledTaskRequest, opts ...grpc.CallOption) (*UpdateScheduledTaskResponse, error)
 	// DeleteScheduledTask removes a scheduled task by id.
 	DeleteScheduledTask(ctx context.Context, in *DeleteScheduledTaskRequest, opts ...grpc.CallOption) (*DeleteScheduledTaskResponse, error)
+	// PauseScheduledTask stops a scheduled task from creating new tasks until it is resumed.
+	PauseScheduledTask(ctx context.Context, in *PauseScheduledTaskRequest, opts ...grpc.CallOption) (*PauseScheduledTaskResponse, error)
+	// ResumeScheduledTask allows a paused scheduled task to create new tasks again.
+	ResumeScheduledTask(ctx context.Context, in *ResumeScheduledTaskRequest, opts ...grpc.CallOption) (*ResumeScheduledTaskResponse, error)
+	// GetScheduledTaskHistory returns the tasks a scheduled task has created along with how consistently they were
+	// completed.
+	GetScheduledTaskHistory(ctx context.Context, in *GetScheduledTaskHistoryRequest, opts ...grpc.CallOption) (*GetScheduledTaskHistoryResponse, error)
+	// PreviewSchedule validates a schedule expression and returns when it would fire without creating anything.
+	PreviewSchedule(ctx context.Context, in *PreviewScheduleRequest, opts ...grpc.CallOption) (*PreviewScheduleResponse, error)
+	// ListTemplates returns all task templates.
+	ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error)
+	// GetTemplate returns a single template by id or name.
+	GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*GetTemplateResponse, error)
+	// CreateTemplate creates a new task template.
+	CreateTemplate(ctx context.Context, in *CreateTemplateRequest, opts ...grpc.CallOption) (*CreateTemplateResponse, error)
+	// DeleteTemplate removes a template by id or name. Tasks already created from it are left alone.
+	DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error)
+	// ApplyTemplate creates the tree of tasks a template describes. Either every task is created or none are.
+	ApplyTemplate(ctx context.Context, in *ApplyTemplateRequest, opts ...grpc.CallOption) (*ApplyTemplateResponse, error)
 }
 
 type todoClient struct {
@@ -133,6 +203,138 @@ func (c *todoClient) DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts
 	return out, nil
 }
 
+func (c *todoClient) BatchUpdateTasks(ctx context.Context, in *BatchUpdateTasksRequest, opts ...grpc.CallOption) (*BatchUpdateTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchUpdateTasksResponse)
+	err := c.cc.Invoke(ctx, Todo_BatchUpdateTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) BatchDeleteTasks(ctx context.Context, in *BatchDeleteTasksRequest, opts ...grpc.CallOption) (*BatchDeleteTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchDeleteTasksResponse)
+	err := c.cc.Invoke(ctx, Todo_BatchDeleteTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOperationsResponse)
+	err := c.cc.Invoke(ctx, Todo_ListOperations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) UndoOperation(ctx context.Context, in *UndoOperationRequest, opts ...grpc.CallOption) (*UndoOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UndoOperationResponse)
+	err := c.cc.Invoke(ctx, Todo_UndoOperation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) GetTaskStats(ctx context.Context, in *GetTaskStatsRequest, opts ...grpc.CallOption) (*GetTaskStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTaskStatsResponse)
+	err := c.cc.Invoke(ctx, Todo_GetTaskStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) ListArchivedTasks(ctx context.Context, in *ListArchivedTasksRequest, opts ...grpc.CallOption) (*ListArchivedTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListArchivedTasksResponse)
+	err := c.cc.Invoke(ctx, Todo_ListArchivedTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) RestoreArchivedTask(ctx context.Context, in *RestoreArchivedTaskRequest, opts ...grpc.CallOption) (*RestoreArchivedTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreArchivedTaskResponse)
+	err := c.cc.Invoke(ctx, Todo_RestoreArchivedTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) AddTaskComment(ctx context.Context, in *AddTaskCommentRequest, opts ...grpc.CallOption) (*AddTaskCommentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTaskCommentResponse)
+	err := c.cc.Invoke(ctx, Todo_AddTaskComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) ListTaskComments(ctx context.Context, in *ListTaskCommentsRequest, opts ...grpc.CallOption) (*ListTaskCommentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTaskCommentsResponse)
+	err := c.cc.Invoke(ctx, Todo_ListTaskComments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentRequest, UploadAttachmentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Todo_ServiceDesc.Streams[0], Todo_UploadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadAttachmentRequest, UploadAttachmentResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Todo_UploadAttachmentClient = grpc.ClientStreamingClient[UploadAttachmentRequest, UploadAttachmentResponse]
+
+func (c *todoClient) ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAttachmentsResponse)
+	err := c.cc.Invoke(ctx, Todo_ListAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadAttachmentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Todo_ServiceDesc.Streams[1], Todo_DownloadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadAttachmentRequest, DownloadAttachmentResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Todo_DownloadAttachmentClient = grpc.ServerStreamingClient[DownloadAttachmentResponse]
+
 func (c *todoClient) ListScheduledTasks(ctx context.Context, in *ListScheduledTasksRequest, opts ...grpc.CallOption) (*ListScheduledTasksResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListScheduledTasksResponse)
@@ -183,6 +385,96 @@ func (c *todoClient) DeleteScheduledTask(ctx context.Context, in *DeleteSchedule
 	return out, nil
 }
 
+func (c *todoClient) PauseScheduledTask(ctx context.Context, in *PauseScheduledTaskRequest, opts ...grpc.CallOption) (*PauseScheduledTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseScheduledTaskResponse)
+	err := c.cc.Invoke(ctx, Todo_PauseScheduledTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) ResumeScheduledTask(ctx context.Context, in *ResumeScheduledTaskRequest, opts ...grpc.CallOption) (*ResumeScheduledTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeScheduledTaskResponse)
+	err := c.cc.Invoke(ctx, Todo_ResumeScheduledTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) GetScheduledTaskHistory(ctx context.Context, in *GetScheduledTaskHistoryRequest, opts ...grpc.CallOption) (*GetScheduledTaskHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetScheduledTaskHistoryResponse)
+	err := c.cc.Invoke(ctx, Todo_GetScheduledTaskHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) PreviewSchedule(ctx context.Context, in *PreviewScheduleRequest, opts ...grpc.CallOption) (*PreviewScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewScheduleResponse)
+	err := c.cc.Invoke(ctx, Todo_PreviewSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTemplatesResponse)
+	err := c.cc.Invoke(ctx, Todo_ListTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*GetTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTemplateResponse)
+	err := c.cc.Invoke(ctx, Todo_GetTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) CreateTemplate(ctx context.Context, in *CreateTemplateRequest, opts ...grpc.CallOption) (*CreateTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTemplateResponse)
+	err := c.cc.Invoke(ctx, Todo_CreateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTemplateResponse)
+	err := c.cc.Invoke(ctx, Todo_DeleteTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) ApplyTemplate(ctx context.Context, in *ApplyTemplateRequest, opts ...grpc.CallOption) (*ApplyTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyTemplateResponse)
+	err := c.cc.Invoke(ctx, Todo_ApplyTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TodoServer is the server API for Todo service.
 // All implementations must embed UnimplementedTodoServer
 // for forward compatibility.
@@ -204,6 +496,36 @@ type TodoServer interface {
 	UpdateTask(context.Context, *UpdateTaskRequest) (*UpdateTaskResponse, error)
 	// DeleteTask removes a task by id.
 	DeleteTask(context.Context, *DeleteTaskRequest) (*DeleteTaskResponse, error)
+	// BatchUpdateTasks updates many tasks at once, chosen by id or by a filter, inside a single transaction. Tasks
+	// that can't be updated are reported in their result and don't stop the others.
+	BatchUpdateTasks(context.Context, *BatchUpdateTasksRequest) (*BatchUpdateTasksResponse, error)
+	// BatchDeleteTasks deletes many tasks at once, chosen by id or by a filter, inside a single transaction.
+	BatchDeleteTasks(context.Context, *BatchDeleteTasksRequest) (*BatchDeleteTasksResponse, error)
+	// ListOperations returns the journal of recent changes made to tasks, newest first.
+	ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error)
+	// UndoOperation puts every task an operation touched back the way it was before the operation.
+	UndoOperation(context.Context, *UndoOperationRequest) (*UndoOperationResponse, error)
+	// GetTaskStats summarizes how tasks are being completed: completions over time, how long tasks take to complete,
+	// how old open tasks are and how far along each tree of tasks is.
+	GetTaskStats(context.Context, *GetTaskStatsRequest) (*GetTaskStatsResponse, error)
+	// ListArchivedTasks returns the tasks the server's cleanup policy has moved out of the task list, most recently
+	// archived first.
+	ListArchivedTasks(context.Context, *ListArchivedTasksRequest) (*ListArchivedTasksResponse, error)
+	// RestoreArchivedTask moves an archived tree of tasks back into the task list. Naming any task in the tree restores
+	// all of it.
+	RestoreArchivedTask(context.Context, *RestoreArchivedTaskRequest) (*RestoreArchivedTaskResponse, error)
+	// AddTaskComment appends a comment to a task's running log. Comments can't be changed once added.
+	AddTaskComment(context.Context, *AddTaskCommentRequest) (*AddTaskCommentResponse, error)
+	// ListTaskComments returns the comments left on a task, oldest first.
+	ListTaskComments(context.Context, *ListTaskCommentsRequest) (*ListTaskCommentsResponse, error)
+	// UploadAttachment attaches a file to a task. The first message names the task and file; the rest carry the file's
+	// contents in order.
+	UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentRequest, UploadAttachmentResponse]) error
+	// ListAttachments returns the files attached to a task, oldest first.
+	ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error)
+	// DownloadAttachment returns an attached file. The first message describes the file; the rest carry its contents
+	// in order.
+	DownloadAttachment(*DownloadAttachmentRequest, grpc.ServerStreamingServer[DownloadAttachmentResponse]) error
 	// ListScheduledTasks returns all registered scheduled tasks.
 	ListScheduledTasks(context.Context, *ListScheduledTasksRequest) (*ListScheduledTasksResponse, error)
 	// CreateScheduledTask creates a scheduled new task.
@@ -214,6 +536,25 @@ type TodoServer interface {
 	UpdateScheduledTask(context.Context, *UpdateScheduledTaskRequest) (*UpdateScheduledTaskResponse, error)
 	// DeleteScheduledTask removes a scheduled task by id.
 	DeleteScheduledTask(context.Context, *DeleteScheduledTaskRequest) (*DeleteScheduledTaskResponse, error)
+	// PauseScheduledTask stops a scheduled task from creating new tasks until it is resumed.
+	PauseScheduledTask(context.Context, *PauseScheduledTaskRequest) (*PauseScheduledTaskResponse, error)
+	// ResumeScheduledTask allows a paused scheduled task to create new tasks again.
+	ResumeScheduledTask(context.Context, *ResumeScheduledTaskRequest) (*ResumeScheduledTaskResponse, error)
+	// GetScheduledTaskHistory returns the tasks a scheduled task has created along with how consistently they were
+	// completed.
+	GetScheduledTaskHistory(context.Context, *GetScheduledTaskHistoryRequest) (*GetScheduledTaskHistoryResponse, error)
+	// PreviewSchedule validates a schedule expression and returns when it would fire without creating anything.
+	PreviewSchedule(context.Context, *PreviewScheduleRequest) (*PreviewScheduleResponse, error)
+	// ListTemplates returns all task templates.
+	ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error)
+	// GetTemplate returns a single template by id or name.
+	GetTemplate(context.Context, *GetTemplateRequest) (*GetTemplateResponse, error)
+	// CreateTemplate creates a new task template.
+	CreateTemplate(context.Context, *CreateTemplateRequest) (*CreateTemplateResponse, error)
+	// DeleteTemplate removes a template by id or name. Tasks already created from it are left alone.
+	DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error)
+	// ApplyTemplate creates the tree of tasks a template describes. Either every task is created or none are.
+	ApplyTemplate(context.Context, *ApplyTemplateRequest) (*ApplyTemplateResponse, error)
 	mustEmbedUnimplementedTodoServer()
 }
 
@@ -242,6 +583,42 @@ func (UnimplementedTodoServer) UpdateTask(context.Context, *UpdateTaskRequest) (
 func (UnimplementedTodoServer) DeleteTask(context.Context, *DeleteTaskRequest) (*DeleteTaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTask not implemented")
 }
+func (UnimplementedTodoServer) BatchUpdateTasks(context.Context, *BatchUpdateTasksRequest) (*BatchUpdateTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateTasks not implemented")
+}
+func (UnimplementedTodoServer) BatchDeleteTasks(context.Context, *BatchDeleteTasksRequest) (*BatchDeleteTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteTasks not implemented")
+}
+func (UnimplementedTodoServer) ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOperations not implemented")
+}
+func (UnimplementedTodoServer) UndoOperation(context.Context, *UndoOperationRequest) (*UndoOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndoOperation not implemented")
+}
+func (UnimplementedTodoServer) GetTaskStats(context.Context, *GetTaskStatsRequest) (*GetTaskStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskStats not implemented")
+}
+func (UnimplementedTodoServer) ListArchivedTasks(context.Context, *ListArchivedTasksRequest) (*ListArchivedTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArchivedTasks not implemented")
+}
+func (UnimplementedTodoServer) RestoreArchivedTask(context.Context, *RestoreArchivedTaskRequest) (*RestoreArchivedTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreArchivedTask not implemented")
+}
+func (UnimplementedTodoServer) AddTaskComment(context.Context, *AddTaskCommentRequest) (*AddTaskCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTaskComment not implemented")
+}
+func (UnimplementedTodoServer) ListTaskComments(context.Context, *ListTaskCommentsRequest) (*ListTaskCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTaskComments not implemented")
+}
+func (UnimplementedTodoServer) UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentRequest, UploadAttachmentResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadAttachment not implemented")
+}
+func (UnimplementedTodoServer) ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttachments not implemented")
+}
+func (UnimplementedTodoServer) DownloadAttachment(*DownloadAttachmentRequest, grpc.ServerStreamingServer[DownloadAttachmentResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadAttachment not implemented")
+}
 func (UnimplementedTodoServer) ListScheduledTasks(context.Context, *ListScheduledTasksRequest) (*ListScheduledTasksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledTasks not implemented")
 }
@@ -257,6 +634,33 @@ func (UnimplementedTodoServer) UpdateScheduledTask(context.Context, *UpdateSched
 func (UnimplementedTodoServer) DeleteScheduledTask(context.Context, *DeleteScheduledTaskRequest) (*DeleteScheduledTaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteScheduledTask not implemented")
 }
+func (UnimplementedTodoServer) PauseScheduledTask(context.Context, *PauseScheduledTaskRequest) (*PauseScheduledTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseScheduledTask not implemented")
+}
+func (UnimplementedTodoServer) ResumeScheduledTask(context.Context, *ResumeScheduledTaskRequest) (*ResumeScheduledTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeScheduledTask not implemented")
+}
+func (UnimplementedTodoServer) GetScheduledTaskHistory(context.Context, *GetScheduledTaskHistoryRequest) (*GetScheduledTaskHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScheduledTaskHistory not implemented")
+}
+func (UnimplementedTodoServer) PreviewSchedule(context.Context, *PreviewScheduleRequest) (*PreviewScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewSchedule not implemented")
+}
+func (UnimplementedTodoServer) ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTemplates not implemented")
+}
+func (UnimplementedTodoServer) GetTemplate(context.Context, *GetTemplateRequest) (*GetTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTemplate not implemented")
+}
+func (UnimplementedTodoServer) CreateTemplate(context.Context, *CreateTemplateRequest) (*CreateTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTemplate not implemented")
+}
+func (UnimplementedTodoServer) DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTemplate not implemented")
+}
+func (UnimplementedTodoServer) ApplyTemplate(context.Context, *ApplyTemplateRequest) (*ApplyTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyTemplate not implemented")
+}
 func (UnimplementedTodoServer) mustEmbedUnimplementedTodoServer() {}
 func (UnimplementedTodoServer) testEmbeddedByValue()              {}
 
@@ -386,6 +790,204 @@ func _Todo_DeleteTask_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Todo_BatchUpdateTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).BatchUpdateTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_BatchUpdateTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).BatchUpdateTasks(ctx, req.(*BatchUpdateTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_BatchDeleteTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).BatchDeleteTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_BatchDeleteTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).BatchDeleteTasks(ctx, req.(*BatchDeleteTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_ListOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).ListOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_ListOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).ListOperations(ctx, req.(*ListOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_UndoOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndoOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).UndoOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_UndoOperation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).UndoOperation(ctx, req.(*UndoOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_GetTaskStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).GetTaskStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_GetTaskStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).GetTaskStats(ctx, req.(*GetTaskStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_ListArchivedTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArchivedTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).ListArchivedTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_ListArchivedTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).ListArchivedTasks(ctx, req.(*ListArchivedTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_RestoreArchivedTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreArchivedTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).RestoreArchivedTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_RestoreArchivedTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).RestoreArchivedTask(ctx, req.(*RestoreArchivedTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_AddTaskComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTaskCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).AddTaskComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_AddTaskComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).AddTaskComment(ctx, req.(*AddTaskCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_ListTaskComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTaskCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).ListTaskComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_ListTaskComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).ListTaskComments(ctx, req.(*ListTaskCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_UploadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TodoServer).UploadAttachment(&grpc.GenericServerStream[UploadAttachmentRequest, UploadAttachmentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Todo_UploadAttachmentServer = grpc.ClientStreamingServer[UploadAttachmentRequest, UploadAttachmentResponse]
+
+func _Todo_ListAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).ListAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_ListAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).ListAttachments(ctx, req.(*ListAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_DownloadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadAttachmentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TodoServer).DownloadAttachment(m, &grpc.GenericServerStream[DownloadAttachmentRequest, DownloadAttachmentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Todo_DownloadAttachmentServer = grpc.ServerStreamingServer[DownloadAttachmentResponse]
+
 func _Todo_ListScheduledTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListScheduledTasksRequest)
 	if err := dec(in); err != nil {
@@ -476,6 +1078,168 @@ func _Todo_DeleteScheduledTask_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Todo_PauseScheduledTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseScheduledTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).PauseScheduledTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_PauseScheduledTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).PauseScheduledTask(ctx, req.(*PauseScheduledTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_ResumeScheduledTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeScheduledTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).ResumeScheduledTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_ResumeScheduledTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).ResumeScheduledTask(ctx, req.(*ResumeScheduledTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_GetScheduledTaskHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScheduledTaskHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).GetScheduledTaskHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_GetScheduledTaskHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).GetScheduledTaskHistory(ctx, req.(*GetScheduledTaskHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_PreviewSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).PreviewSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_PreviewSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).PreviewSchedule(ctx, req.(*PreviewScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_ListTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).ListTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_ListTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).ListTemplates(ctx, req.(*ListTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_GetTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).GetTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_GetTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).GetTemplate(ctx, req.(*GetTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_CreateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).CreateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_CreateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).CreateTemplate(ctx, req.(*CreateTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_DeleteTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).DeleteTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_DeleteTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).DeleteTemplate(ctx, req.(*DeleteTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_ApplyTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).ApplyTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_ApplyTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).ApplyTemplate(ctx, req.(*ApplyTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Todo_ServiceDesc is the grpc.ServiceDesc for Todo service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -507,6 +1271,46 @@ var Todo_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteTask",
 			Handler:    _Todo_DeleteTask_Handler,
 		},
+		{
+			MethodName: "BatchUpdateTasks",
+			Handler:    _Todo_BatchUpdateTasks_Handler,
+		},
+		{
+			MethodName: "BatchDeleteTasks",
+			Handler:    _Todo_BatchDeleteTasks_Handler,
+		},
+		{
+			MethodName: "ListOperations",
+			Handler:    _Todo_ListOperations_Handler,
+		},
+		{
+			MethodName: "UndoOperation",
+			Handler:    _Todo_UndoOperation_Handler,
+		},
+		{
+			MethodName: "GetTaskStats",
+			Handler:    _Todo_GetTaskStats_Handler,
+		},
+		{
+			MethodName: "ListArchivedTasks",
+			Handler:    _Todo_ListArchivedTasks_Handler,
+		},
+		{
+			MethodName: "RestoreArchivedTask",
+			Handler:    _Todo_RestoreArchivedTask_Handler,
+		},
+		{
+			MethodName: "AddTaskComment",
+			Handler:    _Todo_AddTaskComment_Handler,
+		},
+		{
+			MethodName: "ListTaskComments",
+			Handler:    _Todo_ListTaskComments_Handler,
+		},
+		{
+			MethodName: "ListAttachments",
+			Handler:    _Todo_ListAttachments_Handler,
+		},
 		{
 			MethodName: "ListScheduledTasks",
 			Handler:    _Todo_ListScheduledTasks_Handler,
@@ -527,7 +1331,54 @@ var Todo_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteScheduledTask",
 			Handler:    _Todo_DeleteScheduledTask_Handler,
 		},
+		{
+			MethodName: "PauseScheduledTask",
+			Handler:    _Todo_PauseScheduledTask_Handler,
+		},
+		{
+			MethodName: "ResumeScheduledTask",
+			Handler:    _Todo_ResumeScheduledTask_Handler,
+		},
+		{
+			MethodName: "GetScheduledTaskHistory",
+			Handler:    _Todo_GetScheduledTaskHistory_Handler,
+		},
+		{
+			MethodName: "PreviewSchedule",
+			Handler:    _Todo_PreviewSchedule_Handler,
+		},
+		{
+			MethodName: "ListTemplates",
+			Handler:    _Todo_ListTemplates_Handler,
+		},
+		{
+			MethodName: "GetTemplate",
+			Handler:    _Todo_GetTemplate_Handler,
+		},
+		{
+			MethodName: "CreateTemplate",
+			Handler:    _Todo_CreateTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteTemplate",
+			Handler:    _Todo_DeleteTemplate_Handler,
+		},
+		{
+			MethodName: "ApplyTemplate",
+			Handler:    _Todo_ApplyTemplate_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadAttachment",
+			Handler:       _Todo_UploadAttachment_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadAttachment",
+			Handler:       _Todo_DownloadAttachment_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "todo.proto",
 }
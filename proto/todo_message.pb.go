@@ -70,15 +70,194 @@ func (Task_TaskState) EnumDescriptor() ([]byte, []int) {
 	return file_todo_message_proto_rawDescGZIP(), []int{0, 0}
 }
 
+type ScheduledTask_ExpressionType int32
+
+const (
+	// Detect the type of expression from the expression itself.
+	ScheduledTask_EXPRESSION_TYPE_UNKNOWN ScheduledTask_ExpressionType = 0
+	// The six field cron subset understood by https://github.com/clintjedwards/avail.
+	ScheduledTask_AVAIL ScheduledTask_ExpressionType = 1
+	// Standard five field cron.
+	ScheduledTask_CRON ScheduledTask_ExpressionType = 2
+	// An iCalendar (RFC 5545) recurrence rule.
+	ScheduledTask_RRULE ScheduledTask_ExpressionType = 3
+	// A plain English description like "every 2nd tuesday at 9am".
+	ScheduledTask_NATURAL ScheduledTask_ExpressionType = 4
+)
+
+// Enum value maps for ScheduledTask_ExpressionType.
+var (
+	ScheduledTask_ExpressionType_name = map[int32]string{
+		0: "EXPRESSION_TYPE_UNKNOWN",
+		1: "AVAIL",
+		2: "CRON",
+		3: "RRULE",
+		4: "NATURAL",
+	}
+	ScheduledTask_ExpressionType_value = map[string]int32{
+		"EXPRESSION_TYPE_UNKNOWN": 0,
+		"AVAIL":                   1,
+		"CRON":                    2,
+		"RRULE":                   3,
+		"NATURAL":                 4,
+	}
+)
+
+func (x ScheduledTask_ExpressionType) Enum() *ScheduledTask_ExpressionType {
+	p := new(ScheduledTask_ExpressionType)
+	*p = x
+	return p
+}
+
+func (x ScheduledTask_ExpressionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScheduledTask_ExpressionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_message_proto_enumTypes[1].Descriptor()
+}
+
+func (ScheduledTask_ExpressionType) Type() protoreflect.EnumType {
+	return &file_todo_message_proto_enumTypes[1]
+}
+
+func (x ScheduledTask_ExpressionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScheduledTask_ExpressionType.Descriptor instead.
+func (ScheduledTask_ExpressionType) EnumDescriptor() ([]byte, []int) {
+	return file_todo_message_proto_rawDescGZIP(), []int{2, 0}
+}
+
+type ScheduledTask_Recurrence int32
+
+const (
+	// Detect the recurrence from the expression; expressions like "every 3 days after completion" are
+	// AFTER_COMPLETION and everything else is CALENDAR.
+	ScheduledTask_RECURRENCE_UNKNOWN ScheduledTask_Recurrence = 0
+	// A new task is created every time the expression fires.
+	ScheduledTask_CALENDAR ScheduledTask_Recurrence = 1
+	// A new task is created once the previous task created by the scheduled task has been completed for the delay
+	// described by the expression; ex. "3 days" or "36h".
+	ScheduledTask_AFTER_COMPLETION ScheduledTask_Recurrence = 2
+)
+
+// Enum value maps for ScheduledTask_Recurrence.
+var (
+	ScheduledTask_Recurrence_name = map[int32]string{
+		0: "RECURRENCE_UNKNOWN",
+		1: "CALENDAR",
+		2: "AFTER_COMPLETION",
+	}
+	ScheduledTask_Recurrence_value = map[string]int32{
+		"RECURRENCE_UNKNOWN": 0,
+		"CALENDAR":           1,
+		"AFTER_COMPLETION":   2,
+	}
+)
+
+func (x ScheduledTask_Recurrence) Enum() *ScheduledTask_Recurrence {
+	p := new(ScheduledTask_Recurrence)
+	*p = x
+	return p
+}
+
+func (x ScheduledTask_Recurrence) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScheduledTask_Recurrence) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_message_proto_enumTypes[2].Descriptor()
+}
+
+func (ScheduledTask_Recurrence) Type() protoreflect.EnumType {
+	return &file_todo_message_proto_enumTypes[2]
+}
+
+func (x ScheduledTask_Recurrence) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScheduledTask_Recurrence.Descriptor instead.
+func (ScheduledTask_Recurrence) EnumDescriptor() ([]byte, []int) {
+	return file_todo_message_proto_rawDescGZIP(), []int{2, 1}
+}
+
+type Operation_Kind int32
+
+const (
+	Operation_OPERATION_KIND_UNKNOWN Operation_Kind = 0
+	Operation_COMPLETE               Operation_Kind = 1
+	Operation_UPDATE                 Operation_Kind = 2
+	Operation_DELETE                 Operation_Kind = 3
+)
+
+// Enum value maps for Operation_Kind.
+var (
+	Operation_Kind_name = map[int32]string{
+		0: "OPERATION_KIND_UNKNOWN",
+		1: "COMPLETE",
+		2: "UPDATE",
+		3: "DELETE",
+	}
+	Operation_Kind_value = map[string]int32{
+		"OPERATION_KIND_UNKNOWN": 0,
+		"COMPLETE":               1,
+		"UPDATE":                 2,
+		"DELETE":                 3,
+	}
+)
+
+func (x Operation_Kind) Enum() *Operation_Kind {
+	p := new(Operation_Kind)
+	*p = x
+	return p
+}
+
+func (x Operation_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Operation_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_todo_message_proto_enumTypes[3].Descriptor()
+}
+
+func (Operation_Kind) Type() protoreflect.EnumType {
+	return &file_todo_message_proto_enumTypes[3]
+}
+
+func (x Operation_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Operation_Kind.Descriptor instead.
+func (Operation_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_todo_message_proto_rawDescGZIP(), []int{5, 0}
+}
+
 type Task struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	State         Task_TaskState         `protobuf:"varint,4,opt,name=state,proto3,enum=proto.Task_TaskState" json:"state,omitempty"`
-	Created       int64                  `protobuf:"varint,5,opt,name=created,proto3" json:"created,omitempty"`
-	Modified      int64                  `protobuf:"varint,6,opt,name=modified,proto3" json:"modified,omitempty"`
-	Parent        string                 `protobuf:"bytes,7,opt,name=parent,proto3" json:"parent,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	State       Task_TaskState         `protobuf:"varint,4,opt,name=state,proto3,enum=proto.Task_TaskState" json:"state,omitempty"`
+	Created     int64                  `protobuf:"varint,5,opt,name=created,proto3" json:"created,omitempty"`
+	Modified    int64                  `protobuf:"varint,6,opt,name=modified,proto3" json:"modified,omitempty"`
+	Parent      string                 `protobuf:"bytes,7,opt,name=parent,proto3" json:"parent,omitempty"`
+	// The id of the scheduled task that created this task, if any.
+	ScheduledTaskId string `protobuf:"bytes,8,opt,name=scheduled_task_id,json=scheduledTaskId,proto3" json:"scheduled_task_id,omitempty"`
+	// When the scheduled task that created this task came due, in unix milliseconds; 0 for tasks created by hand.
+	ScheduledFor int64 `protobuf:"varint,9,opt,name=scheduled_for,json=scheduledFor,proto3" json:"scheduled_for,omitempty"`
+	// Incremented every time the task is changed. Pass it back as UpdateTaskRequest.expected_version to make sure an
+	// update doesn't overwrite changes made since the task was read.
+	Version int64 `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
+	// When the task was completed, in unix milliseconds; 0 while it is unresolved.
+	CompletedAt int64 `protobuf:"varint,11,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// Optionally, why or how the task was completed.
+	CompletionNote string `protobuf:"bytes,12,opt,name=completion_note,json=completionNote,proto3" json:"completion_note,omitempty"`
+	// When the task was archived, in unix milliseconds; 0 for tasks that aren't archived.
+	Archived      int64 `protobuf:"varint,13,opt,name=archived,proto3" json:"archived,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -162,20 +341,153 @@ func (x *Task) GetParent() string {
 	return ""
 }
 
+func (x *Task) GetScheduledTaskId() string {
+	if x != nil {
+		return x.ScheduledTaskId
+	}
+	return ""
+}
+
+func (x *Task) GetScheduledFor() int64 {
+	if x != nil {
+		return x.ScheduledFor
+	}
+	return 0
+}
+
+func (x *Task) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Task) GetCompletedAt() int64 {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return 0
+}
+
+func (x *Task) GetCompletionNote() string {
+	if x != nil {
+		return x.CompletionNote
+	}
+	return ""
+}
+
+func (x *Task) GetArchived() int64 {
+	if x != nil {
+		return x.Archived
+	}
+	return 0
+}
+
+// TaskComment is an entry in a task's running log of comments.
+type TaskComment struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Who left the comment, as named by the client that added it.
+	Author        string `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Text          string `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	Created       int64  `protobuf:"varint,5,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskComment) Reset() {
+	*x = TaskComment{}
+	mi := &file_todo_message_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskComment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskComment) ProtoMessage() {}
+
+func (x *TaskComment) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_message_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskComment.ProtoReflect.Descriptor instead.
+func (*TaskComment) Descriptor() ([]byte, []int) {
+	return file_todo_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TaskComment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TaskComment) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *TaskComment) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *TaskComment) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *TaskComment) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
 type ScheduledTask struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Expression    string                 `protobuf:"bytes,4,opt,name=expression,proto3" json:"expression,omitempty"`
-	Parent        string                 `protobuf:"bytes,5,opt,name=parent,proto3" json:"parent,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Expression  string                 `protobuf:"bytes,4,opt,name=expression,proto3" json:"expression,omitempty"`
+	Parent      string                 `protobuf:"bytes,5,opt,name=parent,proto3" json:"parent,omitempty"`
+	// Paused scheduled tasks are kept but no longer create new tasks until they are resumed.
+	Paused         bool                         `protobuf:"varint,6,opt,name=paused,proto3" json:"paused,omitempty"`
+	ExpressionType ScheduledTask_ExpressionType `protobuf:"varint,7,opt,name=expression_type,json=expressionType,proto3,enum=proto.ScheduledTask_ExpressionType" json:"expression_type,omitempty"`
+	Created        int64                        `protobuf:"varint,8,opt,name=created,proto3" json:"created,omitempty"`
+	Recurrence     ScheduledTask_Recurrence     `protobuf:"varint,9,opt,name=recurrence,proto3,enum=proto.ScheduledTask_Recurrence" json:"recurrence,omitempty"`
+	// Skip creating a new task if the previous task created by the scheduled task is still unresolved. Tasks with an
+	// AFTER_COMPLETION recurrence always behave this way.
+	SkipIfOpen bool `protobuf:"varint,10,opt,name=skip_if_open,json=skipIfOpen,proto3" json:"skip_if_open,omitempty"`
+	// The IANA time zone the expression is evaluated in, ex. America/New_York. Empty means the server's default
+	// time zone.
+	Timezone string `protobuf:"bytes,11,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// The id of a template to create each time the scheduled task fires instead of a single task.
+	TemplateId    string `protobuf:"bytes,12,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ScheduledTask) Reset() {
 	*x = ScheduledTask{}
-	mi := &file_todo_message_proto_msgTypes[1]
+	mi := &file_todo_message_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -187,7 +499,7 @@ func (x *ScheduledTask) String() string {
 func (*ScheduledTask) ProtoMessage() {}
 
 func (x *ScheduledTask) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_message_proto_msgTypes[1]
+	mi := &file_todo_message_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -200,7 +512,7 @@ func (x *ScheduledTask) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduledTask.ProtoReflect.Descriptor instead.
 func (*ScheduledTask) Descriptor() ([]byte, []int) {
-	return file_todo_message_proto_rawDescGZIP(), []int{1}
+	return file_todo_message_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ScheduledTask) GetId() string {
@@ -238,11 +550,430 @@ func (x *ScheduledTask) GetParent() string {
 	return ""
 }
 
+func (x *ScheduledTask) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *ScheduledTask) GetExpressionType() ScheduledTask_ExpressionType {
+	if x != nil {
+		return x.ExpressionType
+	}
+	return ScheduledTask_EXPRESSION_TYPE_UNKNOWN
+}
+
+func (x *ScheduledTask) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ScheduledTask) GetRecurrence() ScheduledTask_Recurrence {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ScheduledTask_RECURRENCE_UNKNOWN
+}
+
+func (x *ScheduledTask) GetSkipIfOpen() bool {
+	if x != nil {
+		return x.SkipIfOpen
+	}
+	return false
+}
+
+func (x *ScheduledTask) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *ScheduledTask) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+// Template describes a tree of tasks that can be created all at once.
+type Template struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Names are unique and can be used in place of the id.
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// The tasks created at the top of the tree; templates can have more than one.
+	Tasks         []*TemplateTask `protobuf:"bytes,4,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Created       int64           `protobuf:"varint,5,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Template) Reset() {
+	*x = Template{}
+	mi := &file_todo_message_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Template) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Template) ProtoMessage() {}
+
+func (x *Template) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_message_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Template.ProtoReflect.Descriptor instead.
+func (*Template) Descriptor() ([]byte, []int) {
+	return file_todo_message_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Template) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Template) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Template) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Template) GetTasks() []*TemplateTask {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *Template) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+// TemplateTask is a task within a template. Titles and descriptions can contain variables like {{month}} that are
+// filled in when the template is applied.
+type TemplateTask struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Children      []*TemplateTask        `protobuf:"bytes,3,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateTask) Reset() {
+	*x = TemplateTask{}
+	mi := &file_todo_message_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateTask) ProtoMessage() {}
+
+func (x *TemplateTask) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_message_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateTask.ProtoReflect.Descriptor instead.
+func (*TemplateTask) Descriptor() ([]byte, []int) {
+	return file_todo_message_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TemplateTask) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TemplateTask) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TemplateTask) GetChildren() []*TemplateTask {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+// Operation is an entry in the journal of changes made to tasks, kept so that changes can be undone.
+type Operation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind  Operation_Kind         `protobuf:"varint,2,opt,name=kind,proto3,enum=proto.Operation_Kind" json:"kind,omitempty"`
+	// A human readable summary of the change, ex. Completed "Pay bills" and 3 children.
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// The ids of every task the change touched.
+	TaskIds       []string `protobuf:"bytes,4,rep,name=task_ids,json=taskIds,proto3" json:"task_ids,omitempty"`
+	Created       int64    `protobuf:"varint,5,opt,name=created,proto3" json:"created,omitempty"`
+	Undone        bool     `protobuf:"varint,6,opt,name=undone,proto3" json:"undone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Operation) Reset() {
+	*x = Operation{}
+	mi := &file_todo_message_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Operation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Operation) ProtoMessage() {}
+
+func (x *Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_message_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Operation.ProtoReflect.Descriptor instead.
+func (*Operation) Descriptor() ([]byte, []int) {
+	return file_todo_message_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Operation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Operation) GetKind() Operation_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return Operation_OPERATION_KIND_UNKNOWN
+}
+
+func (x *Operation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Operation) GetTaskIds() []string {
+	if x != nil {
+		return x.TaskIds
+	}
+	return nil
+}
+
+func (x *Operation) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *Operation) GetUndone() bool {
+	if x != nil {
+		return x.Undone
+	}
+	return false
+}
+
+// FireTimes describes when a scheduled task has fired and will fire, as unix milliseconds.
+type FireTimes struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The last time the schedule fired; 0 if it has never fired.
+	Last int64 `protobuf:"varint,1,opt,name=last,proto3" json:"last,omitempty"`
+	// The next times the schedule will fire, in order.
+	Next          []int64 `protobuf:"varint,2,rep,packed,name=next,proto3" json:"next,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FireTimes) Reset() {
+	*x = FireTimes{}
+	mi := &file_todo_message_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FireTimes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FireTimes) ProtoMessage() {}
+
+func (x *FireTimes) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_message_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FireTimes.ProtoReflect.Descriptor instead.
+func (*FireTimes) Descriptor() ([]byte, []int) {
+	return file_todo_message_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FireTimes) GetLast() int64 {
+	if x != nil {
+		return x.Last
+	}
+	return 0
+}
+
+func (x *FireTimes) GetNext() []int64 {
+	if x != nil {
+		return x.Next
+	}
+	return nil
+}
+
+// Attachment is a file attached to a task.
+type Attachment struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Name   string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// The size of the file in bytes.
+	Size int64 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	// The hex encoded SHA-256 digest of the file's contents.
+	Digest        string `protobuf:"bytes,5,opt,name=digest,proto3" json:"digest,omitempty"`
+	Created       int64  `protobuf:"varint,6,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_todo_message_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_message_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_todo_message_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Attachment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Attachment) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *Attachment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *Attachment) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
 var File_todo_message_proto protoreflect.FileDescriptor
 
 const file_todo_message_proto_rawDesc = "" +
 	"\n" +
-	"\x12todo_message.proto\x12\x05proto\"\x8d\x02\n" +
+	"\x12todo_message.proto\x12\x05proto\"\xe0\x03\n" +
 	"\x04Task\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -250,12 +981,25 @@ const file_todo_message_proto_rawDesc = "" +
 	"\x05state\x18\x04 \x01(\x0e2\x15.proto.Task.TaskStateR\x05state\x12\x18\n" +
 	"\acreated\x18\x05 \x01(\x03R\acreated\x12\x1a\n" +
 	"\bmodified\x18\x06 \x01(\x03R\bmodified\x12\x16\n" +
-	"\x06parent\x18\a \x01(\tR\x06parent\"B\n" +
+	"\x06parent\x18\a \x01(\tR\x06parent\x12*\n" +
+	"\x11scheduled_task_id\x18\b \x01(\tR\x0fscheduledTaskId\x12#\n" +
+	"\rscheduled_for\x18\t \x01(\x03R\fscheduledFor\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\x03R\aversion\x12!\n" +
+	"\fcompleted_at\x18\v \x01(\x03R\vcompletedAt\x12'\n" +
+	"\x0fcompletion_note\x18\f \x01(\tR\x0ecompletionNote\x12\x1a\n" +
+	"\barchived\x18\r \x01(\x03R\barchived\"B\n" +
 	"\tTaskState\x12\x16\n" +
 	"\x12TASK_STATE_UNKNOWN\x10\x00\x12\x0e\n" +
 	"\n" +
 	"UNRESOLVED\x10\x01\x12\r\n" +
-	"\tCOMPLETED\x10\x02\"\x8f\x01\n" +
+	"\tCOMPLETED\x10\x02\"|\n" +
+	"\vTaskComment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\tR\x06author\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x12\x18\n" +
+	"\acreated\x18\x05 \x01(\x03R\acreated\"\xd5\x04\n" +
 	"\rScheduledTask\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -263,7 +1007,65 @@ const file_todo_message_proto_rawDesc = "" +
 	"\n" +
 	"expression\x18\x04 \x01(\tR\n" +
 	"expression\x12\x16\n" +
-	"\x06parent\x18\x05 \x01(\tR\x06parentB%Z#github.com/clintjedwards/todo/protob\x06proto3"
+	"\x06parent\x18\x05 \x01(\tR\x06parent\x12\x16\n" +
+	"\x06paused\x18\x06 \x01(\bR\x06paused\x12L\n" +
+	"\x0fexpression_type\x18\a \x01(\x0e2#.proto.ScheduledTask.ExpressionTypeR\x0eexpressionType\x12\x18\n" +
+	"\acreated\x18\b \x01(\x03R\acreated\x12?\n" +
+	"\n" +
+	"recurrence\x18\t \x01(\x0e2\x1f.proto.ScheduledTask.RecurrenceR\n" +
+	"recurrence\x12 \n" +
+	"\fskip_if_open\x18\n" +
+	" \x01(\bR\n" +
+	"skipIfOpen\x12\x1a\n" +
+	"\btimezone\x18\v \x01(\tR\btimezone\x12\x1f\n" +
+	"\vtemplate_id\x18\f \x01(\tR\n" +
+	"templateId\"Z\n" +
+	"\x0eExpressionType\x12\x1b\n" +
+	"\x17EXPRESSION_TYPE_UNKNOWN\x10\x00\x12\t\n" +
+	"\x05AVAIL\x10\x01\x12\b\n" +
+	"\x04CRON\x10\x02\x12\t\n" +
+	"\x05RRULE\x10\x03\x12\v\n" +
+	"\aNATURAL\x10\x04\"H\n" +
+	"\n" +
+	"Recurrence\x12\x16\n" +
+	"\x12RECURRENCE_UNKNOWN\x10\x00\x12\f\n" +
+	"\bCALENDAR\x10\x01\x12\x14\n" +
+	"\x10AFTER_COMPLETION\x10\x02\"\x95\x01\n" +
+	"\bTemplate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12)\n" +
+	"\x05tasks\x18\x04 \x03(\v2\x13.proto.TemplateTaskR\x05tasks\x12\x18\n" +
+	"\acreated\x18\x05 \x01(\x03R\acreated\"w\n" +
+	"\fTemplateTask\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12/\n" +
+	"\bchildren\x18\x03 \x03(\v2\x13.proto.TemplateTaskR\bchildren\"\xff\x01\n" +
+	"\tOperation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12)\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x15.proto.Operation.KindR\x04kind\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x19\n" +
+	"\btask_ids\x18\x04 \x03(\tR\ataskIds\x12\x18\n" +
+	"\acreated\x18\x05 \x01(\x03R\acreated\x12\x16\n" +
+	"\x06undone\x18\x06 \x01(\bR\x06undone\"H\n" +
+	"\x04Kind\x12\x1a\n" +
+	"\x16OPERATION_KIND_UNKNOWN\x10\x00\x12\f\n" +
+	"\bCOMPLETE\x10\x01\x12\n" +
+	"\n" +
+	"\x06UPDATE\x10\x02\x12\n" +
+	"\n" +
+	"\x06DELETE\x10\x03\"3\n" +
+	"\tFireTimes\x12\x12\n" +
+	"\x04last\x18\x01 \x01(\x03R\x04last\x12\x12\n" +
+	"\x04next\x18\x02 \x03(\x03R\x04next\"\x8f\x01\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06digest\x18\x05 \x01(\tR\x06digest\x12\x18\n" +
+	"\acreated\x18\x06 \x01(\x03R\acreatedB%Z#github.com/clintjedwards/todo/protob\x06proto3"
 
 var (
 	file_todo_message_proto_rawDescOnce sync.Once
@@ -277,20 +1079,34 @@ func file_todo_message_proto_rawDescGZIP() []byte {
 	return file_todo_message_proto_rawDescData
 }
 
-var file_todo_message_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_todo_message_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_todo_message_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_todo_message_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_todo_message_proto_goTypes = []any{
-	(Task_TaskState)(0),   // 0: proto.Task.TaskState
-	(*Task)(nil),          // 1: proto.Task
-	(*ScheduledTask)(nil), // 2: proto.ScheduledTask
+	(Task_TaskState)(0),               // 0: proto.Task.TaskState
+	(ScheduledTask_ExpressionType)(0), // 1: proto.ScheduledTask.ExpressionType
+	(ScheduledTask_Recurrence)(0),     // 2: proto.ScheduledTask.Recurrence
+	(Operation_Kind)(0),               // 3: proto.Operation.Kind
+	(*Task)(nil),                      // 4: proto.Task
+	(*TaskComment)(nil),               // 5: proto.TaskComment
+	(*ScheduledTask)(nil),             // 6: proto.ScheduledTask
+	(*Template)(nil),                  // 7: proto.Template
+	(*TemplateTask)(nil),              // 8: proto.TemplateTask
+	(*Operation)(nil),                 // 9: proto.Operation
+	(*FireTimes)(nil),                 // 10: proto.FireTimes
+	(*Attachment)(nil),                // 11: proto.Attachment
 }
 var file_todo_message_proto_depIdxs = []int32{
 	0, // 0: proto.Task.state:type_name -> proto.Task.TaskState
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	1, // 1: proto.ScheduledTask.expression_type:type_name -> proto.ScheduledTask.ExpressionType
+	2, // 2: proto.ScheduledTask.recurrence:type_name -> proto.ScheduledTask.Recurrence
+	8, // 3: proto.Template.tasks:type_name -> proto.TemplateTask
+	8, // 4: proto.TemplateTask.children:type_name -> proto.TemplateTask
+	3, // 5: proto.Operation.kind:type_name -> proto.Operation.Kind
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_todo_message_proto_init() }
@@ -303,8 +1119,8 @@ func file_todo_message_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_todo_message_proto_rawDesc), len(file_todo_message_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   2,
+			NumEnums:      4,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -64,7 +64,7 @@ func (x UpdateTaskRequest_TaskState) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use UpdateTaskRequest_TaskState.Descriptor instead.
 func (UpdateTaskRequest_TaskState) EnumDescriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{8, 0}
+	return file_todo_transport_proto_rawDescGZIP(), []int{9, 0}
 }
 
 type GetSystemInfoRequest struct {
@@ -108,8 +108,20 @@ type GetSystemInfoResponse struct {
 	Commit         string                 `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
 	DevModeEnabled bool                   `protobuf:"varint,2,opt,name=dev_mode_enabled,json=devModeEnabled,proto3" json:"dev_mode_enabled,omitempty"`
 	Semver         string                 `protobuf:"bytes,3,opt,name=semver,proto3" json:"semver,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// The ID of the latest database migration applied.
+	SchemaVersion string `protobuf:"bytes,4,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	// How long the server has been running in seconds.
+	UptimeSeconds int64  `protobuf:"varint,5,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	StorageDriver string `protobuf:"bytes,6,opt,name=storage_driver,json=storageDriver,proto3" json:"storage_driver,omitempty"`
+	// The path to the database file or, for network databases, the connection url with any password removed.
+	StoragePath      string         `protobuf:"bytes,7,opt,name=storage_path,json=storagePath,proto3" json:"storage_path,omitempty"`
+	StorageSizeBytes int64          `protobuf:"varint,8,opt,name=storage_size_bytes,json=storageSizeBytes,proto3" json:"storage_size_bytes,omitempty"`
+	UnresolvedTasks  int64          `protobuf:"varint,9,opt,name=unresolved_tasks,json=unresolvedTasks,proto3" json:"unresolved_tasks,omitempty"`
+	CompletedTasks   int64          `protobuf:"varint,10,opt,name=completed_tasks,json=completedTasks,proto3" json:"completed_tasks,omitempty"`
+	ScheduledTasks   int64          `protobuf:"varint,11,opt,name=scheduled_tasks,json=scheduledTasks,proto3" json:"scheduled_tasks,omitempty"`
+	Scheduler        *SchedulerInfo `protobuf:"bytes,12,opt,name=scheduler,proto3" json:"scheduler,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *GetSystemInfoResponse) Reset() {
@@ -163,6 +175,132 @@ func (x *GetSystemInfoResponse) GetSemver() string {
 	return ""
 }
 
+func (x *GetSystemInfoResponse) GetSchemaVersion() string {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return ""
+}
+
+func (x *GetSystemInfoResponse) GetUptimeSeconds() int64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *GetSystemInfoResponse) GetStorageDriver() string {
+	if x != nil {
+		return x.StorageDriver
+	}
+	return ""
+}
+
+func (x *GetSystemInfoResponse) GetStoragePath() string {
+	if x != nil {
+		return x.StoragePath
+	}
+	return ""
+}
+
+func (x *GetSystemInfoResponse) GetStorageSizeBytes() int64 {
+	if x != nil {
+		return x.StorageSizeBytes
+	}
+	return 0
+}
+
+func (x *GetSystemInfoResponse) GetUnresolvedTasks() int64 {
+	if x != nil {
+		return x.UnresolvedTasks
+	}
+	return 0
+}
+
+func (x *GetSystemInfoResponse) GetCompletedTasks() int64 {
+	if x != nil {
+		return x.CompletedTasks
+	}
+	return 0
+}
+
+func (x *GetSystemInfoResponse) GetScheduledTasks() int64 {
+	if x != nil {
+		return x.ScheduledTasks
+	}
+	return 0
+}
+
+func (x *GetSystemInfoResponse) GetScheduler() *SchedulerInfo {
+	if x != nil {
+		return x.Scheduler
+	}
+	return nil
+}
+
+type SchedulerInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether all scheduled tasks have been loaded from the database since startup.
+	Restored bool `protobuf:"varint,1,opt,name=restored,proto3" json:"restored,omitempty"`
+	// The number of scheduled tasks currently being watched.
+	Running int64 `protobuf:"varint,2,opt,name=running,proto3" json:"running,omitempty"`
+	// The number of scheduled tasks that could not be started on startup.
+	Failed        int64 `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchedulerInfo) Reset() {
+	*x = SchedulerInfo{}
+	mi := &file_todo_transport_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchedulerInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulerInfo) ProtoMessage() {}
+
+func (x *SchedulerInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulerInfo.ProtoReflect.Descriptor instead.
+func (*SchedulerInfo) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SchedulerInfo) GetRestored() bool {
+	if x != nil {
+		return x.Restored
+	}
+	return false
+}
+
+func (x *SchedulerInfo) GetRunning() int64 {
+	if x != nil {
+		return x.Running
+	}
+	return 0
+}
+
+func (x *SchedulerInfo) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
 type GetTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // The unique id for a particular task
@@ -172,7 +310,7 @@ type GetTaskRequest struct {
 
 func (x *GetTaskRequest) Reset() {
 	*x = GetTaskRequest{}
-	mi := &file_todo_transport_proto_msgTypes[2]
+	mi := &file_todo_transport_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -184,7 +322,7 @@ func (x *GetTaskRequest) String() string {
 func (*GetTaskRequest) ProtoMessage() {}
 
 func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[2]
+	mi := &file_todo_transport_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -197,7 +335,7 @@ func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
 func (*GetTaskRequest) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{2}
+	return file_todo_transport_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetTaskRequest) GetId() string {
@@ -216,7 +354,7 @@ type GetTaskResponse struct {
 
 func (x *GetTaskResponse) Reset() {
 	*x = GetTaskResponse{}
-	mi := &file_todo_transport_proto_msgTypes[3]
+	mi := &file_todo_transport_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -228,7 +366,7 @@ func (x *GetTaskResponse) String() string {
 func (*GetTaskResponse) ProtoMessage() {}
 
 func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[3]
+	mi := &file_todo_transport_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -241,7 +379,7 @@ func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
 func (*GetTaskResponse) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{3}
+	return file_todo_transport_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetTaskResponse) GetTask() *Task {
@@ -260,13 +398,15 @@ type ListTasksRequest struct {
 	// per result.
 	Limit            int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	ExcludeCompleted bool  `protobuf:"varint,3,opt,name=exclude_completed,json=excludeCompleted,proto3" json:"exclude_completed,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Also return tasks that have been archived, after all of those that haven't.
+	IncludeArchived bool `protobuf:"varint,4,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ListTasksRequest) Reset() {
 	*x = ListTasksRequest{}
-	mi := &file_todo_transport_proto_msgTypes[4]
+	mi := &file_todo_transport_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -278,7 +418,7 @@ func (x *ListTasksRequest) String() string {
 func (*ListTasksRequest) ProtoMessage() {}
 
 func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[4]
+	mi := &file_todo_transport_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -291,7 +431,7 @@ func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
 func (*ListTasksRequest) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{4}
+	return file_todo_transport_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListTasksRequest) GetOffset() int64 {
@@ -315,6 +455,13 @@ func (x *ListTasksRequest) GetExcludeCompleted() bool {
 	return false
 }
 
+func (x *ListTasksRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
 type ListTasksResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
@@ -324,7 +471,7 @@ type ListTasksResponse struct {
 
 func (x *ListTasksResponse) Reset() {
 	*x = ListTasksResponse{}
-	mi := &file_todo_transport_proto_msgTypes[5]
+	mi := &file_todo_transport_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -336,7 +483,7 @@ func (x *ListTasksResponse) String() string {
 func (*ListTasksResponse) ProtoMessage() {}
 
 func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[5]
+	mi := &file_todo_transport_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -349,7 +496,7 @@ func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
 func (*ListTasksResponse) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{5}
+	return file_todo_transport_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListTasksResponse) GetTasks() []*Task {
@@ -360,17 +507,20 @@ func (x *ListTasksResponse) GetTasks() []*Task {
 }
 
 type CreateTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Parent        string                 `protobuf:"bytes,3,opt,name=parent,proto3" json:"parent,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Parent      string                 `protobuf:"bytes,3,opt,name=parent,proto3" json:"parent,omitempty"`
+	// Chosen by clients that may send the same create more than once, ex. after losing the response. A create with the
+	// request id of an earlier one returns the task that one created instead of creating another.
+	RequestId     string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateTaskRequest) Reset() {
 	*x = CreateTaskRequest{}
-	mi := &file_todo_transport_proto_msgTypes[6]
+	mi := &file_todo_transport_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -382,7 +532,7 @@ func (x *CreateTaskRequest) String() string {
 func (*CreateTaskRequest) ProtoMessage() {}
 
 func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[6]
+	mi := &file_todo_transport_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -395,7 +545,7 @@ func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
 func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{6}
+	return file_todo_transport_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CreateTaskRequest) GetTitle() string {
@@ -419,6 +569,13 @@ func (x *CreateTaskRequest) GetParent() string {
 	return ""
 }
 
+func (x *CreateTaskRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
 type CreateTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -428,7 +585,7 @@ type CreateTaskResponse struct {
 
 func (x *CreateTaskResponse) Reset() {
 	*x = CreateTaskResponse{}
-	mi := &file_todo_transport_proto_msgTypes[7]
+	mi := &file_todo_transport_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -440,7 +597,7 @@ func (x *CreateTaskResponse) String() string {
 func (*CreateTaskResponse) ProtoMessage() {}
 
 func (x *CreateTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[7]
+	mi := &file_todo_transport_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -453,7 +610,7 @@ func (x *CreateTaskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateTaskResponse.ProtoReflect.Descriptor instead.
 func (*CreateTaskResponse) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{7}
+	return file_todo_transport_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *CreateTaskResponse) GetId() string {
@@ -463,20 +620,29 @@ func (x *CreateTaskResponse) GetId() string {
 	return ""
 }
 
+// UpdateTaskRequest changes only the fields that are set; the rest are left alone.
 type UpdateTaskRequest struct {
-	state         protoimpl.MessageState      `protogen:"open.v1"`
-	Id            string                      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                      `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                      `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Parent        string                      `protobuf:"bytes,4,opt,name=parent,proto3" json:"parent,omitempty"`
-	State         UpdateTaskRequest_TaskState `protobuf:"varint,5,opt,name=state,proto3,enum=proto.UpdateTaskRequest_TaskState" json:"state,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Description *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// An empty parent removes the task from its parent.
+	Parent *string `protobuf:"bytes,4,opt,name=parent,proto3,oneof" json:"parent,omitempty"`
+	// Completing a task also completes all of its children.
+	State *UpdateTaskRequest_TaskState `protobuf:"varint,5,opt,name=state,proto3,enum=proto.UpdateTaskRequest_TaskState,oneof" json:"state,omitempty"`
+	// If set, the update is rejected with ABORTED unless the task's version still matches, meaning no one else has
+	// changed it since it was read.
+	ExpectedVersion *int64 `protobuf:"varint,6,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	// Why or how the task was completed. Recorded on the task and each child completed along with it; reopening a task
+	// clears it.
+	CompletionNote *string `protobuf:"bytes,7,opt,name=completion_note,json=completionNote,proto3,oneof" json:"completion_note,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *UpdateTaskRequest) Reset() {
 	*x = UpdateTaskRequest{}
-	mi := &file_todo_transport_proto_msgTypes[8]
+	mi := &file_todo_transport_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -488,7 +654,7 @@ func (x *UpdateTaskRequest) String() string {
 func (*UpdateTaskRequest) ProtoMessage() {}
 
 func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[8]
+	mi := &file_todo_transport_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -501,7 +667,7 @@ func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
 func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{8}
+	return file_todo_transport_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UpdateTaskRequest) GetId() string {
@@ -512,42 +678,58 @@ func (x *UpdateTaskRequest) GetId() string {
 }
 
 func (x *UpdateTaskRequest) GetTitle() string {
-	if x != nil {
-		return x.Title
+	if x != nil && x.Title != nil {
+		return *x.Title
 	}
 	return ""
 }
 
 func (x *UpdateTaskRequest) GetDescription() string {
-	if x != nil {
-		return x.Description
+	if x != nil && x.Description != nil {
+		return *x.Description
 	}
 	return ""
 }
 
 func (x *UpdateTaskRequest) GetParent() string {
-	if x != nil {
-		return x.Parent
+	if x != nil && x.Parent != nil {
+		return *x.Parent
 	}
 	return ""
 }
 
 func (x *UpdateTaskRequest) GetState() UpdateTaskRequest_TaskState {
-	if x != nil {
-		return x.State
+	if x != nil && x.State != nil {
+		return *x.State
 	}
 	return UpdateTaskRequest_UNRESOLVED
 }
 
+func (x *UpdateTaskRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetCompletionNote() string {
+	if x != nil && x.CompletionNote != nil {
+		return *x.CompletionNote
+	}
+	return ""
+}
+
 type UpdateTaskResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The task as it is after the update.
+	Task          *Task `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateTaskResponse) Reset() {
 	*x = UpdateTaskResponse{}
-	mi := &file_todo_transport_proto_msgTypes[9]
+	mi := &file_todo_transport_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -559,7 +741,7 @@ func (x *UpdateTaskResponse) String() string {
 func (*UpdateTaskResponse) ProtoMessage() {}
 
 func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[9]
+	mi := &file_todo_transport_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -572,7 +754,14 @@ func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTaskResponse.ProtoReflect.Descriptor instead.
 func (*UpdateTaskResponse) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{9}
+	return file_todo_transport_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
 }
 
 type DeleteTaskRequest struct {
@@ -584,7 +773,7 @@ type DeleteTaskRequest struct {
 
 func (x *DeleteTaskRequest) Reset() {
 	*x = DeleteTaskRequest{}
-	mi := &file_todo_transport_proto_msgTypes[10]
+	mi := &file_todo_transport_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -596,7 +785,7 @@ func (x *DeleteTaskRequest) String() string {
 func (*DeleteTaskRequest) ProtoMessage() {}
 
 func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[10]
+	mi := &file_todo_transport_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -609,7 +798,7 @@ func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
 func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{10}
+	return file_todo_transport_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *DeleteTaskRequest) GetId() string {
@@ -629,7 +818,7 @@ type DeleteTaskResponse struct {
 
 func (x *DeleteTaskResponse) Reset() {
 	*x = DeleteTaskResponse{}
-	mi := &file_todo_transport_proto_msgTypes[11]
+	mi := &file_todo_transport_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -641,7 +830,7 @@ func (x *DeleteTaskResponse) String() string {
 func (*DeleteTaskResponse) ProtoMessage() {}
 
 func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[11]
+	mi := &file_todo_transport_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -654,7 +843,7 @@ func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTaskResponse.ProtoReflect.Descriptor instead.
 func (*DeleteTaskResponse) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{11}
+	return file_todo_transport_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *DeleteTaskResponse) GetIds() []string {
@@ -664,28 +853,34 @@ func (x *DeleteTaskResponse) GetIds() []string {
 	return nil
 }
 
-type GetScheduledTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // The unique id for a particular task
+// TaskFilter selects tasks for batch operations. Tasks must match every criterion set; at least one must be set.
+type TaskFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only match tasks in this state. TASK_STATE_UNKNOWN matches any state.
+	State Task_TaskState `protobuf:"varint,1,opt,name=state,proto3,enum=proto.Task_TaskState" json:"state,omitempty"`
+	// Only match tasks below this task: its children, their children and so on. The task itself isn't matched.
+	Subtree string `protobuf:"bytes,2,opt,name=subtree,proto3" json:"subtree,omitempty"`
+	// Only match tasks created before this time, in unix milliseconds.
+	CreatedBefore int64 `protobuf:"varint,3,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetScheduledTaskRequest) Reset() {
-	*x = GetScheduledTaskRequest{}
-	mi := &file_todo_transport_proto_msgTypes[12]
+func (x *TaskFilter) Reset() {
+	*x = TaskFilter{}
+	mi := &file_todo_transport_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetScheduledTaskRequest) String() string {
+func (x *TaskFilter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetScheduledTaskRequest) ProtoMessage() {}
+func (*TaskFilter) ProtoMessage() {}
 
-func (x *GetScheduledTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[12]
+func (x *TaskFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -696,40 +891,60 @@ func (x *GetScheduledTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetScheduledTaskRequest.ProtoReflect.Descriptor instead.
-func (*GetScheduledTaskRequest) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use TaskFilter.ProtoReflect.Descriptor instead.
+func (*TaskFilter) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *GetScheduledTaskRequest) GetId() string {
+func (x *TaskFilter) GetState() Task_TaskState {
 	if x != nil {
-		return x.Id
+		return x.State
+	}
+	return Task_TASK_STATE_UNKNOWN
+}
+
+func (x *TaskFilter) GetSubtree() string {
+	if x != nil {
+		return x.Subtree
 	}
 	return ""
 }
 
-type GetScheduledTaskResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ScheduledTask *ScheduledTask         `protobuf:"bytes,1,opt,name=scheduled_task,json=scheduledTask,proto3" json:"scheduled_task,omitempty"`
+func (x *TaskFilter) GetCreatedBefore() int64 {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return 0
+}
+
+// BatchTaskResult is the outcome of a batch operation for a single task.
+type BatchTaskResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The id of the task as given or, for filters, the id of the task matched.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Why the operation failed for this task; empty if it succeeded.
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// The ids of every task changed, which includes children when completing or deleting a task.
+	AffectedIds   []string `protobuf:"bytes,3,rep,name=affected_ids,json=affectedIds,proto3" json:"affected_ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetScheduledTaskResponse) Reset() {
-	*x = GetScheduledTaskResponse{}
-	mi := &file_todo_transport_proto_msgTypes[13]
+func (x *BatchTaskResult) Reset() {
+	*x = BatchTaskResult{}
+	mi := &file_todo_transport_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetScheduledTaskResponse) String() string {
+func (x *BatchTaskResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetScheduledTaskResponse) ProtoMessage() {}
+func (*BatchTaskResult) ProtoMessage() {}
 
-func (x *GetScheduledTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[13]
+func (x *BatchTaskResult) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -740,45 +955,2793 @@ func (x *GetScheduledTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetScheduledTaskResponse.ProtoReflect.Descriptor instead.
-func (*GetScheduledTaskResponse) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use BatchTaskResult.ProtoReflect.Descriptor instead.
+func (*BatchTaskResult) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GetScheduledTaskResponse) GetScheduledTask() *ScheduledTask {
+func (x *BatchTaskResult) GetId() string {
 	if x != nil {
-		return x.ScheduledTask
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BatchTaskResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchTaskResult) GetAffectedIds() []string {
+	if x != nil {
+		return x.AffectedIds
 	}
 	return nil
 }
 
-type ListScheduledTasksRequest struct {
+type BatchUpdateTasksRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// offset is a pagination parameter that defines where to start when counting
-	// the list of pipelines to return.
+	// The ids or id prefixes of the tasks to update. Either ids or filter must be given but not both.
+	Ids    []string    `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Filter *TaskFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// The changes to make to every task; fields that aren't set are left alone. Completing a task also completes all
+	// of its children.
+	Title          *string                      `protobuf:"bytes,3,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Description    *string                      `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Parent         *string                      `protobuf:"bytes,5,opt,name=parent,proto3,oneof" json:"parent,omitempty"`
+	State          *UpdateTaskRequest_TaskState `protobuf:"varint,6,opt,name=state,proto3,enum=proto.UpdateTaskRequest_TaskState,oneof" json:"state,omitempty"`
+	CompletionNote *string                      `protobuf:"bytes,8,opt,name=completion_note,json=completionNote,proto3,oneof" json:"completion_note,omitempty"`
+	// Report what would change without changing anything.
+	DryRun        bool `protobuf:"varint,7,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateTasksRequest) Reset() {
+	*x = BatchUpdateTasksRequest{}
+	mi := &file_todo_transport_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateTasksRequest) ProtoMessage() {}
+
+func (x *BatchUpdateTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateTasksRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateTasksRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BatchUpdateTasksRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BatchUpdateTasksRequest) GetFilter() *TaskFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *BatchUpdateTasksRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *BatchUpdateTasksRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *BatchUpdateTasksRequest) GetParent() string {
+	if x != nil && x.Parent != nil {
+		return *x.Parent
+	}
+	return ""
+}
+
+func (x *BatchUpdateTasksRequest) GetState() UpdateTaskRequest_TaskState {
+	if x != nil && x.State != nil {
+		return *x.State
+	}
+	return UpdateTaskRequest_UNRESOLVED
+}
+
+func (x *BatchUpdateTasksRequest) GetCompletionNote() string {
+	if x != nil && x.CompletionNote != nil {
+		return *x.CompletionNote
+	}
+	return ""
+}
+
+func (x *BatchUpdateTasksRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type BatchUpdateTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BatchTaskResult     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateTasksResponse) Reset() {
+	*x = BatchUpdateTasksResponse{}
+	mi := &file_todo_transport_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateTasksResponse) ProtoMessage() {}
+
+func (x *BatchUpdateTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateTasksResponse.ProtoReflect.Descriptor instead.
+func (*BatchUpdateTasksResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BatchUpdateTasksResponse) GetResults() []*BatchTaskResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type BatchDeleteTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ids or id prefixes of the tasks to delete. Either ids or filter must be given but not both. Deleting a task
+	// also deletes all of its children.
+	Ids    []string    `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Filter *TaskFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Report what would be deleted without deleting anything.
+	DryRun        bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteTasksRequest) Reset() {
+	*x = BatchDeleteTasksRequest{}
+	mi := &file_todo_transport_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteTasksRequest) ProtoMessage() {}
+
+func (x *BatchDeleteTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteTasksRequest.ProtoReflect.Descriptor instead.
+func (*BatchDeleteTasksRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BatchDeleteTasksRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BatchDeleteTasksRequest) GetFilter() *TaskFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *BatchDeleteTasksRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type BatchDeleteTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BatchTaskResult     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteTasksResponse) Reset() {
+	*x = BatchDeleteTasksResponse{}
+	mi := &file_todo_transport_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteTasksResponse) ProtoMessage() {}
+
+func (x *BatchDeleteTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteTasksResponse.ProtoReflect.Descriptor instead.
+func (*BatchDeleteTasksResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BatchDeleteTasksResponse) GetResults() []*BatchTaskResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ListOperationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// offset is a pagination parameter that defines where to start when counting
+	// the list of operations to return.
 	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
-	// limit is a pagination parameter that defines how many pipelines to return
+	// limit is a pagination parameter that defines how many operations to return
 	// per result.
 	Limit         int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListScheduledTasksRequest) Reset() {
-	*x = ListScheduledTasksRequest{}
-	mi := &file_todo_transport_proto_msgTypes[14]
+func (x *ListOperationsRequest) Reset() {
+	*x = ListOperationsRequest{}
+	mi := &file_todo_transport_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOperationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOperationsRequest) ProtoMessage() {}
+
+func (x *ListOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOperationsRequest.ProtoReflect.Descriptor instead.
+func (*ListOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListOperationsRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListOperationsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListOperationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Operations newest first.
+	Operations    []*Operation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOperationsResponse) Reset() {
+	*x = ListOperationsResponse{}
+	mi := &file_todo_transport_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOperationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOperationsResponse) ProtoMessage() {}
+
+func (x *ListOperationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOperationsResponse.ProtoReflect.Descriptor instead.
+func (*ListOperationsResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListOperationsResponse) GetOperations() []*Operation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+type UndoOperationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The id or id prefix of the operation to undo. Defaults to the most recent operation that hasn't been undone,
+	// whoever made it; operations aren't tracked per client.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Undo the operation even if the tasks it touched have been changed since. Those later changes are lost.
+	Force         bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndoOperationRequest) Reset() {
+	*x = UndoOperationRequest{}
+	mi := &file_todo_transport_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndoOperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoOperationRequest) ProtoMessage() {}
+
+func (x *UndoOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoOperationRequest.ProtoReflect.Descriptor instead.
+func (*UndoOperationRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UndoOperationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UndoOperationRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type UndoOperationResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Operation *Operation             `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	// The ids of every task put back the way it was.
+	RestoredIds   []string `protobuf:"bytes,2,rep,name=restored_ids,json=restoredIds,proto3" json:"restored_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndoOperationResponse) Reset() {
+	*x = UndoOperationResponse{}
+	mi := &file_todo_transport_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndoOperationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoOperationResponse) ProtoMessage() {}
+
+func (x *UndoOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoOperationResponse.ProtoReflect.Descriptor instead.
+func (*UndoOperationResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UndoOperationResponse) GetOperation() *Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+func (x *UndoOperationResponse) GetRestoredIds() []string {
+	if x != nil {
+		return x.RestoredIds
+	}
+	return nil
+}
+
+type GetTaskStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// How many days, counting today, to count completions for. Defaults to 14.
+	Days int64 `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	// How many weeks, counting this one, to count completions for. Weeks start on monday. Defaults to 8.
+	Weeks int64 `protobuf:"varint,2,opt,name=weeks,proto3" json:"weeks,omitempty"`
+	// IANA time zone days and weeks are counted in. Defaults to the server's time zone.
+	Timezone      string `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskStatsRequest) Reset() {
+	*x = GetTaskStatsRequest{}
+	mi := &file_todo_transport_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskStatsRequest) ProtoMessage() {}
+
+func (x *GetTaskStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskStatsRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetTaskStatsRequest) GetDays() int64 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *GetTaskStatsRequest) GetWeeks() int64 {
+	if x != nil {
+		return x.Weeks
+	}
+	return 0
+}
+
+func (x *GetTaskStatsRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type GetTaskStatsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Open      int64                  `protobuf:"varint,1,opt,name=open,proto3" json:"open,omitempty"`
+	Completed int64                  `protobuf:"varint,2,opt,name=completed,proto3" json:"completed,omitempty"`
+	// Completions per day and per week, oldest first.
+	CompletedPerDay  []*PeriodCount `protobuf:"bytes,3,rep,name=completed_per_day,json=completedPerDay,proto3" json:"completed_per_day,omitempty"`
+	CompletedPerWeek []*PeriodCount `protobuf:"bytes,4,rep,name=completed_per_week,json=completedPerWeek,proto3" json:"completed_per_week,omitempty"`
+	// The mean time in milliseconds from a task being created to it being completed; 0 if none have been.
+	AverageTimeToComplete int64 `protobuf:"varint,5,opt,name=average_time_to_complete,json=averageTimeToComplete,proto3" json:"average_time_to_complete,omitempty"`
+	// How long open tasks have been open, youngest first.
+	OpenByAge []*AgeBucket `protobuf:"bytes,6,rep,name=open_by_age,json=openByAge,proto3" json:"open_by_age,omitempty"`
+	// Progress of each open top level task that has children.
+	Progress []*TaskProgress `protobuf:"bytes,7,rep,name=progress,proto3" json:"progress,omitempty"`
+	// The time zone days and weeks were counted in.
+	Timezone      string `protobuf:"bytes,8,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskStatsResponse) Reset() {
+	*x = GetTaskStatsResponse{}
+	mi := &file_todo_transport_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskStatsResponse) ProtoMessage() {}
+
+func (x *GetTaskStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskStatsResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetTaskStatsResponse) GetOpen() int64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *GetTaskStatsResponse) GetCompleted() int64 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *GetTaskStatsResponse) GetCompletedPerDay() []*PeriodCount {
+	if x != nil {
+		return x.CompletedPerDay
+	}
+	return nil
+}
+
+func (x *GetTaskStatsResponse) GetCompletedPerWeek() []*PeriodCount {
+	if x != nil {
+		return x.CompletedPerWeek
+	}
+	return nil
+}
+
+func (x *GetTaskStatsResponse) GetAverageTimeToComplete() int64 {
+	if x != nil {
+		return x.AverageTimeToComplete
+	}
+	return 0
+}
+
+func (x *GetTaskStatsResponse) GetOpenByAge() []*AgeBucket {
+	if x != nil {
+		return x.OpenByAge
+	}
+	return nil
+}
+
+func (x *GetTaskStatsResponse) GetProgress() []*TaskProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *GetTaskStatsResponse) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+// PeriodCount is a count of events in the period starting at the given time.
+type PeriodCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         int64                  `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeriodCount) Reset() {
+	*x = PeriodCount{}
+	mi := &file_todo_transport_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeriodCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeriodCount) ProtoMessage() {}
+
+func (x *PeriodCount) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeriodCount.ProtoReflect.Descriptor instead.
+func (*PeriodCount) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PeriodCount) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *PeriodCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type AgeBucket struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Human readable range of ages the bucket covers, ex: "1-7 days".
+	Label         string `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Count         int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgeBucket) Reset() {
+	*x = AgeBucket{}
+	mi := &file_todo_transport_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgeBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgeBucket) ProtoMessage() {}
+
+func (x *AgeBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgeBucket.ProtoReflect.Descriptor instead.
+func (*AgeBucket) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AgeBucket) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *AgeBucket) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// TaskProgress is how many of the tasks beneath a task, at any depth, are completed.
+type TaskProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Completed     int64                  `protobuf:"varint,3,opt,name=completed,proto3" json:"completed,omitempty"`
+	Total         int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskProgress) Reset() {
+	*x = TaskProgress{}
+	mi := &file_todo_transport_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskProgress) ProtoMessage() {}
+
+func (x *TaskProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskProgress.ProtoReflect.Descriptor instead.
+func (*TaskProgress) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TaskProgress) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TaskProgress) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TaskProgress) GetCompleted() int64 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *TaskProgress) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetScheduledTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // The unique id for a particular task
+	// The number of upcoming fire times to return.
+	NextFireCount int64 `protobuf:"varint,2,opt,name=next_fire_count,json=nextFireCount,proto3" json:"next_fire_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScheduledTaskRequest) Reset() {
+	*x = GetScheduledTaskRequest{}
+	mi := &file_todo_transport_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScheduledTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScheduledTaskRequest) ProtoMessage() {}
+
+func (x *GetScheduledTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScheduledTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetScheduledTaskRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetScheduledTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetScheduledTaskRequest) GetNextFireCount() int64 {
+	if x != nil {
+		return x.NextFireCount
+	}
+	return 0
+}
+
+type GetScheduledTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledTask *ScheduledTask         `protobuf:"bytes,1,opt,name=scheduled_task,json=scheduledTask,proto3" json:"scheduled_task,omitempty"`
+	FireTimes     *FireTimes             `protobuf:"bytes,2,opt,name=fire_times,json=fireTimes,proto3" json:"fire_times,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScheduledTaskResponse) Reset() {
+	*x = GetScheduledTaskResponse{}
+	mi := &file_todo_transport_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScheduledTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScheduledTaskResponse) ProtoMessage() {}
+
+func (x *GetScheduledTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScheduledTaskResponse.ProtoReflect.Descriptor instead.
+func (*GetScheduledTaskResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetScheduledTaskResponse) GetScheduledTask() *ScheduledTask {
+	if x != nil {
+		return x.ScheduledTask
+	}
+	return nil
+}
+
+func (x *GetScheduledTaskResponse) GetFireTimes() *FireTimes {
+	if x != nil {
+		return x.FireTimes
+	}
+	return nil
+}
+
+type ListScheduledTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// offset is a pagination parameter that defines where to start when counting
+	// the list of pipelines to return.
+	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit is a pagination parameter that defines how many pipelines to return
+	// per result.
+	Limit int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// The number of upcoming fire times to return for each scheduled task.
+	NextFireCount int64 `protobuf:"varint,3,opt,name=next_fire_count,json=nextFireCount,proto3" json:"next_fire_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledTasksRequest) Reset() {
+	*x = ListScheduledTasksRequest{}
+	mi := &file_todo_transport_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledTasksRequest) ProtoMessage() {}
+
+func (x *ListScheduledTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledTasksRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListScheduledTasksRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListScheduledTasksRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListScheduledTasksRequest) GetNextFireCount() int64 {
+	if x != nil {
+		return x.NextFireCount
+	}
+	return 0
+}
+
+type ListScheduledTasksResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledTasks []*ScheduledTask       `protobuf:"bytes,1,rep,name=scheduled_tasks,json=scheduledTasks,proto3" json:"scheduled_tasks,omitempty"`
+	// Fire times for each scheduled task keyed by scheduled task id.
+	FireTimes     map[string]*FireTimes `protobuf:"bytes,2,rep,name=fire_times,json=fireTimes,proto3" json:"fire_times,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledTasksResponse) Reset() {
+	*x = ListScheduledTasksResponse{}
+	mi := &file_todo_transport_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledTasksResponse) ProtoMessage() {}
+
+func (x *ListScheduledTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledTasksResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListScheduledTasksResponse) GetScheduledTasks() []*ScheduledTask {
+	if x != nil {
+		return x.ScheduledTasks
+	}
+	return nil
+}
+
+func (x *ListScheduledTasksResponse) GetFireTimes() map[string]*FireTimes {
+	if x != nil {
+		return x.FireTimes
+	}
+	return nil
+}
+
+type CreateScheduledTaskRequest struct {
+	state          protoimpl.MessageState       `protogen:"open.v1"`
+	Title          string                       `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description    string                       `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Parent         string                       `protobuf:"bytes,3,opt,name=parent,proto3" json:"parent,omitempty"`
+	Expression     string                       `protobuf:"bytes,4,opt,name=expression,proto3" json:"expression,omitempty"`
+	ExpressionType ScheduledTask_ExpressionType `protobuf:"varint,5,opt,name=expression_type,json=expressionType,proto3,enum=proto.ScheduledTask_ExpressionType" json:"expression_type,omitempty"`
+	Recurrence     ScheduledTask_Recurrence     `protobuf:"varint,6,opt,name=recurrence,proto3,enum=proto.ScheduledTask_Recurrence" json:"recurrence,omitempty"`
+	SkipIfOpen     bool                         `protobuf:"varint,7,opt,name=skip_if_open,json=skipIfOpen,proto3" json:"skip_if_open,omitempty"`
+	// The IANA time zone to evaluate the expression in; defaults to the server's time zone.
+	Timezone string `protobuf:"bytes,8,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// The id or name of a template to create each time the scheduled task fires instead of a single task. The title
+	// defaults to the template's name.
+	Template      string `protobuf:"bytes,9,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateScheduledTaskRequest) Reset() {
+	*x = CreateScheduledTaskRequest{}
+	mi := &file_todo_transport_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateScheduledTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScheduledTaskRequest) ProtoMessage() {}
+
+func (x *CreateScheduledTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScheduledTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateScheduledTaskRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreateScheduledTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateScheduledTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateScheduledTaskRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *CreateScheduledTaskRequest) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *CreateScheduledTaskRequest) GetExpressionType() ScheduledTask_ExpressionType {
+	if x != nil {
+		return x.ExpressionType
+	}
+	return ScheduledTask_EXPRESSION_TYPE_UNKNOWN
+}
+
+func (x *CreateScheduledTaskRequest) GetRecurrence() ScheduledTask_Recurrence {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ScheduledTask_RECURRENCE_UNKNOWN
+}
+
+func (x *CreateScheduledTaskRequest) GetSkipIfOpen() bool {
+	if x != nil {
+		return x.SkipIfOpen
+	}
+	return false
+}
+
+func (x *CreateScheduledTaskRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *CreateScheduledTaskRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+type CreateScheduledTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateScheduledTaskResponse) Reset() {
+	*x = CreateScheduledTaskResponse{}
+	mi := &file_todo_transport_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateScheduledTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScheduledTaskResponse) ProtoMessage() {}
+
+func (x *CreateScheduledTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScheduledTaskResponse.ProtoReflect.Descriptor instead.
+func (*CreateScheduledTaskResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateScheduledTaskResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UpdateScheduledTaskRequest struct {
+	state          protoimpl.MessageState       `protogen:"open.v1"`
+	Id             string                       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title          string                       `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description    string                       `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Parent         string                       `protobuf:"bytes,4,opt,name=parent,proto3" json:"parent,omitempty"`
+	Expression     string                       `protobuf:"bytes,5,opt,name=expression,proto3" json:"expression,omitempty"`
+	ExpressionType ScheduledTask_ExpressionType `protobuf:"varint,6,opt,name=expression_type,json=expressionType,proto3,enum=proto.ScheduledTask_ExpressionType" json:"expression_type,omitempty"`
+	Recurrence     ScheduledTask_Recurrence     `protobuf:"varint,7,opt,name=recurrence,proto3,enum=proto.ScheduledTask_Recurrence" json:"recurrence,omitempty"`
+	SkipIfOpen     bool                         `protobuf:"varint,8,opt,name=skip_if_open,json=skipIfOpen,proto3" json:"skip_if_open,omitempty"`
+	Timezone       string                       `protobuf:"bytes,9,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Template       string                       `protobuf:"bytes,10,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateScheduledTaskRequest) Reset() {
+	*x = UpdateScheduledTaskRequest{}
+	mi := &file_todo_transport_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateScheduledTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateScheduledTaskRequest) ProtoMessage() {}
+
+func (x *UpdateScheduledTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateScheduledTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateScheduledTaskRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UpdateScheduledTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateScheduledTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateScheduledTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateScheduledTaskRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *UpdateScheduledTaskRequest) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *UpdateScheduledTaskRequest) GetExpressionType() ScheduledTask_ExpressionType {
+	if x != nil {
+		return x.ExpressionType
+	}
+	return ScheduledTask_EXPRESSION_TYPE_UNKNOWN
+}
+
+func (x *UpdateScheduledTaskRequest) GetRecurrence() ScheduledTask_Recurrence {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ScheduledTask_RECURRENCE_UNKNOWN
+}
+
+func (x *UpdateScheduledTaskRequest) GetSkipIfOpen() bool {
+	if x != nil {
+		return x.SkipIfOpen
+	}
+	return false
+}
+
+func (x *UpdateScheduledTaskRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *UpdateScheduledTaskRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+type UpdateScheduledTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateScheduledTaskResponse) Reset() {
+	*x = UpdateScheduledTaskResponse{}
+	mi := &file_todo_transport_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateScheduledTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateScheduledTaskResponse) ProtoMessage() {}
+
+func (x *UpdateScheduledTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateScheduledTaskResponse.ProtoReflect.Descriptor instead.
+func (*UpdateScheduledTaskResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{35}
+}
+
+type DeleteScheduledTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteScheduledTaskRequest) Reset() {
+	*x = DeleteScheduledTaskRequest{}
+	mi := &file_todo_transport_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteScheduledTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteScheduledTaskRequest) ProtoMessage() {}
+
+func (x *DeleteScheduledTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteScheduledTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteScheduledTaskRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DeleteScheduledTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteScheduledTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteScheduledTaskResponse) Reset() {
+	*x = DeleteScheduledTaskResponse{}
+	mi := &file_todo_transport_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteScheduledTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteScheduledTaskResponse) ProtoMessage() {}
+
+func (x *DeleteScheduledTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteScheduledTaskResponse.ProtoReflect.Descriptor instead.
+func (*DeleteScheduledTaskResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DeleteScheduledTaskResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PauseScheduledTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseScheduledTaskRequest) Reset() {
+	*x = PauseScheduledTaskRequest{}
+	mi := &file_todo_transport_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseScheduledTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseScheduledTaskRequest) ProtoMessage() {}
+
+func (x *PauseScheduledTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseScheduledTaskRequest.ProtoReflect.Descriptor instead.
+func (*PauseScheduledTaskRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PauseScheduledTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PauseScheduledTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseScheduledTaskResponse) Reset() {
+	*x = PauseScheduledTaskResponse{}
+	mi := &file_todo_transport_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseScheduledTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseScheduledTaskResponse) ProtoMessage() {}
+
+func (x *PauseScheduledTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseScheduledTaskResponse.ProtoReflect.Descriptor instead.
+func (*PauseScheduledTaskResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PauseScheduledTaskResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ResumeScheduledTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeScheduledTaskRequest) Reset() {
+	*x = ResumeScheduledTaskRequest{}
+	mi := &file_todo_transport_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeScheduledTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeScheduledTaskRequest) ProtoMessage() {}
+
+func (x *ResumeScheduledTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeScheduledTaskRequest.ProtoReflect.Descriptor instead.
+func (*ResumeScheduledTaskRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ResumeScheduledTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ResumeScheduledTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeScheduledTaskResponse) Reset() {
+	*x = ResumeScheduledTaskResponse{}
+	mi := &file_todo_transport_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeScheduledTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeScheduledTaskResponse) ProtoMessage() {}
+
+func (x *ResumeScheduledTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeScheduledTaskResponse.ProtoReflect.Descriptor instead.
+func (*ResumeScheduledTaskResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ResumeScheduledTaskResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetScheduledTaskHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The number of tasks to return, newest first. The summary always covers every task.
+	Limit         int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScheduledTaskHistoryRequest) Reset() {
+	*x = GetScheduledTaskHistoryRequest{}
+	mi := &file_todo_transport_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScheduledTaskHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScheduledTaskHistoryRequest) ProtoMessage() {}
+
+func (x *GetScheduledTaskHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScheduledTaskHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetScheduledTaskHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetScheduledTaskHistoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetScheduledTaskHistoryRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetScheduledTaskHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledTask *ScheduledTask         `protobuf:"bytes,1,opt,name=scheduled_task,json=scheduledTask,proto3" json:"scheduled_task,omitempty"`
+	// The tasks created by the scheduled task, newest first.
+	Tasks         []*Task                      `protobuf:"bytes,2,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Summary       *ScheduledTaskHistorySummary `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScheduledTaskHistoryResponse) Reset() {
+	*x = GetScheduledTaskHistoryResponse{}
+	mi := &file_todo_transport_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScheduledTaskHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScheduledTaskHistoryResponse) ProtoMessage() {}
+
+func (x *GetScheduledTaskHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScheduledTaskHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetScheduledTaskHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetScheduledTaskHistoryResponse) GetScheduledTask() *ScheduledTask {
+	if x != nil {
+		return x.ScheduledTask
+	}
+	return nil
+}
+
+func (x *GetScheduledTaskHistoryResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *GetScheduledTaskHistoryResponse) GetSummary() *ScheduledTaskHistorySummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+type ScheduledTaskHistorySummary struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The number of tasks the scheduled task has created that still exist.
+	Total     int64 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Completed int64 `protobuf:"varint,2,opt,name=completed,proto3" json:"completed,omitempty"`
+	// The fraction of tasks that were completed, between 0 and 1. The newest task is left out while it is still
+	// unresolved since there may still be time to complete it.
+	CompletionRate float64 `protobuf:"fixed64,3,opt,name=completion_rate,json=completionRate,proto3" json:"completion_rate,omitempty"`
+	// The number of tasks completed in a row, counting back from the newest.
+	CurrentStreak int64 `protobuf:"varint,4,opt,name=current_streak,json=currentStreak,proto3" json:"current_streak,omitempty"`
+	LongestStreak int64 `protobuf:"varint,5,opt,name=longest_streak,json=longestStreak,proto3" json:"longest_streak,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledTaskHistorySummary) Reset() {
+	*x = ScheduledTaskHistorySummary{}
+	mi := &file_todo_transport_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledTaskHistorySummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledTaskHistorySummary) ProtoMessage() {}
+
+func (x *ScheduledTaskHistorySummary) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledTaskHistorySummary.ProtoReflect.Descriptor instead.
+func (*ScheduledTaskHistorySummary) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ScheduledTaskHistorySummary) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ScheduledTaskHistorySummary) GetCompleted() int64 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *ScheduledTaskHistorySummary) GetCompletionRate() float64 {
+	if x != nil {
+		return x.CompletionRate
+	}
+	return 0
+}
+
+func (x *ScheduledTaskHistorySummary) GetCurrentStreak() int64 {
+	if x != nil {
+		return x.CurrentStreak
+	}
+	return 0
+}
+
+func (x *ScheduledTaskHistorySummary) GetLongestStreak() int64 {
+	if x != nil {
+		return x.LongestStreak
+	}
+	return 0
+}
+
+type PreviewScheduleRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Expression string                 `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	// The number of upcoming fire times to return.
+	NextFireCount  int64                        `protobuf:"varint,2,opt,name=next_fire_count,json=nextFireCount,proto3" json:"next_fire_count,omitempty"`
+	ExpressionType ScheduledTask_ExpressionType `protobuf:"varint,3,opt,name=expression_type,json=expressionType,proto3,enum=proto.ScheduledTask_ExpressionType" json:"expression_type,omitempty"`
+	Recurrence     ScheduledTask_Recurrence     `protobuf:"varint,4,opt,name=recurrence,proto3,enum=proto.ScheduledTask_Recurrence" json:"recurrence,omitempty"`
+	Timezone       string                       `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PreviewScheduleRequest) Reset() {
+	*x = PreviewScheduleRequest{}
+	mi := &file_todo_transport_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewScheduleRequest) ProtoMessage() {}
+
+func (x *PreviewScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewScheduleRequest.ProtoReflect.Descriptor instead.
+func (*PreviewScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *PreviewScheduleRequest) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *PreviewScheduleRequest) GetNextFireCount() int64 {
+	if x != nil {
+		return x.NextFireCount
+	}
+	return 0
+}
+
+func (x *PreviewScheduleRequest) GetExpressionType() ScheduledTask_ExpressionType {
+	if x != nil {
+		return x.ExpressionType
+	}
+	return ScheduledTask_EXPRESSION_TYPE_UNKNOWN
+}
+
+func (x *PreviewScheduleRequest) GetRecurrence() ScheduledTask_Recurrence {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ScheduledTask_RECURRENCE_UNKNOWN
+}
+
+func (x *PreviewScheduleRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type PreviewScheduleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Fire times are only returned for CALENDAR recurrences.
+	FireTimes *FireTimes `protobuf:"bytes,1,opt,name=fire_times,json=fireTimes,proto3" json:"fire_times,omitempty"`
+	// The type of the expression; useful when it was detected.
+	ExpressionType ScheduledTask_ExpressionType `protobuf:"varint,2,opt,name=expression_type,json=expressionType,proto3,enum=proto.ScheduledTask_ExpressionType" json:"expression_type,omitempty"`
+	Recurrence     ScheduledTask_Recurrence     `protobuf:"varint,3,opt,name=recurrence,proto3,enum=proto.ScheduledTask_Recurrence" json:"recurrence,omitempty"`
+	// For AFTER_COMPLETION recurrences, a description of how long after completion the next task is created.
+	Delay string `protobuf:"bytes,4,opt,name=delay,proto3" json:"delay,omitempty"`
+	// The time zone the expression was evaluated in.
+	Timezone      string `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewScheduleResponse) Reset() {
+	*x = PreviewScheduleResponse{}
+	mi := &file_todo_transport_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewScheduleResponse) ProtoMessage() {}
+
+func (x *PreviewScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewScheduleResponse.ProtoReflect.Descriptor instead.
+func (*PreviewScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *PreviewScheduleResponse) GetFireTimes() *FireTimes {
+	if x != nil {
+		return x.FireTimes
+	}
+	return nil
+}
+
+func (x *PreviewScheduleResponse) GetExpressionType() ScheduledTask_ExpressionType {
+	if x != nil {
+		return x.ExpressionType
+	}
+	return ScheduledTask_EXPRESSION_TYPE_UNKNOWN
+}
+
+func (x *PreviewScheduleResponse) GetRecurrence() ScheduledTask_Recurrence {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ScheduledTask_RECURRENCE_UNKNOWN
+}
+
+func (x *PreviewScheduleResponse) GetDelay() string {
+	if x != nil {
+		return x.Delay
+	}
+	return ""
+}
+
+func (x *PreviewScheduleResponse) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type ListTemplatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// offset is a pagination parameter that defines where to start when counting
+	// the list of templates to return.
+	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit is a pagination parameter that defines how many templates to return
+	// per result.
+	Limit         int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTemplatesRequest) Reset() {
+	*x = ListTemplatesRequest{}
+	mi := &file_todo_transport_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesRequest) ProtoMessage() {}
+
+func (x *ListTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListTemplatesRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListTemplatesRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*Template            `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTemplatesResponse) Reset() {
+	*x = ListTemplatesResponse{}
+	mi := &file_todo_transport_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesResponse) ProtoMessage() {}
+
+func (x *ListTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListTemplatesResponse) GetTemplates() []*Template {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+type GetTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // The id, id prefix or name of a template
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTemplateRequest) Reset() {
+	*x = GetTemplateRequest{}
+	mi := &file_todo_transport_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTemplateRequest) ProtoMessage() {}
+
+func (x *GetTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTemplateRequest.ProtoReflect.Descriptor instead.
+func (*GetTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetTemplateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *Template              `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTemplateResponse) Reset() {
+	*x = GetTemplateResponse{}
+	mi := &file_todo_transport_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTemplateResponse) ProtoMessage() {}
+
+func (x *GetTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTemplateResponse.ProtoReflect.Descriptor instead.
+func (*GetTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetTemplateResponse) GetTemplate() *Template {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type CreateTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Tasks         []*TemplateTask        `protobuf:"bytes,3,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTemplateRequest) Reset() {
+	*x = CreateTemplateRequest{}
+	mi := &file_todo_transport_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTemplateRequest) ProtoMessage() {}
+
+func (x *CreateTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *CreateTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTemplateRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTemplateRequest) GetTasks() []*TemplateTask {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type CreateTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTemplateResponse) Reset() {
+	*x = CreateTemplateResponse{}
+	mi := &file_todo_transport_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTemplateResponse) ProtoMessage() {}
+
+func (x *CreateTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTemplateResponse.ProtoReflect.Descriptor instead.
+func (*CreateTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *CreateTemplateResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTemplateRequest) Reset() {
+	*x = DeleteTemplateRequest{}
+	mi := &file_todo_transport_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DeleteTemplateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTemplateResponse) Reset() {
+	*x = DeleteTemplateResponse{}
+	mi := &file_todo_transport_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTemplateResponse) ProtoMessage() {}
+
+func (x *DeleteTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DeleteTemplateResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ApplyTemplateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // The id, id prefix or name of a template
+	// Link the tasks at the top of the template as children of this task.
+	Parent string `protobuf:"bytes,2,opt,name=parent,proto3" json:"parent,omitempty"`
+	// Values for variables used in the template. These override the variables that are always available, like
+	// {{date}}.
+	Variables     map[string]string `protobuf:"bytes,3,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyTemplateRequest) Reset() {
+	*x = ApplyTemplateRequest{}
+	mi := &file_todo_transport_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyTemplateRequest) ProtoMessage() {}
+
+func (x *ApplyTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyTemplateRequest.ProtoReflect.Descriptor instead.
+func (*ApplyTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ApplyTemplateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApplyTemplateRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *ApplyTemplateRequest) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+type ApplyTemplateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ids of every task created; the tasks at the top of the template come first.
+	Ids           []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	RootIds       []string `protobuf:"bytes,2,rep,name=root_ids,json=rootIds,proto3" json:"root_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyTemplateResponse) Reset() {
+	*x = ApplyTemplateResponse{}
+	mi := &file_todo_transport_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyTemplateResponse) ProtoMessage() {}
+
+func (x *ApplyTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyTemplateResponse.ProtoReflect.Descriptor instead.
+func (*ApplyTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ApplyTemplateResponse) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *ApplyTemplateResponse) GetRootIds() []string {
+	if x != nil {
+		return x.RootIds
+	}
+	return nil
+}
+
+type ListArchivedTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offset        int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArchivedTasksRequest) Reset() {
+	*x = ListArchivedTasksRequest{}
+	mi := &file_todo_transport_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArchivedTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArchivedTasksRequest) ProtoMessage() {}
+
+func (x *ListArchivedTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArchivedTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListArchivedTasksRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListArchivedTasksRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListArchivedTasksRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListArchivedTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArchivedTasksResponse) Reset() {
+	*x = ListArchivedTasksResponse{}
+	mi := &file_todo_transport_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArchivedTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArchivedTasksResponse) ProtoMessage() {}
+
+func (x *ListArchivedTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArchivedTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListArchivedTasksResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ListArchivedTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type RestoreArchivedTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreArchivedTaskRequest) Reset() {
+	*x = RestoreArchivedTaskRequest{}
+	mi := &file_todo_transport_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreArchivedTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreArchivedTaskRequest) ProtoMessage() {}
+
+func (x *RestoreArchivedTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreArchivedTaskRequest.ProtoReflect.Descriptor instead.
+func (*RestoreArchivedTaskRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *RestoreArchivedTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RestoreArchivedTaskResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The id of every task restored, starting with the top of the tree.
+	RestoredIds   []string `protobuf:"bytes,1,rep,name=restored_ids,json=restoredIds,proto3" json:"restored_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreArchivedTaskResponse) Reset() {
+	*x = RestoreArchivedTaskResponse{}
+	mi := &file_todo_transport_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreArchivedTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreArchivedTaskResponse) ProtoMessage() {}
+
+func (x *RestoreArchivedTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreArchivedTaskResponse.ProtoReflect.Descriptor instead.
+func (*RestoreArchivedTaskResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *RestoreArchivedTaskResponse) GetRestoredIds() []string {
+	if x != nil {
+		return x.RestoredIds
+	}
+	return nil
+}
+
+type AddTaskCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Author        string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTaskCommentRequest) Reset() {
+	*x = AddTaskCommentRequest{}
+	mi := &file_todo_transport_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTaskCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTaskCommentRequest) ProtoMessage() {}
+
+func (x *AddTaskCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTaskCommentRequest.ProtoReflect.Descriptor instead.
+func (*AddTaskCommentRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *AddTaskCommentRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *AddTaskCommentRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *AddTaskCommentRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+type AddTaskCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comment       *TaskComment           `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTaskCommentResponse) Reset() {
+	*x = AddTaskCommentResponse{}
+	mi := &file_todo_transport_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTaskCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTaskCommentResponse) ProtoMessage() {}
+
+func (x *AddTaskCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTaskCommentResponse.ProtoReflect.Descriptor instead.
+func (*AddTaskCommentResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *AddTaskCommentResponse) GetComment() *TaskComment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+type ListTaskCommentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskCommentsRequest) Reset() {
+	*x = ListTaskCommentsRequest{}
+	mi := &file_todo_transport_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListScheduledTasksRequest) String() string {
+func (x *ListTaskCommentsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListScheduledTasksRequest) ProtoMessage() {}
+func (*ListTaskCommentsRequest) ProtoMessage() {}
 
-func (x *ListScheduledTasksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[14]
+func (x *ListTaskCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -789,47 +3752,54 @@ func (x *ListScheduledTasksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListScheduledTasksRequest.ProtoReflect.Descriptor instead.
-func (*ListScheduledTasksRequest) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ListTaskCommentsRequest.ProtoReflect.Descriptor instead.
+func (*ListTaskCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *ListScheduledTasksRequest) GetOffset() int64 {
+func (x *ListTaskCommentsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ListTaskCommentsRequest) GetOffset() int64 {
 	if x != nil {
 		return x.Offset
 	}
 	return 0
 }
 
-func (x *ListScheduledTasksRequest) GetLimit() int64 {
+func (x *ListTaskCommentsRequest) GetLimit() int64 {
 	if x != nil {
 		return x.Limit
 	}
 	return 0
 }
 
-type ListScheduledTasksResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	ScheduledTasks []*ScheduledTask       `protobuf:"bytes,1,rep,name=scheduled_tasks,json=scheduledTasks,proto3" json:"scheduled_tasks,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type ListTaskCommentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comments      []*TaskComment         `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListScheduledTasksResponse) Reset() {
-	*x = ListScheduledTasksResponse{}
-	mi := &file_todo_transport_proto_msgTypes[15]
+func (x *ListTaskCommentsResponse) Reset() {
+	*x = ListTaskCommentsResponse{}
+	mi := &file_todo_transport_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListScheduledTasksResponse) String() string {
+func (x *ListTaskCommentsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListScheduledTasksResponse) ProtoMessage() {}
+func (*ListTaskCommentsResponse) ProtoMessage() {}
 
-func (x *ListScheduledTasksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[15]
+func (x *ListTaskCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -840,43 +3810,44 @@ func (x *ListScheduledTasksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListScheduledTasksResponse.ProtoReflect.Descriptor instead.
-func (*ListScheduledTasksResponse) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ListTaskCommentsResponse.ProtoReflect.Descriptor instead.
+func (*ListTaskCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *ListScheduledTasksResponse) GetScheduledTasks() []*ScheduledTask {
+func (x *ListTaskCommentsResponse) GetComments() []*TaskComment {
 	if x != nil {
-		return x.ScheduledTasks
+		return x.Comments
 	}
 	return nil
 }
 
-type CreateScheduledTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Parent        string                 `protobuf:"bytes,3,opt,name=parent,proto3" json:"parent,omitempty"`
-	Expression    string                 `protobuf:"bytes,4,opt,name=expression,proto3" json:"expression,omitempty"`
+type UploadAttachmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*UploadAttachmentRequest_Details_
+	//	*UploadAttachmentRequest_Chunk
+	Data          isUploadAttachmentRequest_Data `protobuf_oneof:"data"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateScheduledTaskRequest) Reset() {
-	*x = CreateScheduledTaskRequest{}
-	mi := &file_todo_transport_proto_msgTypes[16]
+func (x *UploadAttachmentRequest) Reset() {
+	*x = UploadAttachmentRequest{}
+	mi := &file_todo_transport_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateScheduledTaskRequest) String() string {
+func (x *UploadAttachmentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateScheduledTaskRequest) ProtoMessage() {}
+func (*UploadAttachmentRequest) ProtoMessage() {}
 
-func (x *CreateScheduledTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[16]
+func (x *UploadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -887,61 +3858,74 @@ func (x *CreateScheduledTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateScheduledTaskRequest.ProtoReflect.Descriptor instead.
-func (*CreateScheduledTaskRequest) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use UploadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *CreateScheduledTaskRequest) GetTitle() string {
+func (x *UploadAttachmentRequest) GetData() isUploadAttachmentRequest_Data {
 	if x != nil {
-		return x.Title
+		return x.Data
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateScheduledTaskRequest) GetDescription() string {
+func (x *UploadAttachmentRequest) GetDetails() *UploadAttachmentRequest_Details {
 	if x != nil {
-		return x.Description
+		if x, ok := x.Data.(*UploadAttachmentRequest_Details_); ok {
+			return x.Details
+		}
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateScheduledTaskRequest) GetParent() string {
+func (x *UploadAttachmentRequest) GetChunk() []byte {
 	if x != nil {
-		return x.Parent
+		if x, ok := x.Data.(*UploadAttachmentRequest_Chunk); ok {
+			return x.Chunk
+		}
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateScheduledTaskRequest) GetExpression() string {
-	if x != nil {
-		return x.Expression
-	}
-	return ""
+type isUploadAttachmentRequest_Data interface {
+	isUploadAttachmentRequest_Data()
 }
 
-type CreateScheduledTaskResponse struct {
+type UploadAttachmentRequest_Details_ struct {
+	Details *UploadAttachmentRequest_Details `protobuf:"bytes,1,opt,name=details,proto3,oneof"`
+}
+
+type UploadAttachmentRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadAttachmentRequest_Details_) isUploadAttachmentRequest_Data() {}
+
+func (*UploadAttachmentRequest_Chunk) isUploadAttachmentRequest_Data() {}
+
+type UploadAttachmentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Attachment    *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateScheduledTaskResponse) Reset() {
-	*x = CreateScheduledTaskResponse{}
-	mi := &file_todo_transport_proto_msgTypes[17]
+func (x *UploadAttachmentResponse) Reset() {
+	*x = UploadAttachmentResponse{}
+	mi := &file_todo_transport_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateScheduledTaskResponse) String() string {
+func (x *UploadAttachmentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateScheduledTaskResponse) ProtoMessage() {}
+func (*UploadAttachmentResponse) ProtoMessage() {}
 
-func (x *CreateScheduledTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[17]
+func (x *UploadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -952,44 +3936,40 @@ func (x *CreateScheduledTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateScheduledTaskResponse.ProtoReflect.Descriptor instead.
-func (*CreateScheduledTaskResponse) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use UploadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *CreateScheduledTaskResponse) GetId() string {
+func (x *UploadAttachmentResponse) GetAttachment() *Attachment {
 	if x != nil {
-		return x.Id
+		return x.Attachment
 	}
-	return ""
+	return nil
 }
 
-type UpdateScheduledTaskRequest struct {
+type ListAttachmentsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Parent        string                 `protobuf:"bytes,4,opt,name=parent,proto3" json:"parent,omitempty"`
-	Expression    string                 `protobuf:"bytes,5,opt,name=expression,proto3" json:"expression,omitempty"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateScheduledTaskRequest) Reset() {
-	*x = UpdateScheduledTaskRequest{}
-	mi := &file_todo_transport_proto_msgTypes[18]
+func (x *ListAttachmentsRequest) Reset() {
+	*x = ListAttachmentsRequest{}
+	mi := &file_todo_transport_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateScheduledTaskRequest) String() string {
+func (x *ListAttachmentsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateScheduledTaskRequest) ProtoMessage() {}
+func (*ListAttachmentsRequest) ProtoMessage() {}
 
-func (x *UpdateScheduledTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[18]
+func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1000,67 +3980,84 @@ func (x *UpdateScheduledTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateScheduledTaskRequest.ProtoReflect.Descriptor instead.
-func (*UpdateScheduledTaskRequest) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ListAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *UpdateScheduledTaskRequest) GetId() string {
+func (x *ListAttachmentsRequest) GetTaskId() string {
 	if x != nil {
-		return x.Id
+		return x.TaskId
 	}
 	return ""
 }
 
-func (x *UpdateScheduledTaskRequest) GetTitle() string {
-	if x != nil {
-		return x.Title
-	}
-	return ""
+type ListAttachmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachments   []*Attachment          `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateScheduledTaskRequest) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
+func (x *ListAttachmentsResponse) Reset() {
+	*x = ListAttachmentsResponse{}
+	mi := &file_todo_transport_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateScheduledTaskRequest) GetParent() string {
+func (x *ListAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsResponse) ProtoMessage() {}
+
+func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[68]
 	if x != nil {
-		return x.Parent
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateScheduledTaskRequest) GetExpression() string {
+// Deprecated: Use ListAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ListAttachmentsResponse) GetAttachments() []*Attachment {
 	if x != nil {
-		return x.Expression
+		return x.Attachments
 	}
-	return ""
+	return nil
 }
 
-type UpdateScheduledTaskResponse struct {
+type DownloadAttachmentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateScheduledTaskResponse) Reset() {
-	*x = UpdateScheduledTaskResponse{}
-	mi := &file_todo_transport_proto_msgTypes[19]
+func (x *DownloadAttachmentRequest) Reset() {
+	*x = DownloadAttachmentRequest{}
+	mi := &file_todo_transport_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateScheduledTaskResponse) String() string {
+func (x *DownloadAttachmentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateScheduledTaskResponse) ProtoMessage() {}
+func (*DownloadAttachmentRequest) ProtoMessage() {}
 
-func (x *UpdateScheduledTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[19]
+func (x *DownloadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1071,33 +4068,44 @@ func (x *UpdateScheduledTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateScheduledTaskResponse.ProtoReflect.Descriptor instead.
-func (*UpdateScheduledTaskResponse) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use DownloadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{69}
 }
 
-type DeleteScheduledTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (x *DownloadAttachmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DownloadAttachmentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*DownloadAttachmentResponse_Attachment
+	//	*DownloadAttachmentResponse_Chunk
+	Data          isDownloadAttachmentResponse_Data `protobuf_oneof:"data"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteScheduledTaskRequest) Reset() {
-	*x = DeleteScheduledTaskRequest{}
-	mi := &file_todo_transport_proto_msgTypes[20]
+func (x *DownloadAttachmentResponse) Reset() {
+	*x = DownloadAttachmentResponse{}
+	mi := &file_todo_transport_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteScheduledTaskRequest) String() string {
+func (x *DownloadAttachmentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteScheduledTaskRequest) ProtoMessage() {}
+func (*DownloadAttachmentResponse) ProtoMessage() {}
 
-func (x *DeleteScheduledTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[20]
+func (x *DownloadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1108,40 +4116,76 @@ func (x *DeleteScheduledTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteScheduledTaskRequest.ProtoReflect.Descriptor instead.
-func (*DeleteScheduledTaskRequest) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use DownloadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *DeleteScheduledTaskRequest) GetId() string {
+func (x *DownloadAttachmentResponse) GetData() isDownloadAttachmentResponse_Data {
 	if x != nil {
-		return x.Id
+		return x.Data
 	}
-	return ""
+	return nil
 }
 
-type DeleteScheduledTaskResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (x *DownloadAttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadAttachmentResponse_Attachment); ok {
+			return x.Attachment
+		}
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadAttachmentResponse_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isDownloadAttachmentResponse_Data interface {
+	isDownloadAttachmentResponse_Data()
+}
+
+type DownloadAttachmentResponse_Attachment struct {
+	Attachment *Attachment `protobuf:"bytes,1,opt,name=attachment,proto3,oneof"`
+}
+
+type DownloadAttachmentResponse_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*DownloadAttachmentResponse_Attachment) isDownloadAttachmentResponse_Data() {}
+
+func (*DownloadAttachmentResponse_Chunk) isDownloadAttachmentResponse_Data() {}
+
+type UploadAttachmentRequest_Details struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// The name of the file, without any directories.
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteScheduledTaskResponse) Reset() {
-	*x = DeleteScheduledTaskResponse{}
-	mi := &file_todo_transport_proto_msgTypes[21]
+func (x *UploadAttachmentRequest_Details) Reset() {
+	*x = UploadAttachmentRequest_Details{}
+	mi := &file_todo_transport_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteScheduledTaskResponse) String() string {
+func (x *UploadAttachmentRequest_Details) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteScheduledTaskResponse) ProtoMessage() {}
+func (*UploadAttachmentRequest_Details) ProtoMessage() {}
 
-func (x *DeleteScheduledTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_todo_transport_proto_msgTypes[21]
+func (x *UploadAttachmentRequest_Details) ProtoReflect() protoreflect.Message {
+	mi := &file_todo_transport_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1152,14 +4196,21 @@ func (x *DeleteScheduledTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteScheduledTaskResponse.ProtoReflect.Descriptor instead.
-func (*DeleteScheduledTaskResponse) Descriptor() ([]byte, []int) {
-	return file_todo_transport_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use UploadAttachmentRequest_Details.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest_Details) Descriptor() ([]byte, []int) {
+	return file_todo_transport_proto_rawDescGZIP(), []int{65, 0}
 }
 
-func (x *DeleteScheduledTaskResponse) GetId() string {
+func (x *UploadAttachmentRequest_Details) GetTaskId() string {
 	if x != nil {
-		return x.Id
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *UploadAttachmentRequest_Details) GetName() string {
+	if x != nil {
+		return x.Name
 	}
 	return ""
 }
@@ -1169,60 +4220,171 @@ var File_todo_transport_proto protoreflect.FileDescriptor
 const file_todo_transport_proto_rawDesc = "" +
 	"\n" +
 	"\x14todo_transport.proto\x12\x05proto\x1a\x12todo_message.proto\"\x16\n" +
-	"\x14GetSystemInfoRequest\"q\n" +
+	"\x14GetSystemInfoRequest\"\xe8\x03\n" +
 	"\x15GetSystemInfoResponse\x12\x16\n" +
 	"\x06commit\x18\x01 \x01(\tR\x06commit\x12(\n" +
 	"\x10dev_mode_enabled\x18\x02 \x01(\bR\x0edevModeEnabled\x12\x16\n" +
-	"\x06semver\x18\x03 \x01(\tR\x06semver\" \n" +
+	"\x06semver\x18\x03 \x01(\tR\x06semver\x12%\n" +
+	"\x0eschema_version\x18\x04 \x01(\tR\rschemaVersion\x12%\n" +
+	"\x0euptime_seconds\x18\x05 \x01(\x03R\ruptimeSeconds\x12%\n" +
+	"\x0estorage_driver\x18\x06 \x01(\tR\rstorageDriver\x12!\n" +
+	"\fstorage_path\x18\a \x01(\tR\vstoragePath\x12,\n" +
+	"\x12storage_size_bytes\x18\b \x01(\x03R\x10storageSizeBytes\x12)\n" +
+	"\x10unresolved_tasks\x18\t \x01(\x03R\x0funresolvedTasks\x12'\n" +
+	"\x0fcompleted_tasks\x18\n" +
+	" \x01(\x03R\x0ecompletedTasks\x12'\n" +
+	"\x0fscheduled_tasks\x18\v \x01(\x03R\x0escheduledTasks\x122\n" +
+	"\tscheduler\x18\f \x01(\v2\x14.proto.SchedulerInfoR\tscheduler\"]\n" +
+	"\rSchedulerInfo\x12\x1a\n" +
+	"\brestored\x18\x01 \x01(\bR\brestored\x12\x18\n" +
+	"\arunning\x18\x02 \x01(\x03R\arunning\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x03R\x06failed\" \n" +
 	"\x0eGetTaskRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
 	"\x0fGetTaskResponse\x12\x1f\n" +
-	"\x04task\x18\x01 \x01(\v2\v.proto.TaskR\x04task\"m\n" +
+	"\x04task\x18\x01 \x01(\v2\v.proto.TaskR\x04task\"\x98\x01\n" +
 	"\x10ListTasksRequest\x12\x16\n" +
 	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12+\n" +
-	"\x11exclude_completed\x18\x03 \x01(\bR\x10excludeCompleted\"6\n" +
+	"\x11exclude_completed\x18\x03 \x01(\bR\x10excludeCompleted\x12)\n" +
+	"\x10include_archived\x18\x04 \x01(\bR\x0fincludeArchived\"6\n" +
 	"\x11ListTasksResponse\x12!\n" +
-	"\x05tasks\x18\x01 \x03(\v2\v.proto.TaskR\x05tasks\"c\n" +
+	"\x05tasks\x18\x01 \x03(\v2\v.proto.TaskR\x05tasks\"\x82\x01\n" +
 	"\x11CreateTaskRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
-	"\x06parent\x18\x03 \x01(\tR\x06parent\"$\n" +
+	"\x06parent\x18\x03 \x01(\tR\x06parent\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tR\trequestId\"$\n" +
 	"\x12CreateTaskResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xd9\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xa3\x03\n" +
 	"\x11UpdateTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
-	"\x06parent\x18\x04 \x01(\tR\x06parent\x128\n" +
-	"\x05state\x18\x05 \x01(\x0e2\".proto.UpdateTaskRequest.TaskStateR\x05state\"*\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x1b\n" +
+	"\x06parent\x18\x04 \x01(\tH\x02R\x06parent\x88\x01\x01\x12=\n" +
+	"\x05state\x18\x05 \x01(\x0e2\".proto.UpdateTaskRequest.TaskStateH\x03R\x05state\x88\x01\x01\x12.\n" +
+	"\x10expected_version\x18\x06 \x01(\x03H\x04R\x0fexpectedVersion\x88\x01\x01\x12,\n" +
+	"\x0fcompletion_note\x18\a \x01(\tH\x05R\x0ecompletionNote\x88\x01\x01\"*\n" +
 	"\tTaskState\x12\x0e\n" +
 	"\n" +
 	"UNRESOLVED\x10\x00\x12\r\n" +
-	"\tCOMPLETED\x10\x01\"\x14\n" +
-	"\x12UpdateTaskResponse\"#\n" +
+	"\tCOMPLETED\x10\x01B\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\t\n" +
+	"\a_parentB\b\n" +
+	"\x06_stateB\x13\n" +
+	"\x11_expected_versionB\x12\n" +
+	"\x10_completion_note\"5\n" +
+	"\x12UpdateTaskResponse\x12\x1f\n" +
+	"\x04task\x18\x01 \x01(\v2\v.proto.TaskR\x04task\"#\n" +
 	"\x11DeleteTaskRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"&\n" +
 	"\x12DeleteTaskResponse\x12\x10\n" +
-	"\x03ids\x18\x01 \x03(\tR\x03ids\")\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"z\n" +
+	"\n" +
+	"TaskFilter\x12+\n" +
+	"\x05state\x18\x01 \x01(\x0e2\x15.proto.Task.TaskStateR\x05state\x12\x18\n" +
+	"\asubtree\x18\x02 \x01(\tR\asubtree\x12%\n" +
+	"\x0ecreated_before\x18\x03 \x01(\x03R\rcreatedBefore\"Z\n" +
+	"\x0fBatchTaskResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12!\n" +
+	"\faffected_ids\x18\x03 \x03(\tR\vaffectedIds\"\xfe\x02\n" +
+	"\x17BatchUpdateTasksRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12)\n" +
+	"\x06filter\x18\x02 \x01(\v2\x11.proto.TaskFilterR\x06filter\x12\x19\n" +
+	"\x05title\x18\x03 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x1b\n" +
+	"\x06parent\x18\x05 \x01(\tH\x02R\x06parent\x88\x01\x01\x12=\n" +
+	"\x05state\x18\x06 \x01(\x0e2\".proto.UpdateTaskRequest.TaskStateH\x03R\x05state\x88\x01\x01\x12,\n" +
+	"\x0fcompletion_note\x18\b \x01(\tH\x04R\x0ecompletionNote\x88\x01\x01\x12\x17\n" +
+	"\adry_run\x18\a \x01(\bR\x06dryRunB\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\t\n" +
+	"\a_parentB\b\n" +
+	"\x06_stateB\x12\n" +
+	"\x10_completion_note\"L\n" +
+	"\x18BatchUpdateTasksResponse\x120\n" +
+	"\aresults\x18\x01 \x03(\v2\x16.proto.BatchTaskResultR\aresults\"o\n" +
+	"\x17BatchDeleteTasksRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12)\n" +
+	"\x06filter\x18\x02 \x01(\v2\x11.proto.TaskFilterR\x06filter\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\"L\n" +
+	"\x18BatchDeleteTasksResponse\x120\n" +
+	"\aresults\x18\x01 \x03(\v2\x16.proto.BatchTaskResultR\aresults\"E\n" +
+	"\x15ListOperationsRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"J\n" +
+	"\x16ListOperationsResponse\x120\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\v2\x10.proto.OperationR\n" +
+	"operations\"<\n" +
+	"\x14UndoOperationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\"j\n" +
+	"\x15UndoOperationResponse\x12.\n" +
+	"\toperation\x18\x01 \x01(\v2\x10.proto.OperationR\toperation\x12!\n" +
+	"\frestored_ids\x18\x02 \x03(\tR\vrestoredIds\"[\n" +
+	"\x13GetTaskStatsRequest\x12\x12\n" +
+	"\x04days\x18\x01 \x01(\x03R\x04days\x12\x14\n" +
+	"\x05weeks\x18\x02 \x01(\x03R\x05weeks\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\"\x82\x03\n" +
+	"\x14GetTaskStatsResponse\x12\x12\n" +
+	"\x04open\x18\x01 \x01(\x03R\x04open\x12\x1c\n" +
+	"\tcompleted\x18\x02 \x01(\x03R\tcompleted\x12>\n" +
+	"\x11completed_per_day\x18\x03 \x03(\v2\x12.proto.PeriodCountR\x0fcompletedPerDay\x12@\n" +
+	"\x12completed_per_week\x18\x04 \x03(\v2\x12.proto.PeriodCountR\x10completedPerWeek\x127\n" +
+	"\x18average_time_to_complete\x18\x05 \x01(\x03R\x15averageTimeToComplete\x120\n" +
+	"\vopen_by_age\x18\x06 \x03(\v2\x10.proto.AgeBucketR\topenByAge\x12/\n" +
+	"\bprogress\x18\a \x03(\v2\x13.proto.TaskProgressR\bprogress\x12\x1a\n" +
+	"\btimezone\x18\b \x01(\tR\btimezone\"9\n" +
+	"\vPeriodCount\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x03R\x05start\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"7\n" +
+	"\tAgeBucket\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"h\n" +
+	"\fTaskProgress\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1c\n" +
+	"\tcompleted\x18\x03 \x01(\x03R\tcompleted\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\"Q\n" +
 	"\x17GetScheduledTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"W\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x0fnext_fire_count\x18\x02 \x01(\x03R\rnextFireCount\"\x88\x01\n" +
 	"\x18GetScheduledTaskResponse\x12;\n" +
-	"\x0escheduled_task\x18\x01 \x01(\v2\x14.proto.ScheduledTaskR\rscheduledTask\"I\n" +
+	"\x0escheduled_task\x18\x01 \x01(\v2\x14.proto.ScheduledTaskR\rscheduledTask\x12/\n" +
+	"\n" +
+	"fire_times\x18\x02 \x01(\v2\x10.proto.FireTimesR\tfireTimes\"q\n" +
 	"\x19ListScheduledTasksRequest\x12\x16\n" +
 	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x03R\x05limit\"[\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12&\n" +
+	"\x0fnext_fire_count\x18\x03 \x01(\x03R\rnextFireCount\"\xfc\x01\n" +
 	"\x1aListScheduledTasksResponse\x12=\n" +
-	"\x0fscheduled_tasks\x18\x01 \x03(\v2\x14.proto.ScheduledTaskR\x0escheduledTasks\"\x8c\x01\n" +
+	"\x0fscheduled_tasks\x18\x01 \x03(\v2\x14.proto.ScheduledTaskR\x0escheduledTasks\x12O\n" +
+	"\n" +
+	"fire_times\x18\x02 \x03(\v20.proto.ListScheduledTasksResponse.FireTimesEntryR\tfireTimes\x1aN\n" +
+	"\x0eFireTimesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12&\n" +
+	"\x05value\x18\x02 \x01(\v2\x10.proto.FireTimesR\x05value:\x028\x01\"\xf5\x02\n" +
 	"\x1aCreateScheduledTaskRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
 	"\x06parent\x18\x03 \x01(\tR\x06parent\x12\x1e\n" +
 	"\n" +
 	"expression\x18\x04 \x01(\tR\n" +
-	"expression\"-\n" +
+	"expression\x12L\n" +
+	"\x0fexpression_type\x18\x05 \x01(\x0e2#.proto.ScheduledTask.ExpressionTypeR\x0eexpressionType\x12?\n" +
+	"\n" +
+	"recurrence\x18\x06 \x01(\x0e2\x1f.proto.ScheduledTask.RecurrenceR\n" +
+	"recurrence\x12 \n" +
+	"\fskip_if_open\x18\a \x01(\bR\n" +
+	"skipIfOpen\x12\x1a\n" +
+	"\btimezone\x18\b \x01(\tR\btimezone\x12\x1a\n" +
+	"\btemplate\x18\t \x01(\tR\btemplate\"-\n" +
 	"\x1bCreateScheduledTaskResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x9c\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x85\x03\n" +
 	"\x1aUpdateScheduledTaskRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -1230,12 +4392,134 @@ const file_todo_transport_proto_rawDesc = "" +
 	"\x06parent\x18\x04 \x01(\tR\x06parent\x12\x1e\n" +
 	"\n" +
 	"expression\x18\x05 \x01(\tR\n" +
-	"expression\"\x1d\n" +
+	"expression\x12L\n" +
+	"\x0fexpression_type\x18\x06 \x01(\x0e2#.proto.ScheduledTask.ExpressionTypeR\x0eexpressionType\x12?\n" +
+	"\n" +
+	"recurrence\x18\a \x01(\x0e2\x1f.proto.ScheduledTask.RecurrenceR\n" +
+	"recurrence\x12 \n" +
+	"\fskip_if_open\x18\b \x01(\bR\n" +
+	"skipIfOpen\x12\x1a\n" +
+	"\btimezone\x18\t \x01(\tR\btimezone\x12\x1a\n" +
+	"\btemplate\x18\n" +
+	" \x01(\tR\btemplate\"\x1d\n" +
 	"\x1bUpdateScheduledTaskResponse\",\n" +
 	"\x1aDeleteScheduledTaskRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"-\n" +
 	"\x1bDeleteScheduledTaskResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02idB%Z#github.com/clintjedwards/todo/protob\x06proto3"
+	"\x02id\x18\x01 \x01(\tR\x02id\"+\n" +
+	"\x19PauseScheduledTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\",\n" +
+	"\x1aPauseScheduledTaskResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\",\n" +
+	"\x1aResumeScheduledTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"-\n" +
+	"\x1bResumeScheduledTaskResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"F\n" +
+	"\x1eGetScheduledTaskHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"\xbf\x01\n" +
+	"\x1fGetScheduledTaskHistoryResponse\x12;\n" +
+	"\x0escheduled_task\x18\x01 \x01(\v2\x14.proto.ScheduledTaskR\rscheduledTask\x12!\n" +
+	"\x05tasks\x18\x02 \x03(\v2\v.proto.TaskR\x05tasks\x12<\n" +
+	"\asummary\x18\x03 \x01(\v2\".proto.ScheduledTaskHistorySummaryR\asummary\"\xc8\x01\n" +
+	"\x1bScheduledTaskHistorySummary\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12\x1c\n" +
+	"\tcompleted\x18\x02 \x01(\x03R\tcompleted\x12'\n" +
+	"\x0fcompletion_rate\x18\x03 \x01(\x01R\x0ecompletionRate\x12%\n" +
+	"\x0ecurrent_streak\x18\x04 \x01(\x03R\rcurrentStreak\x12%\n" +
+	"\x0elongest_streak\x18\x05 \x01(\x03R\rlongestStreak\"\x8b\x02\n" +
+	"\x16PreviewScheduleRequest\x12\x1e\n" +
+	"\n" +
+	"expression\x18\x01 \x01(\tR\n" +
+	"expression\x12&\n" +
+	"\x0fnext_fire_count\x18\x02 \x01(\x03R\rnextFireCount\x12L\n" +
+	"\x0fexpression_type\x18\x03 \x01(\x0e2#.proto.ScheduledTask.ExpressionTypeR\x0eexpressionType\x12?\n" +
+	"\n" +
+	"recurrence\x18\x04 \x01(\x0e2\x1f.proto.ScheduledTask.RecurrenceR\n" +
+	"recurrence\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\"\x8b\x02\n" +
+	"\x17PreviewScheduleResponse\x12/\n" +
+	"\n" +
+	"fire_times\x18\x01 \x01(\v2\x10.proto.FireTimesR\tfireTimes\x12L\n" +
+	"\x0fexpression_type\x18\x02 \x01(\x0e2#.proto.ScheduledTask.ExpressionTypeR\x0eexpressionType\x12?\n" +
+	"\n" +
+	"recurrence\x18\x03 \x01(\x0e2\x1f.proto.ScheduledTask.RecurrenceR\n" +
+	"recurrence\x12\x14\n" +
+	"\x05delay\x18\x04 \x01(\tR\x05delay\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\"D\n" +
+	"\x14ListTemplatesRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"F\n" +
+	"\x15ListTemplatesResponse\x12-\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x0f.proto.TemplateR\ttemplates\"$\n" +
+	"\x12GetTemplateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"B\n" +
+	"\x13GetTemplateResponse\x12+\n" +
+	"\btemplate\x18\x01 \x01(\v2\x0f.proto.TemplateR\btemplate\"x\n" +
+	"\x15CreateTemplateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12)\n" +
+	"\x05tasks\x18\x03 \x03(\v2\x13.proto.TemplateTaskR\x05tasks\"(\n" +
+	"\x16CreateTemplateResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"'\n" +
+	"\x15DeleteTemplateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"(\n" +
+	"\x16DeleteTemplateResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xc6\x01\n" +
+	"\x14ApplyTemplateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06parent\x18\x02 \x01(\tR\x06parent\x12H\n" +
+	"\tvariables\x18\x03 \x03(\v2*.proto.ApplyTemplateRequest.VariablesEntryR\tvariables\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"D\n" +
+	"\x15ApplyTemplateResponse\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x19\n" +
+	"\broot_ids\x18\x02 \x03(\tR\arootIds\"H\n" +
+	"\x18ListArchivedTasksRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\">\n" +
+	"\x19ListArchivedTasksResponse\x12!\n" +
+	"\x05tasks\x18\x01 \x03(\v2\v.proto.TaskR\x05tasks\",\n" +
+	"\x1aRestoreArchivedTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"@\n" +
+	"\x1bRestoreArchivedTaskResponse\x12!\n" +
+	"\frestored_ids\x18\x01 \x03(\tR\vrestoredIds\"\\\n" +
+	"\x15AddTaskCommentRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\tR\x06author\"F\n" +
+	"\x16AddTaskCommentResponse\x12,\n" +
+	"\acomment\x18\x01 \x01(\v2\x12.proto.TaskCommentR\acomment\"`\n" +
+	"\x17ListTaskCommentsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\"J\n" +
+	"\x18ListTaskCommentsResponse\x12.\n" +
+	"\bcomments\x18\x01 \x03(\v2\x12.proto.TaskCommentR\bcomments\"\xb5\x01\n" +
+	"\x17UploadAttachmentRequest\x12B\n" +
+	"\adetails\x18\x01 \x01(\v2&.proto.UploadAttachmentRequest.DetailsH\x00R\adetails\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunk\x1a6\n" +
+	"\aDetails\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04nameB\x06\n" +
+	"\x04data\"M\n" +
+	"\x18UploadAttachmentResponse\x121\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x11.proto.AttachmentR\n" +
+	"attachment\"1\n" +
+	"\x16ListAttachmentsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"N\n" +
+	"\x17ListAttachmentsResponse\x123\n" +
+	"\vattachments\x18\x01 \x03(\v2\x11.proto.AttachmentR\vattachments\"+\n" +
+	"\x19DownloadAttachmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"q\n" +
+	"\x1aDownloadAttachmentResponse\x123\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x11.proto.AttachmentH\x00R\n" +
+	"attachment\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04dataB%Z#github.com/clintjedwards/todo/protob\x06proto3"
 
 var (
 	file_todo_transport_proto_rawDescOnce sync.Once
@@ -1250,45 +4534,146 @@ func file_todo_transport_proto_rawDescGZIP() []byte {
 }
 
 var file_todo_transport_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_todo_transport_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_todo_transport_proto_msgTypes = make([]protoimpl.MessageInfo, 74)
 var file_todo_transport_proto_goTypes = []any{
-	(UpdateTaskRequest_TaskState)(0),    // 0: proto.UpdateTaskRequest.TaskState
-	(*GetSystemInfoRequest)(nil),        // 1: proto.GetSystemInfoRequest
-	(*GetSystemInfoResponse)(nil),       // 2: proto.GetSystemInfoResponse
-	(*GetTaskRequest)(nil),              // 3: proto.GetTaskRequest
-	(*GetTaskResponse)(nil),             // 4: proto.GetTaskResponse
-	(*ListTasksRequest)(nil),            // 5: proto.ListTasksRequest
-	(*ListTasksResponse)(nil),           // 6: proto.ListTasksResponse
-	(*CreateTaskRequest)(nil),           // 7: proto.CreateTaskRequest
-	(*CreateTaskResponse)(nil),          // 8: proto.CreateTaskResponse
-	(*UpdateTaskRequest)(nil),           // 9: proto.UpdateTaskRequest
-	(*UpdateTaskResponse)(nil),          // 10: proto.UpdateTaskResponse
-	(*DeleteTaskRequest)(nil),           // 11: proto.DeleteTaskRequest
-	(*DeleteTaskResponse)(nil),          // 12: proto.DeleteTaskResponse
-	(*GetScheduledTaskRequest)(nil),     // 13: proto.GetScheduledTaskRequest
-	(*GetScheduledTaskResponse)(nil),    // 14: proto.GetScheduledTaskResponse
-	(*ListScheduledTasksRequest)(nil),   // 15: proto.ListScheduledTasksRequest
-	(*ListScheduledTasksResponse)(nil),  // 16: proto.ListScheduledTasksResponse
-	(*CreateScheduledTaskRequest)(nil),  // 17: proto.CreateScheduledTaskRequest
-	(*CreateScheduledTaskResponse)(nil), // 18: proto.CreateScheduledTaskResponse
-	(*UpdateScheduledTaskRequest)(nil),  // 19: proto.UpdateScheduledTaskRequest
-	(*UpdateScheduledTaskResponse)(nil), // 20: proto.UpdateScheduledTaskResponse
-	(*DeleteScheduledTaskRequest)(nil),  // 21: proto.DeleteScheduledTaskRequest
-	(*DeleteScheduledTaskResponse)(nil), // 22: proto.DeleteScheduledTaskResponse
-	(*Task)(nil),                        // 23: proto.Task
-	(*ScheduledTask)(nil),               // 24: proto.ScheduledTask
+	(UpdateTaskRequest_TaskState)(0),        // 0: proto.UpdateTaskRequest.TaskState
+	(*GetSystemInfoRequest)(nil),            // 1: proto.GetSystemInfoRequest
+	(*GetSystemInfoResponse)(nil),           // 2: proto.GetSystemInfoResponse
+	(*SchedulerInfo)(nil),                   // 3: proto.SchedulerInfo
+	(*GetTaskRequest)(nil),                  // 4: proto.GetTaskRequest
+	(*GetTaskResponse)(nil),                 // 5: proto.GetTaskResponse
+	(*ListTasksRequest)(nil),                // 6: proto.ListTasksRequest
+	(*ListTasksResponse)(nil),               // 7: proto.ListTasksResponse
+	(*CreateTaskRequest)(nil),               // 8: proto.CreateTaskRequest
+	(*CreateTaskResponse)(nil),              // 9: proto.CreateTaskResponse
+	(*UpdateTaskRequest)(nil),               // 10: proto.UpdateTaskRequest
+	(*UpdateTaskResponse)(nil),              // 11: proto.UpdateTaskResponse
+	(*DeleteTaskRequest)(nil),               // 12: proto.DeleteTaskRequest
+	(*DeleteTaskResponse)(nil),              // 13: proto.DeleteTaskResponse
+	(*TaskFilter)(nil),                      // 14: proto.TaskFilter
+	(*BatchTaskResult)(nil),                 // 15: proto.BatchTaskResult
+	(*BatchUpdateTasksRequest)(nil),         // 16: proto.BatchUpdateTasksRequest
+	(*BatchUpdateTasksResponse)(nil),        // 17: proto.BatchUpdateTasksResponse
+	(*BatchDeleteTasksRequest)(nil),         // 18: proto.BatchDeleteTasksRequest
+	(*BatchDeleteTasksResponse)(nil),        // 19: proto.BatchDeleteTasksResponse
+	(*ListOperationsRequest)(nil),           // 20: proto.ListOperationsRequest
+	(*ListOperationsResponse)(nil),          // 21: proto.ListOperationsResponse
+	(*UndoOperationRequest)(nil),            // 22: proto.UndoOperationRequest
+	(*UndoOperationResponse)(nil),           // 23: proto.UndoOperationResponse
+	(*GetTaskStatsRequest)(nil),             // 24: proto.GetTaskStatsRequest
+	(*GetTaskStatsResponse)(nil),            // 25: proto.GetTaskStatsResponse
+	(*PeriodCount)(nil),                     // 26: proto.PeriodCount
+	(*AgeBucket)(nil),                       // 27: proto.AgeBucket
+	(*TaskProgress)(nil),                    // 28: proto.TaskProgress
+	(*GetScheduledTaskRequest)(nil),         // 29: proto.GetScheduledTaskRequest
+	(*GetScheduledTaskResponse)(nil),        // 30: proto.GetScheduledTaskResponse
+	(*ListScheduledTasksRequest)(nil),       // 31: proto.ListScheduledTasksRequest
+	(*ListScheduledTasksResponse)(nil),      // 32: proto.ListScheduledTasksResponse
+	(*CreateScheduledTaskRequest)(nil),      // 33: proto.CreateScheduledTaskRequest
+	(*CreateScheduledTaskResponse)(nil),     // 34: proto.CreateScheduledTaskResponse
+	(*UpdateScheduledTaskRequest)(nil),      // 35: proto.UpdateScheduledTaskRequest
+	(*UpdateScheduledTaskResponse)(nil),     // 36: proto.UpdateScheduledTaskResponse
+	(*DeleteScheduledTaskRequest)(nil),      // 37: proto.DeleteScheduledTaskRequest
+	(*DeleteScheduledTaskResponse)(nil),     // 38: proto.DeleteScheduledTaskResponse
+	(*PauseScheduledTaskRequest)(nil),       // 39: proto.PauseScheduledTaskRequest
+	(*PauseScheduledTaskResponse)(nil),      // 40: proto.PauseScheduledTaskResponse
+	(*ResumeScheduledTaskRequest)(nil),      // 41: proto.ResumeScheduledTaskRequest
+	(*ResumeScheduledTaskResponse)(nil),     // 42: proto.ResumeScheduledTaskResponse
+	(*GetScheduledTaskHistoryRequest)(nil),  // 43: proto.GetScheduledTaskHistoryRequest
+	(*GetScheduledTaskHistoryResponse)(nil), // 44: proto.GetScheduledTaskHistoryResponse
+	(*ScheduledTaskHistorySummary)(nil),     // 45: proto.ScheduledTaskHistorySummary
+	(*PreviewScheduleRequest)(nil),          // 46: proto.PreviewScheduleRequest
+	(*PreviewScheduleResponse)(nil),         // 47: proto.PreviewScheduleResponse
+	(*ListTemplatesRequest)(nil),            // 48: proto.ListTemplatesRequest
+	(*ListTemplatesResponse)(nil),           // 49: proto.ListTemplatesResponse
+	(*GetTemplateRequest)(nil),              // 50: proto.GetTemplateRequest
+	(*GetTemplateResponse)(nil),             // 51: proto.GetTemplateResponse
+	(*CreateTemplateRequest)(nil),           // 52: proto.CreateTemplateRequest
+	(*CreateTemplateResponse)(nil),          // 53: proto.CreateTemplateResponse
+	(*DeleteTemplateRequest)(nil),           // 54: proto.DeleteTemplateRequest
+	(*DeleteTemplateResponse)(nil),          // 55: proto.DeleteTemplateResponse
+	(*ApplyTemplateRequest)(nil),            // 56: proto.ApplyTemplateRequest
+	(*ApplyTemplateResponse)(nil),           // 57: proto.ApplyTemplateResponse
+	(*ListArchivedTasksRequest)(nil),        // 58: proto.ListArchivedTasksRequest
+	(*ListArchivedTasksResponse)(nil),       // 59: proto.ListArchivedTasksResponse
+	(*RestoreArchivedTaskRequest)(nil),      // 60: proto.RestoreArchivedTaskRequest
+	(*RestoreArchivedTaskResponse)(nil),     // 61: proto.RestoreArchivedTaskResponse
+	(*AddTaskCommentRequest)(nil),           // 62: proto.AddTaskCommentRequest
+	(*AddTaskCommentResponse)(nil),          // 63: proto.AddTaskCommentResponse
+	(*ListTaskCommentsRequest)(nil),         // 64: proto.ListTaskCommentsRequest
+	(*ListTaskCommentsResponse)(nil),        // 65: proto.ListTaskCommentsResponse
+	(*UploadAttachmentRequest)(nil),         // 66: proto.UploadAttachmentRequest
+	(*UploadAttachmentResponse)(nil),        // 67: proto.UploadAttachmentResponse
+	(*ListAttachmentsRequest)(nil),          // 68: proto.ListAttachmentsRequest
+	(*ListAttachmentsResponse)(nil),         // 69: proto.ListAttachmentsResponse
+	(*DownloadAttachmentRequest)(nil),       // 70: proto.DownloadAttachmentRequest
+	(*DownloadAttachmentResponse)(nil),      // 71: proto.DownloadAttachmentResponse
+	nil,                                     // 72: proto.ListScheduledTasksResponse.FireTimesEntry
+	nil,                                     // 73: proto.ApplyTemplateRequest.VariablesEntry
+	(*UploadAttachmentRequest_Details)(nil), // 74: proto.UploadAttachmentRequest.Details
+	(*Task)(nil),                            // 75: proto.Task
+	(Task_TaskState)(0),                     // 76: proto.Task.TaskState
+	(*Operation)(nil),                       // 77: proto.Operation
+	(*ScheduledTask)(nil),                   // 78: proto.ScheduledTask
+	(*FireTimes)(nil),                       // 79: proto.FireTimes
+	(ScheduledTask_ExpressionType)(0),       // 80: proto.ScheduledTask.ExpressionType
+	(ScheduledTask_Recurrence)(0),           // 81: proto.ScheduledTask.Recurrence
+	(*Template)(nil),                        // 82: proto.Template
+	(*TemplateTask)(nil),                    // 83: proto.TemplateTask
+	(*TaskComment)(nil),                     // 84: proto.TaskComment
+	(*Attachment)(nil),                      // 85: proto.Attachment
 }
 var file_todo_transport_proto_depIdxs = []int32{
-	23, // 0: proto.GetTaskResponse.task:type_name -> proto.Task
-	23, // 1: proto.ListTasksResponse.tasks:type_name -> proto.Task
-	0,  // 2: proto.UpdateTaskRequest.state:type_name -> proto.UpdateTaskRequest.TaskState
-	24, // 3: proto.GetScheduledTaskResponse.scheduled_task:type_name -> proto.ScheduledTask
-	24, // 4: proto.ListScheduledTasksResponse.scheduled_tasks:type_name -> proto.ScheduledTask
-	5,  // [5:5] is the sub-list for method output_type
-	5,  // [5:5] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	3,  // 0: proto.GetSystemInfoResponse.scheduler:type_name -> proto.SchedulerInfo
+	75, // 1: proto.GetTaskResponse.task:type_name -> proto.Task
+	75, // 2: proto.ListTasksResponse.tasks:type_name -> proto.Task
+	0,  // 3: proto.UpdateTaskRequest.state:type_name -> proto.UpdateTaskRequest.TaskState
+	75, // 4: proto.UpdateTaskResponse.task:type_name -> proto.Task
+	76, // 5: proto.TaskFilter.state:type_name -> proto.Task.TaskState
+	14, // 6: proto.BatchUpdateTasksRequest.filter:type_name -> proto.TaskFilter
+	0,  // 7: proto.BatchUpdateTasksRequest.state:type_name -> proto.UpdateTaskRequest.TaskState
+	15, // 8: proto.BatchUpdateTasksResponse.results:type_name -> proto.BatchTaskResult
+	14, // 9: proto.BatchDeleteTasksRequest.filter:type_name -> proto.TaskFilter
+	15, // 10: proto.BatchDeleteTasksResponse.results:type_name -> proto.BatchTaskResult
+	77, // 11: proto.ListOperationsResponse.operations:type_name -> proto.Operation
+	77, // 12: proto.UndoOperationResponse.operation:type_name -> proto.Operation
+	26, // 13: proto.GetTaskStatsResponse.completed_per_day:type_name -> proto.PeriodCount
+	26, // 14: proto.GetTaskStatsResponse.completed_per_week:type_name -> proto.PeriodCount
+	27, // 15: proto.GetTaskStatsResponse.open_by_age:type_name -> proto.AgeBucket
+	28, // 16: proto.GetTaskStatsResponse.progress:type_name -> proto.TaskProgress
+	78, // 17: proto.GetScheduledTaskResponse.scheduled_task:type_name -> proto.ScheduledTask
+	79, // 18: proto.GetScheduledTaskResponse.fire_times:type_name -> proto.FireTimes
+	78, // 19: proto.ListScheduledTasksResponse.scheduled_tasks:type_name -> proto.ScheduledTask
+	72, // 20: proto.ListScheduledTasksResponse.fire_times:type_name -> proto.ListScheduledTasksResponse.FireTimesEntry
+	80, // 21: proto.CreateScheduledTaskRequest.expression_type:type_name -> proto.ScheduledTask.ExpressionType
+	81, // 22: proto.CreateScheduledTaskRequest.recurrence:type_name -> proto.ScheduledTask.Recurrence
+	80, // 23: proto.UpdateScheduledTaskRequest.expression_type:type_name -> proto.ScheduledTask.ExpressionType
+	81, // 24: proto.UpdateScheduledTaskRequest.recurrence:type_name -> proto.ScheduledTask.Recurrence
+	78, // 25: proto.GetScheduledTaskHistoryResponse.scheduled_task:type_name -> proto.ScheduledTask
+	75, // 26: proto.GetScheduledTaskHistoryResponse.tasks:type_name -> proto.Task
+	45, // 27: proto.GetScheduledTaskHistoryResponse.summary:type_name -> proto.ScheduledTaskHistorySummary
+	80, // 28: proto.PreviewScheduleRequest.expression_type:type_name -> proto.ScheduledTask.ExpressionType
+	81, // 29: proto.PreviewScheduleRequest.recurrence:type_name -> proto.ScheduledTask.Recurrence
+	79, // 30: proto.PreviewScheduleResponse.fire_times:type_name -> proto.FireTimes
+	80, // 31: proto.PreviewScheduleResponse.expression_type:type_name -> proto.ScheduledTask.ExpressionType
+	81, // 32: proto.PreviewScheduleResponse.recurrence:type_name -> proto.ScheduledTask.Recurrence
+	82, // 33: proto.ListTemplatesResponse.templates:type_name -> proto.Template
+	82, // 34: proto.GetTemplateResponse.template:type_name -> proto.Template
+	83, // 35: proto.CreateTemplateRequest.tasks:type_name -> proto.TemplateTask
+	73, // 36: proto.ApplyTemplateRequest.variables:type_name -> proto.ApplyTemplateRequest.VariablesEntry
+	75, // 37: proto.ListArchivedTasksResponse.tasks:type_name -> proto.Task
+	84, // 38: proto.AddTaskCommentResponse.comment:type_name -> proto.TaskComment
+	84, // 39: proto.ListTaskCommentsResponse.comments:type_name -> proto.TaskComment
+	74, // 40: proto.UploadAttachmentRequest.details:type_name -> proto.UploadAttachmentRequest.Details
+	85, // 41: proto.UploadAttachmentResponse.attachment:type_name -> proto.Attachment
+	85, // 42: proto.ListAttachmentsResponse.attachments:type_name -> proto.Attachment
+	85, // 43: proto.DownloadAttachmentResponse.attachment:type_name -> proto.Attachment
+	79, // 44: proto.ListScheduledTasksResponse.FireTimesEntry.value:type_name -> proto.FireTimes
+	45, // [45:45] is the sub-list for method output_type
+	45, // [45:45] is the sub-list for method input_type
+	45, // [45:45] is the sub-list for extension type_name
+	45, // [45:45] is the sub-list for extension extendee
+	0,  // [0:45] is the sub-list for field type_name
 }
 
 func init() { file_todo_transport_proto_init() }
@@ -1297,13 +4682,23 @@ func file_todo_transport_proto_init() {
 		return
 	}
 	file_todo_message_proto_init()
+	file_todo_transport_proto_msgTypes[9].OneofWrappers = []any{}
+	file_todo_transport_proto_msgTypes[15].OneofWrappers = []any{}
+	file_todo_transport_proto_msgTypes[65].OneofWrappers = []any{
+		(*UploadAttachmentRequest_Details_)(nil),
+		(*UploadAttachmentRequest_Chunk)(nil),
+	}
+	file_todo_transport_proto_msgTypes[70].OneofWrappers = []any{
+		(*DownloadAttachmentResponse_Attachment)(nil),
+		(*DownloadAttachmentResponse_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_todo_transport_proto_rawDesc), len(file_todo_transport_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   22,
+			NumMessages:   74,
 			NumExtensions: 0,
 			NumServices:   0,
 		},